@@ -2,23 +2,31 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 	"net/http"
+	"os"
 	"time"
 
+	"github.com/augustdev/autoclip/internal/auth"
 	"github.com/augustdev/autoclip/internal/bootstrap"
 	"github.com/augustdev/autoclip/internal/deployments"
 	"github.com/augustdev/autoclip/internal/gitserver"
+	"github.com/augustdev/autoclip/internal/internalgit"
 	"github.com/augustdev/autoclip/internal/storage/pg"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/worker"
 	"go.uber.org/fx"
 )
 
 type config struct {
 	fx.Out
 
-	Db        pg.DbConfig
-	Temporal  bootstrap.TemporalClientConfig
-	GitServer gitserver.Config
+	Auth        auth.Config
+	Db          pg.DbConfig
+	Temporal    bootstrap.TemporalClientConfig
+	GitServer   gitserver.Config
+	InternalGit internalgit.Config
 }
 
 func main() {
@@ -34,13 +42,18 @@ func main() {
 			pg.NewUserQueries,
 			pg.NewGitHubCredsQueries,
 			pg.NewGitTokenQueries,
+			pg.NewInternalReposQueries,
 			pg.NewClusterMap,
 			bootstrap.CreateTemporalClient,
 			deployments.NewService,
 			gitserver.NewServer,
+			gitserver.NewActivities,
+			newTemporalWorker,
 		),
 		fx.Invoke(
+			gitserver.RegisterWorkflowsAndActivities,
 			startGitServer,
+			startWorker,
 		),
 	).Run()
 }
@@ -74,3 +87,34 @@ func startGitServer(lc fx.Lifecycle, server *gitserver.Server, config gitserver.
 		},
 	})
 }
+
+// newTemporalWorker runs the repo gc. It stops within the server's stop
+// timeout; a gc cut short is retried.
+func newTemporalWorker(c client.Client) worker.Worker {
+	return worker.New(c, gitserver.TaskQueue, worker.Options{
+		WorkerStopTimeout: 10 * time.Second,
+	})
+}
+
+func startWorker(lc fx.Lifecycle, c client.Client, w worker.Worker, logger *slog.Logger) {
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			logger.Info("Starting temporal worker")
+			go func() {
+				if err := w.Run(worker.InterruptCh()); err != nil {
+					logger.Error(fmt.Sprintf("Worker failed: %v", err))
+					os.Exit(1)
+				}
+			}()
+			if err := gitserver.StartGCSchedule(ctx, c); err != nil {
+				logger.Error(fmt.Sprintf("failed to start gc schedule: %v", err))
+			}
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			logger.Info("Stopping temporal worker")
+			w.Stop()
+			return nil
+		},
+	})
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/augustdev/autoclip/internal/account"
+	"github.com/augustdev/autoclip/internal/auth"
+	"github.com/augustdev/autoclip/internal/bootstrap"
+	"github.com/augustdev/autoclip/internal/deployments"
+	"github.com/augustdev/autoclip/internal/resources"
+	"github.com/augustdev/autoclip/internal/storage/pg"
+	"github.com/augustdev/autoclip/internal/turso"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/worker"
+	"go.uber.org/fx"
+)
+
+type config struct {
+	fx.Out
+
+	Db       pg.DbConfig
+	Temporal bootstrap.TemporalClientConfig
+	Turso    turso.Config
+	Auth     auth.Config
+}
+
+func main() {
+	fx.New(
+		fx.StopTimeout(1*time.Minute),
+		fx.Provide(
+			bootstrap.NewLogger,
+			bootstrap.LoadConfig[config],
+			pg.NewDatabase,
+			pg.NewProjectQueries,
+			pg.NewResourceQueries,
+			pg.NewServiceQueries,
+			pg.NewDeploymentQueries,
+			pg.NewUserQueries,
+			pg.NewGitHubCredsQueries,
+			pg.NewInternalReposQueries,
+			pg.NewGitTokenQueries,
+			pg.NewZoneRecordQueries,
+			pg.NewClusterMap,
+			turso.NewClient,
+			bootstrap.CreateTemporalClient,
+			newTemporalWorker,
+			account.NewActivities,
+			resources.NewActivities,
+			resources.NewService,
+			deployments.NewService,
+			deployments.NewActivities,
+		),
+		fx.Invoke(
+			account.RegisterWorkflowsAndActivities,
+			resources.RegisterWorkflowsAndActivities,
+			deployments.RegisterWorkflowsAndActivities,
+			startWorker,
+		),
+	).Run()
+}
+
+func newTemporalWorker(c client.Client) worker.Worker {
+	return worker.New(c, "default", worker.Options{
+		WorkerStopTimeout: 10 * time.Minute,
+	})
+}
+
+func startWorker(lc fx.Lifecycle, c client.Client, w worker.Worker, logger *slog.Logger) {
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			logger.Info("Starting temporal worker")
+			go func() {
+				if err := w.Run(worker.InterruptCh()); err != nil {
+					logger.Error(fmt.Sprintf("Worker failed: %v", err))
+					os.Exit(1)
+				}
+			}()
+			if err := resources.StartUsageSchedule(ctx, c); err != nil {
+				logger.Error(fmt.Sprintf("failed to start usage schedule: %v", err))
+			}
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			logger.Info("Stopping temporal worker")
+			w.Stop()
+			return nil
+		},
+	})
+}
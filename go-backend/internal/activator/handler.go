@@ -0,0 +1,110 @@
+package activator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/augustdev/autoclip/internal/deployments"
+)
+
+const (
+	// wakeTimeout bounds how long a request is held while its service wakes.
+	wakeTimeout = 4 * time.Minute
+
+	// awakeTTL is how long a woken upstream is reused for requests still
+	// routed here while Traefik picks up the restored Ingress.
+	awakeTTL = time.Minute
+)
+
+type Config struct {
+	Port string
+}
+
+// Handler receives requests for sleeping services, holds them until the
+// service has woken, then forwards them to it.
+type Handler struct {
+	deployService *deployments.Service
+	logger        *slog.Logger
+
+	mu    sync.Mutex
+	awake map[string]awakeUpstream
+}
+
+type awakeUpstream struct {
+	target  *url.URL
+	expires time.Time
+}
+
+func NewHandler(deployService *deployments.Service, logger *slog.Logger) *Handler {
+	return &Handler{
+		deployService: deployService,
+		logger:        logger,
+		awake:         make(map[string]awakeUpstream),
+	}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	host := r.Host
+	if hostname, _, err := net.SplitHostPort(host); err == nil {
+		host = hostname
+	}
+
+	target, err := h.upstream(r.Context(), host)
+	if err != nil {
+		if errors.Is(err, deployments.ErrNoServiceForHost) {
+			http.NotFound(w, r)
+			return
+		}
+		h.logger.Error("Failed to wake service", "host", host, "error", err)
+		w.Header().Set("Retry-After", "5")
+		http.Error(w, "service is waking up, try again shortly", http.StatusServiceUnavailable)
+		return
+	}
+
+	proxy := &httputil.ReverseProxy{
+		Rewrite: func(pr *httputil.ProxyRequest) {
+			pr.SetURL(target)
+			pr.Out.Host = pr.In.Host
+			// Keep the forwarding headers Traefik set for the client.
+			for _, k := range []string{"X-Forwarded-For", "X-Forwarded-Host", "X-Forwarded-Proto"} {
+				if v := pr.In.Header.Values(k); len(v) > 0 {
+					pr.Out.Header[k] = v
+				}
+			}
+		},
+	}
+	proxy.ServeHTTP(w, r)
+}
+
+func (h *Handler) upstream(ctx context.Context, host string) (*url.URL, error) {
+	h.mu.Lock()
+	cached, ok := h.awake[host]
+	h.mu.Unlock()
+	if ok && time.Now().Before(cached.expires) {
+		return cached.target, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, wakeTimeout)
+	defer cancel()
+	result, err := h.deployService.WakeService(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+
+	target := &url.URL{
+		Scheme: "http",
+		Host:   fmt.Sprintf("%s.%s.svc.cluster.local:%d", result.DeploymentName, result.Namespace, result.Port),
+	}
+	h.mu.Lock()
+	h.awake[host] = awakeUpstream{target: target, expires: time.Now().Add(awakeTTL)}
+	h.mu.Unlock()
+	return target, nil
+}
@@ -11,7 +11,9 @@ import (
 	"k8s.io/client-go/util/homedir"
 )
 
-func k8sRestConfig() (*rest.Config, error) {
+// NewK8sRestConfig uses the in-cluster config when running as a pod and
+// ~/.kube/config otherwise.
+func NewK8sRestConfig() (*rest.Config, error) {
 	config, err := rest.InClusterConfig()
 	if err != nil {
 		slog.Info("Not running in-cluster, falling back to kubeconfig")
@@ -25,7 +27,7 @@ func k8sRestConfig() (*rest.Config, error) {
 }
 
 func NewK8sClient() (kubernetes.Interface, error) {
-	config, err := k8sRestConfig()
+	config, err := NewK8sRestConfig()
 	if err != nil {
 		return nil, err
 	}
@@ -33,7 +35,7 @@ func NewK8sClient() (kubernetes.Interface, error) {
 }
 
 func NewK8sDynamicClient() (dynamic.Interface, error) {
-	config, err := k8sRestConfig()
+	config, err := NewK8sRestConfig()
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,27 @@
+// Package cron starts the Temporal cron workflows the workers own.
+package cron
+
+import (
+	"context"
+	"errors"
+
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/sdk/client"
+)
+
+// Start starts workflow with args as a cron under id on taskQueue. Workers
+// call it each time they start, so a cron already running under id is left
+// as it is, schedule included; changing the schedule takes terminating it.
+func Start(ctx context.Context, c client.Client, id, taskQueue, schedule string, workflow any, args ...any) error {
+	_, err := c.ExecuteWorkflow(ctx, client.StartWorkflowOptions{
+		ID:                                       id,
+		TaskQueue:                                taskQueue,
+		CronSchedule:                             schedule,
+		WorkflowExecutionErrorWhenAlreadyStarted: true,
+	}, workflow, args...)
+	var started *serviceerror.WorkflowExecutionAlreadyStarted
+	if errors.As(err, &started) {
+		return nil
+	}
+	return err
+}
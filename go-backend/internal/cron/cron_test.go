@@ -0,0 +1,47 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/mocks"
+)
+
+func noopWorkflow() error { return nil }
+
+func TestStart(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		wantErr bool
+	}{
+		{name: "started"},
+		{name: "already running", err: serviceerror.NewWorkflowExecutionAlreadyStarted("running", "", "run-1")},
+		{name: "unavailable", err: errors.New("connection refused"), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := mocks.NewClient(t)
+			var run client.WorkflowRun
+			if tt.err == nil {
+				run = mocks.NewWorkflowRun(t)
+			}
+			c.On("ExecuteWorkflow", mock.Anything,
+				mock.MatchedBy(func(o client.StartWorkflowOptions) bool {
+					return o.ID == "nightly" && o.TaskQueue == "tq" && o.CronSchedule == "0 3 * * *" &&
+						o.WorkflowExecutionErrorWhenAlreadyStarted
+				}),
+				mock.Anything, "arg",
+			).Return(run, tt.err).Once()
+
+			err := Start(context.Background(), c, "nightly", "tq", "0 3 * * *", noopWorkflow, "arg")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Start() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,389 @@
+package deployments
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/augustdev/autoclip/internal/dns"
+	"github.com/augustdev/autoclip/internal/internalgit"
+	"github.com/augustdev/autoclip/internal/k8sdeployments"
+	"github.com/augustdev/autoclip/internal/resources"
+	"github.com/augustdev/autoclip/internal/storage/pg/generated/gittokens"
+	"github.com/augustdev/autoclip/internal/storage/pg/generated/internalrepos"
+	"github.com/augustdev/autoclip/internal/storage/pg/generated/services"
+	"github.com/augustdev/autoclip/internal/storage/pg/generated/zonerecords"
+	"github.com/jackc/pgx/v5"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/worker"
+	"go.temporal.io/sdk/workflow"
+)
+
+// ProjectTaskQueue is served by cmd/worker. Work inside a cluster, such as
+// deleting a namespace, is started on that cluster's queue instead.
+const ProjectTaskQueue = "default"
+
+// teardownPageSize is how many services or resources a teardown lists at a
+// time.
+const teardownPageSize = 100
+
+// DeleteProjectWorkflowID names the deletion of a project, so deleting it
+// twice joins the run already under way.
+func DeleteProjectWorkflowID(projectID string) string {
+	return fmt.Sprintf("delete-project-%s", projectID)
+}
+
+type Activities struct {
+	service          *Service
+	resourcesService *resources.Service
+	internalReposQ   internalrepos.Querier
+	gitTokensQ       gittokens.Querier
+	zoneRecordsQ     zonerecords.Querier
+	logger           *slog.Logger
+}
+
+func NewActivities(
+	service *Service,
+	resourcesService *resources.Service,
+	internalReposQ internalrepos.Querier,
+	gitTokensQ gittokens.Querier,
+	zoneRecordsQ zonerecords.Querier,
+	logger *slog.Logger,
+) *Activities {
+	return &Activities{
+		service:          service,
+		resourcesService: resourcesService,
+		internalReposQ:   internalReposQ,
+		gitTokensQ:       gitTokensQ,
+		zoneRecordsQ:     zoneRecordsQ,
+		logger:           logger,
+	}
+}
+
+type DeleteProjectWorkflowInput struct {
+	ProjectID string
+}
+
+type DeleteProjectWorkflowResult struct {
+	DomainsRemoved   int
+	ServicesDeleted  int
+	ResourcesDeleted int
+	ReposDeleted     int
+}
+
+// ProjectTeardown is everything DeleteProjectWorkflow has to remove besides
+// the database rows.
+type ProjectTeardown struct {
+	UserID    string
+	Ref       string
+	Namespace string
+	Services  []ServiceTeardown
+	Domains   []DomainTeardown
+	// Repos are the full names of the project's internal repos, which the
+	// git server deletes from its disk.
+	Repos []string
+	// ClusterTaskQueues are the queues of every cluster, as the namespace
+	// may be left in one the project no longer has services in.
+	ClusterTaskQueues []string
+}
+
+type ServiceTeardown struct {
+	ServiceID string
+	Name      string
+	TaskQueue string
+}
+
+type DomainTeardown struct {
+	ZoneRecordID string
+	Zone         string
+	Name         string
+	ServiceName  string
+}
+
+type DeleteProjectResourcesInput struct {
+	UserID     string
+	ProjectRef string
+}
+
+// ListProjectTeardown gathers what deleting a project involves, or returns
+// nil when the project is already gone.
+func (a *Activities) ListProjectTeardown(ctx context.Context, projectID string) (*ProjectTeardown, error) {
+	project, err := a.service.projectsQ.GetProjectByID(ctx, projectID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get project: %w", err)
+	}
+
+	teardown := &ProjectTeardown{
+		UserID:    project.UserID,
+		Ref:       project.Ref,
+		Namespace: k8sdeployments.NamespaceName(project.UserID, project.Ref),
+	}
+
+	for offset := int32(0); ; offset += teardownPageSize {
+		page, err := a.service.servicesQ.ListServicesByProjectID(ctx, services.ListServicesByProjectIDParams{
+			ProjectID: projectID,
+			Limit:     teardownPageSize,
+			Offset:    offset,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("list services: %w", err)
+		}
+		for _, svc := range page {
+			cluster, ok := a.service.clusters[svc.Region]
+			if !ok {
+				return nil, fmt.Errorf("unknown region %q for service %s", svc.Region, svc.ID)
+			}
+			var name string
+			if svc.Name != nil {
+				name = *svc.Name
+			}
+			teardown.Services = append(teardown.Services, ServiceTeardown{
+				ServiceID: svc.ID,
+				Name:      k8sdeployments.ServiceName(name),
+				TaskQueue: cluster.TaskQueue,
+			})
+		}
+		if len(page) < teardownPageSize {
+			break
+		}
+	}
+
+	records, err := a.zoneRecordsQ.ListByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("list zone records: %w", err)
+	}
+	for _, zr := range records {
+		var serviceName string
+		if zr.ServiceName != nil {
+			serviceName = *zr.ServiceName
+		}
+		teardown.Domains = append(teardown.Domains, DomainTeardown{
+			ZoneRecordID: zr.ID,
+			Zone:         zr.Zone,
+			Name:         zr.Name,
+			ServiceName:  k8sdeployments.ServiceName(serviceName),
+		})
+	}
+
+	repos, err := a.internalReposQ.ListInternalReposByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("list repos: %w", err)
+	}
+	for _, repo := range repos {
+		teardown.Repos = append(teardown.Repos, repo.FullName)
+	}
+
+	queues := make(map[string]bool, len(a.service.clusters))
+	for _, cluster := range a.service.clusters {
+		if !queues[cluster.TaskQueue] {
+			queues[cluster.TaskQueue] = true
+			teardown.ClusterTaskQueues = append(teardown.ClusterTaskQueues, cluster.TaskQueue)
+		}
+	}
+	sort.Strings(teardown.ClusterTaskQueues)
+
+	return teardown, nil
+}
+
+func (a *Activities) DeleteZoneRecord(ctx context.Context, zoneRecordID string) error {
+	if err := a.zoneRecordsQ.Delete(ctx, zoneRecordID); err != nil {
+		return fmt.Errorf("delete zone record: %w", err)
+	}
+	return nil
+}
+
+// DeleteProjectResources deletes every resource in the project. In-cluster
+// ones are torn down by their own workflows, which this only starts.
+func (a *Activities) DeleteProjectResources(ctx context.Context, input DeleteProjectResourcesInput) (int, error) {
+	var all []*resources.Resource
+	for offset := int32(0); ; offset += teardownPageSize {
+		page, err := a.resourcesService.ListResources(ctx, input.UserID, input.ProjectRef, teardownPageSize, offset)
+		if err != nil {
+			return 0, err
+		}
+		all = append(all, page...)
+		if len(page) < teardownPageSize {
+			break
+		}
+	}
+
+	for _, r := range all {
+		if err := a.resourcesService.DeleteResource(ctx, input.UserID, r.ID); err != nil {
+			return 0, fmt.Errorf("delete resource %s: %w", r.Name, err)
+		}
+	}
+	return len(all), nil
+}
+
+// RevokeProjectRepoTokens revokes the tokens of the project's internal
+// repos. A push creates a repo missing from disk, so this runs before the
+// git server deletes them.
+func (a *Activities) RevokeProjectRepoTokens(ctx context.Context, projectID string) error {
+	repos, err := a.internalReposQ.ListInternalReposByProjectID(ctx, projectID)
+	if err != nil {
+		return fmt.Errorf("list repos: %w", err)
+	}
+	for _, repo := range repos {
+		repoID := repo.ID
+		if err := a.gitTokensQ.RevokeTokensByRepoID(ctx, &repoID); err != nil {
+			return fmt.Errorf("revoke tokens of %s: %w", repo.FullName, err)
+		}
+	}
+	return nil
+}
+
+// DeleteProjectRepos deletes the rows of the project's internal repos once
+// they are off the git server's disk.
+func (a *Activities) DeleteProjectRepos(ctx context.Context, projectID string) (int, error) {
+	repos, err := a.internalReposQ.ListInternalReposByProjectID(ctx, projectID)
+	if err != nil {
+		return 0, fmt.Errorf("list repos: %w", err)
+	}
+	for _, repo := range repos {
+		if err := a.internalReposQ.DeleteInternalRepo(ctx, repo.ID); err != nil {
+			return 0, fmt.Errorf("delete repo %s: %w", repo.FullName, err)
+		}
+	}
+	return len(repos), nil
+}
+
+// DeleteProjectRecord deletes the project row, and with it the rows still
+// referencing it such as soft-deleted services.
+func (a *Activities) DeleteProjectRecord(ctx context.Context, projectID string) error {
+	if err := a.service.projectsQ.DeleteProject(ctx, projectID); err != nil {
+		return fmt.Errorf("delete project: %w", err)
+	}
+	a.logger.Info("Deleted project", "projectID", projectID)
+	return nil
+}
+
+// DeleteProjectWorkflow removes a project: its custom domains, services,
+// resources, internal repos and namespace, then the project itself. It
+// stops at the first failure, leaving the project in place so deleting it
+// again picks up where this run left off.
+func DeleteProjectWorkflow(ctx workflow.Context, input DeleteProjectWorkflowInput) (DeleteProjectWorkflowResult, error) {
+	logger := workflow.GetLogger(ctx)
+	logger.Info("Starting project delete", "projectID", input.ProjectID)
+
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: 5 * time.Minute,
+		RetryPolicy: &temporal.RetryPolicy{
+			InitialInterval:    time.Second,
+			BackoffCoefficient: 2.0,
+			MaximumInterval:    30 * time.Second,
+			MaximumAttempts:    3,
+		},
+	})
+
+	var activities *Activities
+	var teardown *ProjectTeardown
+	if err := workflow.ExecuteActivity(ctx, activities.ListProjectTeardown, input.ProjectID).Get(ctx, &teardown); err != nil {
+		return DeleteProjectWorkflowResult{}, err
+	}
+	if teardown == nil {
+		logger.Info("Project already deleted", "projectID", input.ProjectID)
+		return DeleteProjectWorkflowResult{}, nil
+	}
+
+	var result DeleteProjectWorkflowResult
+
+	for _, domain := range teardown.Domains {
+		detachCtx := workflow.WithChildOptions(ctx, workflow.ChildWorkflowOptions{
+			WorkflowID: fmt.Sprintf("detach-dz-%s", domain.ZoneRecordID),
+			TaskQueue:  dns.TaskQueue,
+		})
+		if err := workflow.ExecuteChildWorkflow(detachCtx, dns.DetachSubdomainWorkflow, dns.DetachSubdomainInput{
+			Zone:        domain.Zone,
+			Name:        domain.Name,
+			Namespace:   teardown.Namespace,
+			ServiceName: domain.ServiceName,
+		}).Get(ctx, nil); err != nil {
+			return result, fmt.Errorf("detach %s.%s: %w", domain.Name, domain.Zone, err)
+		}
+		if err := workflow.ExecuteActivity(ctx, activities.DeleteZoneRecord, domain.ZoneRecordID).Get(ctx, nil); err != nil {
+			return result, err
+		}
+		result.DomainsRemoved++
+	}
+
+	for _, svc := range teardown.Services {
+		deleteCtx := workflow.WithChildOptions(ctx, workflow.ChildWorkflowOptions{
+			WorkflowID: fmt.Sprintf("delete-svc-%s", svc.ServiceID),
+			TaskQueue:  svc.TaskQueue,
+		})
+		var deleted k8sdeployments.DeleteServiceWorkflowResult
+		if err := workflow.ExecuteChildWorkflow(deleteCtx, k8sdeployments.DeleteServiceWorkflow, k8sdeployments.DeleteServiceWorkflowInput{
+			ServiceID: svc.ServiceID,
+			Namespace: teardown.Namespace,
+			Name:      svc.Name,
+		}).Get(ctx, &deleted); err != nil {
+			return result, fmt.Errorf("delete service %s: %w", svc.Name, err)
+		}
+		if deleted.Status == k8sdeployments.StatusFailed {
+			return result, fmt.Errorf("delete service %s: %s", svc.Name, deleted.ErrorMessage)
+		}
+		result.ServicesDeleted++
+	}
+
+	if err := workflow.ExecuteActivity(ctx, activities.DeleteProjectResources, DeleteProjectResourcesInput{
+		UserID:     teardown.UserID,
+		ProjectRef: teardown.Ref,
+	}).Get(ctx, &result.ResourcesDeleted); err != nil {
+		return result, err
+	}
+
+	if err := workflow.ExecuteActivity(ctx, activities.RevokeProjectRepoTokens, input.ProjectID).Get(ctx, nil); err != nil {
+		return result, err
+	}
+	gitCtx := workflow.WithTaskQueue(ctx, internalgit.GitServerTaskQueue)
+	for _, repo := range teardown.Repos {
+		if err := workflow.ExecuteActivity(gitCtx, internalgit.DeleteRepoActivityName, internalgit.DeleteRepoInput{
+			FullName: repo,
+		}).Get(ctx, nil); err != nil {
+			return result, fmt.Errorf("delete repo %s from disk: %w", repo, err)
+		}
+	}
+	if err := workflow.ExecuteActivity(ctx, activities.DeleteProjectRepos, input.ProjectID).Get(ctx, &result.ReposDeleted); err != nil {
+		return result, err
+	}
+
+	for _, taskQueue := range teardown.ClusterTaskQueues {
+		nsCtx := workflow.WithChildOptions(ctx, workflow.ChildWorkflowOptions{
+			WorkflowID: fmt.Sprintf("delete-ns-%s-%s", teardown.Namespace, taskQueue),
+			TaskQueue:  taskQueue,
+		})
+		if err := workflow.ExecuteChildWorkflow(nsCtx, k8sdeployments.DeleteNamespaceWorkflow, k8sdeployments.DeleteNamespaceInput{
+			Namespace: teardown.Namespace,
+		}).Get(ctx, nil); err != nil {
+			return result, fmt.Errorf("delete namespace on %s: %w", taskQueue, err)
+		}
+	}
+
+	if err := workflow.ExecuteActivity(ctx, activities.DeleteProjectRecord, input.ProjectID).Get(ctx, nil); err != nil {
+		return result, err
+	}
+
+	logger.Info("Project deleted",
+		"projectID", input.ProjectID,
+		"services", result.ServicesDeleted,
+		"resources", result.ResourcesDeleted,
+		"repos", result.ReposDeleted,
+		"domains", result.DomainsRemoved)
+	return result, nil
+}
+
+func RegisterWorkflowsAndActivities(w worker.Worker, activities *Activities) {
+	w.RegisterWorkflow(DeleteProjectWorkflow)
+	w.RegisterActivity(activities.ListProjectTeardown)
+	w.RegisterActivity(activities.DeleteZoneRecord)
+	w.RegisterActivity(activities.DeleteProjectResources)
+	w.RegisterActivity(activities.RevokeProjectRepoTokens)
+	w.RegisterActivity(activities.DeleteProjectRepos)
+	w.RegisterActivity(activities.DeleteProjectRecord)
+}
@@ -0,0 +1,149 @@
+package deployments
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/augustdev/autoclip/internal/dns"
+	"github.com/augustdev/autoclip/internal/internalgit"
+	"github.com/augustdev/autoclip/internal/k8sdeployments"
+	"github.com/stretchr/testify/mock"
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/testsuite"
+)
+
+const testProjectID = "proj-1"
+
+func newDeleteProjectTestEnv(t *testing.T) (*testsuite.TestWorkflowEnvironment, *Activities) {
+	t.Helper()
+	var suite testsuite.WorkflowTestSuite
+	env := suite.NewTestWorkflowEnvironment()
+	a := &Activities{}
+	env.RegisterActivity(a)
+	// The git server's worker registers DeleteRepo by name.
+	env.RegisterActivityWithOptions(func(context.Context, internalgit.DeleteRepoInput) error { return nil },
+		activity.RegisterOptions{Name: internalgit.DeleteRepoActivityName})
+	env.RegisterWorkflow(dns.DetachSubdomainWorkflow)
+	env.RegisterWorkflow(k8sdeployments.DeleteServiceWorkflow)
+	env.RegisterWorkflow(k8sdeployments.DeleteNamespaceWorkflow)
+	t.Cleanup(func() { env.AssertExpectations(t) })
+	return env, a
+}
+
+func testTeardown() *ProjectTeardown {
+	return &ProjectTeardown{
+		UserID:    "user1",
+		Ref:       "staging",
+		Namespace: "dp-user1-staging",
+		Services: []ServiceTeardown{
+			{ServiceID: "svc-1", Name: "api", TaskQueue: "tq-eu"},
+			{ServiceID: "svc-2", Name: "web", TaskQueue: "tq-us"},
+		},
+		Domains: []DomainTeardown{
+			{ZoneRecordID: "zr-1", Zone: "apps.example.com", Name: "api", ServiceName: "api"},
+		},
+		Repos:             []string{"user1/api"},
+		ClusterTaskQueues: []string{"tq-eu", "tq-us"},
+	}
+}
+
+func TestDeleteProjectWorkflow(t *testing.T) {
+	env, a := newDeleteProjectTestEnv(t)
+
+	env.OnActivity(a.ListProjectTeardown, mock.Anything, testProjectID).Return(testTeardown(), nil).Once()
+	env.OnWorkflow(dns.DetachSubdomainWorkflow, mock.Anything, dns.DetachSubdomainInput{
+		Zone: "apps.example.com", Name: "api", Namespace: "dp-user1-staging", ServiceName: "api",
+	}).Return(dns.DetachSubdomainResult{Status: "deleted"}, nil).Once()
+	env.OnActivity(a.DeleteZoneRecord, mock.Anything, "zr-1").Return(nil).Once()
+	env.OnWorkflow(k8sdeployments.DeleteServiceWorkflow, mock.Anything, mock.Anything).
+		Return(k8sdeployments.DeleteServiceWorkflowResult{Status: k8sdeployments.StatusDeleted}, nil).Twice()
+	env.OnActivity(a.DeleteProjectResources, mock.Anything, DeleteProjectResourcesInput{
+		UserID: "user1", ProjectRef: "staging",
+	}).Return(3, nil).Once()
+	env.OnActivity(a.RevokeProjectRepoTokens, mock.Anything, testProjectID).Return(nil).Once()
+	env.OnActivity(internalgit.DeleteRepoActivityName, mock.Anything, internalgit.DeleteRepoInput{
+		FullName: "user1/api",
+	}).Return(nil).Once()
+	env.OnActivity(a.DeleteProjectRepos, mock.Anything, testProjectID).Return(1, nil).Once()
+	env.OnWorkflow(k8sdeployments.DeleteNamespaceWorkflow, mock.Anything, k8sdeployments.DeleteNamespaceInput{
+		Namespace: "dp-user1-staging",
+	}).Return(nil).Twice()
+	env.OnActivity(a.DeleteProjectRecord, mock.Anything, testProjectID).Return(nil).Once()
+
+	env.ExecuteWorkflow(DeleteProjectWorkflow, DeleteProjectWorkflowInput{ProjectID: testProjectID})
+
+	if err := env.GetWorkflowError(); err != nil {
+		t.Fatalf("workflow failed: %v", err)
+	}
+	var result DeleteProjectWorkflowResult
+	if err := env.GetWorkflowResult(&result); err != nil {
+		t.Fatal(err)
+	}
+	want := DeleteProjectWorkflowResult{DomainsRemoved: 1, ServicesDeleted: 2, ResourcesDeleted: 3, ReposDeleted: 1}
+	if result != want {
+		t.Fatalf("result = %+v, want %+v", result, want)
+	}
+}
+
+func TestDeleteProjectWorkflow_FailedServiceKeepsProject(t *testing.T) {
+	env, a := newDeleteProjectTestEnv(t)
+
+	teardown := testTeardown()
+	teardown.Domains = nil
+	env.OnActivity(a.ListProjectTeardown, mock.Anything, testProjectID).Return(teardown, nil).Once()
+	env.OnWorkflow(k8sdeployments.DeleteServiceWorkflow, mock.Anything, mock.Anything).
+		Return(k8sdeployments.DeleteServiceWorkflowResult{Status: k8sdeployments.StatusFailed, ErrorMessage: "delete ingress: forbidden"}, nil).Once()
+
+	env.ExecuteWorkflow(DeleteProjectWorkflow, DeleteProjectWorkflowInput{ProjectID: testProjectID})
+
+	if err := env.GetWorkflowError(); err == nil {
+		t.Fatal("expected the workflow to fail")
+	}
+	// DeleteProjectRecord has no expectation, so reaching it fails the test.
+}
+
+func TestDeleteProjectWorkflow_FailedRepoDeleteKeepsRows(t *testing.T) {
+	env, a := newDeleteProjectTestEnv(t)
+	teardown := testTeardown()
+	teardown.Domains = nil
+	teardown.Services = nil
+
+	env.OnActivity(a.ListProjectTeardown, mock.Anything, testProjectID).Return(teardown, nil).Once()
+	env.OnActivity(a.DeleteProjectResources, mock.Anything, mock.Anything).Return(0, nil).Once()
+	env.OnActivity(a.RevokeProjectRepoTokens, mock.Anything, testProjectID).Return(nil).Once()
+	env.OnActivity(internalgit.DeleteRepoActivityName, mock.Anything, mock.Anything).
+		Return(errors.New("permission denied")).Once()
+
+	env.ExecuteWorkflow(DeleteProjectWorkflow, DeleteProjectWorkflowInput{ProjectID: testProjectID})
+
+	// The repo rows stay so a retried delete finds the repo again.
+	if err := env.GetWorkflowError(); err == nil {
+		t.Fatal("workflow succeeded, want the repo delete error")
+	}
+}
+
+func TestDeleteProjectWorkflow_AlreadyDeleted(t *testing.T) {
+	env, a := newDeleteProjectTestEnv(t)
+
+	env.OnActivity(a.ListProjectTeardown, mock.Anything, testProjectID).Return(nil, nil).Once()
+
+	env.ExecuteWorkflow(DeleteProjectWorkflow, DeleteProjectWorkflowInput{ProjectID: testProjectID})
+
+	if err := env.GetWorkflowError(); err != nil {
+		t.Fatalf("workflow failed: %v", err)
+	}
+}
+
+func TestValidateProjectRef(t *testing.T) {
+	for _, ref := range []string{"staging", "a", "team-2", "abcdefghij0123456789"} {
+		if err := validateProjectRef(ref); err != nil {
+			t.Errorf("validateProjectRef(%q) = %v, want nil", ref, err)
+		}
+	}
+	for _, ref := range []string{"", "Staging", "-api", "api-", "my_app", "abcdefghij01234567890"} {
+		if err := validateProjectRef(ref); err == nil {
+			t.Errorf("validateProjectRef(%q) = nil, want an error", ref)
+		}
+	}
+}
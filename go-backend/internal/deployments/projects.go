@@ -0,0 +1,175 @@
+package deployments
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/augustdev/autoclip/internal/storage/pg/generated/projects"
+	"github.com/jackc/pgx/v5"
+	"go.temporal.io/sdk/client"
+)
+
+// projectRefPattern is what a project created or renamed by name may be
+// called. The name becomes the project's ref, which ends up in its
+// namespace (dp-<user id>-<ref>), so it is kept DNS-safe and short enough
+// for that to fit in 63 characters.
+var projectRefPattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,18}[a-z0-9])?$`)
+
+// ErrProjectNotFound means the user has no project by that name.
+var ErrProjectNotFound = errors.New("project not found")
+
+func validateProjectRef(ref string) error {
+	if !projectRefPattern.MatchString(ref) {
+		return fmt.Errorf("invalid project name %q: use up to 20 lowercase letters, digits and dashes, starting and ending with a letter or digit", ref)
+	}
+	return nil
+}
+
+func (s *Service) ListProjects(ctx context.Context, userID string, limit, offset int32) ([]projects.Project, error) {
+	list, err := s.projectsQ.ListProjectsByUserID(ctx, projects.ListProjectsByUserIDParams{
+		UserID: userID,
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list projects: %w", err)
+	}
+	return list, nil
+}
+
+// lookupProject returns the user's project by ref without creating it. An
+// empty ref is the default project.
+func (s *Service) lookupProject(ctx context.Context, userID, ref string) (*projects.Project, error) {
+	if ref == "" {
+		ref = "default"
+	}
+	project, err := s.projectsQ.GetProjectByRef(ctx, projects.GetProjectByRefParams{
+		UserID: userID,
+		Ref:    ref,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %s", ErrProjectNotFound, ref)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+	return &project, nil
+}
+
+func (s *Service) CreateProject(ctx context.Context, userID, name string) (*projects.Project, error) {
+	ref := strings.TrimSpace(name)
+	if err := validateProjectRef(ref); err != nil {
+		return nil, err
+	}
+	if err := s.checkProjectRefFree(ctx, userID, ref); err != nil {
+		return nil, err
+	}
+
+	project, err := s.projectsQ.CreateProject(ctx, projects.CreateProjectParams{
+		UserID: userID,
+		Name:   ref,
+		Ref:    ref,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create project: %w", err)
+	}
+	s.logger.Info("created project", "user_id", userID, "project_id", project.ID, "ref", ref)
+	return &project, nil
+}
+
+// RenameProject gives a project a new name, which is also its new ref. A
+// project's namespace and its services' URLs are derived from the ref, so
+// only a project with nothing running in the cluster can be renamed.
+func (s *Service) RenameProject(ctx context.Context, userID, ref, newName string) (*projects.Project, error) {
+	project, err := s.lookupProject(ctx, userID, ref)
+	if err != nil {
+		return nil, err
+	}
+	if project.IsDefault {
+		return nil, fmt.Errorf("the default project can't be renamed")
+	}
+
+	newRef := strings.TrimSpace(newName)
+	if newRef == project.Ref {
+		return project, nil
+	}
+	if err := validateProjectRef(newRef); err != nil {
+		return nil, err
+	}
+	if err := s.checkProjectRefFree(ctx, userID, newRef); err != nil {
+		return nil, err
+	}
+
+	workloads, err := s.projectsQ.CountProjectWorkloads(ctx, project.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count project workloads: %w", err)
+	}
+	if workloads > 0 {
+		return nil, fmt.Errorf("project %s still has %d services or in-cluster resources; their namespace and URLs are derived from the project name, so delete them before renaming it", project.Ref, workloads)
+	}
+
+	renamed, err := s.projectsQ.UpdateProjectName(ctx, projects.UpdateProjectNameParams{
+		ID:   project.ID,
+		Name: newRef,
+		Ref:  newRef,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to rename project: %w", err)
+	}
+	s.logger.Info("renamed project", "project_id", project.ID, "from", project.Ref, "to", newRef)
+	return &renamed, nil
+}
+
+func (s *Service) checkProjectRefFree(ctx context.Context, userID, ref string) error {
+	_, err := s.projectsQ.GetProjectByRef(ctx, projects.GetProjectByRefParams{
+		UserID: userID,
+		Ref:    ref,
+	})
+	if err == nil {
+		return fmt.Errorf("project %s already exists", ref)
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("failed to check project name: %w", err)
+	}
+	return nil
+}
+
+type DeleteProjectResult struct {
+	ProjectID  string
+	Ref        string
+	WorkflowID string
+}
+
+// DeleteProject starts the DeleteProjectWorkflow, which removes the project
+// and everything in it. The default project can't be deleted.
+func (s *Service) DeleteProject(ctx context.Context, userID, ref string) (*DeleteProjectResult, error) {
+	project, err := s.lookupProject(ctx, userID, ref)
+	if err != nil {
+		return nil, err
+	}
+	if project.IsDefault {
+		return nil, fmt.Errorf("the default project can't be deleted")
+	}
+
+	run, err := s.temporalClient.ExecuteWorkflow(ctx, client.StartWorkflowOptions{
+		ID:        DeleteProjectWorkflowID(project.ID),
+		TaskQueue: ProjectTaskQueue,
+	}, DeleteProjectWorkflow, DeleteProjectWorkflowInput{ProjectID: project.ID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to start delete workflow: %w", err)
+	}
+
+	s.logger.Info("started delete project workflow",
+		"project_id", project.ID,
+		"ref", project.Ref,
+		"workflow_id", run.GetID())
+
+	return &DeleteProjectResult{
+		ProjectID:  project.ID,
+		Ref:        project.Ref,
+		WorkflowID: run.GetID(),
+	}, nil
+}
@@ -3,20 +3,28 @@ package deployments
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
+	"sort"
 	"strings"
+	"time"
 
+	"github.com/augustdev/autoclip/internal/auth"
+	"github.com/augustdev/autoclip/internal/helpers"
 	"github.com/augustdev/autoclip/internal/k8sdeployments"
+	"github.com/augustdev/autoclip/internal/resources"
 	"github.com/augustdev/autoclip/internal/storage/pg/generated/clusters"
 	deploymentsdb "github.com/augustdev/autoclip/internal/storage/pg/generated/deployments"
 	"github.com/augustdev/autoclip/internal/storage/pg/generated/githubcreds"
 	"github.com/augustdev/autoclip/internal/storage/pg/generated/projects"
 	"github.com/augustdev/autoclip/internal/storage/pg/generated/services"
 	"github.com/augustdev/autoclip/internal/storage/pg/generated/users"
+	"github.com/jackc/pgx/v5"
 	"github.com/lithammer/shortuuid/v4"
 	enumspb "go.temporal.io/api/enums/v1"
 	"go.temporal.io/sdk/client"
+	"k8s.io/utils/ptr"
 )
 
 type Service struct {
@@ -27,6 +35,7 @@ type Service struct {
 	usersQ         users.Querier
 	ghCredsQ       githubcreds.Querier
 	clusters       map[string]clusters.Cluster
+	authConfig     auth.Config
 	logger         *slog.Logger
 }
 
@@ -38,6 +47,7 @@ func NewService(
 	usersQ users.Querier,
 	ghCredsQ githubcreds.Querier,
 	clusters map[string]clusters.Cluster,
+	authConfig auth.Config,
 	logger *slog.Logger,
 ) *Service {
 	return &Service{
@@ -48,6 +58,7 @@ func NewService(
 		usersQ:         usersQ,
 		ghCredsQ:       ghCredsQ,
 		clusters:       clusters,
+		authConfig:     authConfig,
 		logger:         logger,
 	}
 }
@@ -65,6 +76,10 @@ type CreateServiceInput struct {
 	GitProvider      string // "github" or "internal"
 	Memory           string
 	VCPUs            string
+	Replicas         int32
+	Autoscaling      *k8sdeployments.AutoscalingConfig
+	SleepAfter       *int32 // seconds idle before scaling to zero; nil never sleeps
+	HealthCheck      *k8sdeployments.HealthCheck
 	BuildCommand     string
 	StartCommand     string
 	InstallationID   int64
@@ -72,6 +87,17 @@ type CreateServiceInput struct {
 	RootDirectory    string
 	DockerfilePath   string
 	Region           string
+	Resources        []ResourceBinding
+
+	// Image deploys a prebuilt image instead of building Repo, which is
+	// then left empty. RegistryCredentials log in to its registry when it
+	// is private.
+	Image               string
+	RegistryCredentials *k8sdeployments.RegistryCredentials
+
+	// Upload creates a service built from uploaded source tarballs. Nothing
+	// deploys until the first upload arrives.
+	Upload bool
 }
 
 type CreateServiceResult struct {
@@ -84,20 +110,11 @@ type CreateServiceResult struct {
 }
 
 func (s *Service) CreateService(ctx context.Context, input CreateServiceInput) (*CreateServiceResult, error) {
-	var projectID string
-	if input.ProjectRef != "" {
-		project, err := s.getOrCreateProject(ctx, input.UserID, input.ProjectRef)
-		if err != nil {
-			return nil, err
-		}
-		projectID = project.ID
-	} else {
-		project, err := s.projectsQ.GetDefaultProject(ctx, input.UserID)
-		if err != nil {
-			return nil, fmt.Errorf("default project not found for user")
-		}
-		projectID = project.ID
+	project, err := s.ResolveProject(ctx, input.UserID, input.ProjectRef)
+	if err != nil {
+		return nil, err
 	}
+	projectID := project.ID
 
 	if input.Name != "" {
 		_, err := s.servicesQ.GetServiceByNameAndProject(ctx, services.GetServiceByNameAndProjectParams{
@@ -118,14 +135,46 @@ func (s *Service) CreateService(ctx context.Context, input CreateServiceInput) (
 		gitProvider = "github"
 	}
 
+	sourceType := k8sdeployments.SourceGit
+	var image *string
+	var registryCreds []byte
+	if input.Upload {
+		if input.Repo != "" || input.Image != "" {
+			return nil, fmt.Errorf("an upload service has no repo or image")
+		}
+		sourceType = k8sdeployments.SourceUpload
+		gitProvider = k8sdeployments.SourceUpload
+	}
+	if input.Image != "" {
+		if input.Repo != "" {
+			return nil, fmt.Errorf("a service deploys either a repo or an image, not both")
+		}
+		if err := k8sdeployments.ValidateImageRef(input.Image); err != nil {
+			return nil, err
+		}
+		sourceType = k8sdeployments.SourceImage
+		image = &input.Image
+		gitProvider = ""
+		if input.RegistryCredentials != nil {
+			registryCreds, err = resources.EncryptSecret(input.RegistryCredentials, s.authConfig.APIKeyEncryptionKey)
+			if err != nil {
+				return nil, fmt.Errorf("failed to encrypt registry credentials: %w", err)
+			}
+		}
+	}
+
 	envVarsJSON, _ := json.Marshal(input.EnvVars)
 
+	if err := k8sdeployments.ValidateHealthCheck(input.HealthCheck); err != nil {
+		return nil, err
+	}
 	buildConfigJSON, _ := json.Marshal(k8sdeployments.BuildConfig{
 		RootDirectory:    input.RootDirectory,
 		DockerfilePath:   input.DockerfilePath,
 		PublishDirectory: input.PublishDirectory,
 		BuildCommand:     input.BuildCommand,
 		StartCommand:     input.StartCommand,
+		HealthCheck:      input.HealthCheck,
 	})
 
 	memory := input.Memory
@@ -137,6 +186,21 @@ func (s *Service) CreateService(ctx context.Context, input CreateServiceInput) (
 		vcpus = "0.5"
 	}
 
+	replicas := input.Replicas
+	if replicas == 0 {
+		replicas = 1
+	}
+	if err := s.validateScaling(ctx, input.UserID, replicas, input.Autoscaling); err != nil {
+		return nil, err
+	}
+	if err := k8sdeployments.ValidateSleepAfter(input.SleepAfter, input.Autoscaling != nil); err != nil {
+		return nil, err
+	}
+	var autoscalingJSON []byte
+	if input.Autoscaling != nil {
+		autoscalingJSON, _ = json.Marshal(input.Autoscaling)
+	}
+
 	region := input.Region
 	if region == "" {
 		region = "eu-central-1"
@@ -149,7 +213,7 @@ func (s *Service) CreateService(ctx context.Context, input CreateServiceInput) (
 		return nil, fmt.Errorf("region %q is not available (status=%s)", region, cluster.Status)
 	}
 
-	_, err := s.servicesQ.CreateService(ctx, services.CreateServiceParams{
+	_, err = s.servicesQ.CreateService(ctx, services.CreateServiceParams{
 		ID:          svcID,
 		UserID:      input.UserID,
 		ProjectID:   projectID,
@@ -165,11 +229,37 @@ func (s *Service) CreateService(ctx context.Context, input CreateServiceInput) (
 		Memory:      memory,
 		Vcpus:       vcpus,
 		Region:      cluster.Region,
+		Replicas:    replicas,
+		Autoscaling: autoscalingJSON,
+		SleepAfter:  input.SleepAfter,
+
+		SourceType:          sourceType,
+		Image:               image,
+		RegistryCredentials: registryCreds,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create service record: %w", err)
 	}
 
+	for _, r := range input.Resources {
+		if err := s.servicesQ.CreateServiceResource(ctx, services.CreateServiceResourceParams{
+			ServiceID:  svcID,
+			ResourceID: r.ResourceID,
+			EnvPrefix:  r.EnvPrefix,
+		}); err != nil {
+			return nil, fmt.Errorf("failed to bind resource: %w", err)
+		}
+	}
+
+	if input.Upload {
+		s.logger.Info("created upload service", "serviceID", svcID, "name", input.Name)
+		return &CreateServiceResult{
+			ServiceID: svcID,
+			Name:      input.Name,
+			Status:    "awaiting_upload",
+		}, nil
+	}
+
 	_, err = s.deploymentsQ.CreateDeployment(ctx, deploymentsdb.CreateDeploymentParams{
 		ID:              deploymentID,
 		ServiceID:       svcID,
@@ -179,6 +269,8 @@ func (s *Service) CreateService(ctx context.Context, input CreateServiceInput) (
 		EnvVarsSnapshot: envVarsJSON,
 		Memory:          memory,
 		Vcpus:           vcpus,
+		Replicas:        replicas,
+		Autoscaling:     autoscalingJSON,
 		Port:            input.Port,
 		Trigger:         "api",
 	})
@@ -194,6 +286,7 @@ func (s *Service) CreateService(ctx context.Context, input CreateServiceInput) (
 		GitProvider:    gitProvider,
 		InstallationID: input.InstallationID,
 		AppsDomain:     cluster.AppsDomain,
+		Image:          input.Image,
 	}
 
 	workflowOptions := client.StartWorkflowOptions{
@@ -250,6 +343,19 @@ func (s *Service) ListServices(ctx context.Context, userID string, limit, offset
 	return svcList, nil
 }
 
+// ResolveProject returns the project a service created with ref lands in:
+// the user's default project when ref is empty, else ref, created if needed.
+func (s *Service) ResolveProject(ctx context.Context, userID, ref string) (*projects.Project, error) {
+	if ref != "" {
+		return s.getOrCreateProject(ctx, userID, ref)
+	}
+	project, err := s.projectsQ.GetDefaultProject(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("default project not found for user")
+	}
+	return &project, nil
+}
+
 func (s *Service) GetProjectByRef(ctx context.Context, userID, ref string) (*projects.Project, error) {
 	return s.getOrCreateProject(ctx, userID, ref)
 }
@@ -332,6 +438,367 @@ func (s *Service) GetLatestDeployment(ctx context.Context, serviceID string) (*d
 	return &dep, nil
 }
 
+type ListDeploymentsParams struct {
+	ServiceID string
+	Limit     int32
+	Offset    int32
+	// After, when set, pages by cursor (a deployment ID) and Offset is ignored.
+	After string
+}
+
+func (s *Service) ListDeployments(ctx context.Context, params ListDeploymentsParams) ([]deploymentsdb.Deployment, int64, error) {
+	total, err := s.deploymentsQ.CountDeploymentsByServiceID(ctx, params.ServiceID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count deployments: %w", err)
+	}
+
+	var deps []deploymentsdb.Deployment
+	if params.After != "" {
+		if _, err := s.GetDeployment(ctx, params.ServiceID, params.After); err != nil {
+			return nil, 0, err
+		}
+		deps, err = s.deploymentsQ.ListDeploymentsByServiceIDAfter(ctx, deploymentsdb.ListDeploymentsByServiceIDAfterParams{
+			ServiceID: params.ServiceID,
+			ID:        params.After,
+			Limit:     params.Limit,
+		})
+	} else {
+		deps, err = s.deploymentsQ.ListDeploymentsByServiceID(ctx, deploymentsdb.ListDeploymentsByServiceIDParams{
+			ServiceID: params.ServiceID,
+			Limit:     params.Limit,
+			Offset:    params.Offset,
+		})
+	}
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list deployments: %w", err)
+	}
+	return deps, total, nil
+}
+
+// GetDeployment returns a deployment only if it belongs to serviceID.
+func (s *Service) GetDeployment(ctx context.Context, serviceID, deploymentID string) (*deploymentsdb.Deployment, error) {
+	dep, err := s.deploymentsQ.GetDeploymentByID(ctx, deploymentID)
+	if err != nil || dep.ServiceID != serviceID {
+		return nil, fmt.Errorf("deployment not found: %s", deploymentID)
+	}
+	return &dep, nil
+}
+
+// validateScaling checks requested replicas and autoscaling against the
+// user's quota before anything is persisted; Deploy re-checks when applying.
+func (s *Service) validateScaling(ctx context.Context, userID string, replicas int32, autoscaling *k8sdeployments.AutoscalingConfig) error {
+	user, err := s.usersQ.GetUserByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("user not found: %w", err)
+	}
+	if err := k8sdeployments.ValidateReplicas(replicas, user.MaxReplicas); err != nil {
+		return err
+	}
+	return k8sdeployments.ValidateAutoscaling(autoscaling, user.MaxReplicas)
+}
+
+// UpdateServiceInput patches a service's stored config. Nil fields are left
+// untouched; a non-nil empty string clears an optional build config field.
+type UpdateServiceInput struct {
+	UserID  string
+	Project string
+	Name    string
+
+	Branch     *string
+	BuildPack  *string
+	Port       *string
+	Memory     *string
+	VCPUs      *string
+	Replicas   *int32
+	SleepAfter *int32 // seconds; 0 turns sleeping off
+	// Autoscaling replaces the service's autoscaling; a zero MaxReplicas
+	// turns it off and goes back to fixed replicas.
+	Autoscaling *k8sdeployments.AutoscalingConfig
+
+	BuildCommand     *string
+	StartCommand     *string
+	RootDirectory    *string
+	DockerfilePath   *string
+	PublishDirectory *string
+	HealthCheck      *k8sdeployments.HealthCheck // an empty Path removes it
+
+	SetEnvVars    []EnvVar
+	RemoveEnvVars []string
+
+	Redeploy bool
+}
+
+type UpdateServiceResult struct {
+	Service    *services.Service
+	Changed    []string
+	WorkflowID string
+}
+
+func (s *Service) UpdateService(ctx context.Context, input UpdateServiceInput) (*UpdateServiceResult, error) {
+	svc, err := s.GetServiceByName(ctx, GetServiceByNameParams{
+		Name:    input.Name,
+		Project: input.Project,
+		UserID:  input.UserID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var changed []string
+	setString := func(field string, dst *string, src *string) {
+		if src != nil && *src != *dst {
+			*dst = *src
+			changed = append(changed, field)
+		}
+	}
+
+	branch := svc.Branch
+	buildPack := svc.BuildPack
+	port := svc.Port
+	memory := svc.Memory
+	vcpus := svc.Vcpus
+	setString("branch", &branch, input.Branch)
+	setString("build_pack", &buildPack, input.BuildPack)
+	setString("port", &port, input.Port)
+	setString("memory", &memory, input.Memory)
+	setString("vcpus", &vcpus, input.VCPUs)
+
+	replicas := svc.Replicas
+	autoscaling := k8sdeployments.ParseAutoscaling(svc.Autoscaling)
+	scalingChanged := false
+	if input.Replicas != nil && *input.Replicas != replicas {
+		replicas = *input.Replicas
+		changed = append(changed, "replicas")
+		scalingChanged = true
+	}
+	if input.Autoscaling != nil {
+		next := input.Autoscaling
+		if next.MaxReplicas == 0 {
+			next = nil
+		}
+		if !equalAutoscaling(next, autoscaling) {
+			autoscaling = next
+			changed = append(changed, "autoscaling")
+			scalingChanged = true
+		}
+	}
+	if scalingChanged {
+		if err := s.validateScaling(ctx, svc.UserID, replicas, autoscaling); err != nil {
+			return nil, err
+		}
+	}
+
+	sleepAfter := svc.SleepAfter
+	if input.SleepAfter != nil {
+		next := input.SleepAfter
+		if *next == 0 {
+			next = nil
+		}
+		if !equalSleepAfter(next, sleepAfter) {
+			sleepAfter = next
+			changed = append(changed, "sleep_after")
+		}
+	}
+	if input.SleepAfter != nil || input.Autoscaling != nil {
+		if err := k8sdeployments.ValidateSleepAfter(sleepAfter, autoscaling != nil); err != nil {
+			return nil, err
+		}
+	}
+
+	var bc k8sdeployments.BuildConfig
+	if len(svc.BuildConfig) > 0 {
+		if err := json.Unmarshal(svc.BuildConfig, &bc); err != nil {
+			return nil, fmt.Errorf("failed to parse stored build config: %w", err)
+		}
+	}
+	setString("build_command", &bc.BuildCommand, input.BuildCommand)
+	setString("start_command", &bc.StartCommand, input.StartCommand)
+	setString("root_directory", &bc.RootDirectory, input.RootDirectory)
+	setString("dockerfile_path", &bc.DockerfilePath, input.DockerfilePath)
+	setString("publish_directory", &bc.PublishDirectory, input.PublishDirectory)
+	if input.HealthCheck != nil {
+		next := input.HealthCheck
+		if next.Path == "" {
+			next = nil
+		}
+		if err := k8sdeployments.ValidateHealthCheck(next); err != nil {
+			return nil, err
+		}
+		if !equalHealthCheck(next, bc.HealthCheck) {
+			bc.HealthCheck = next
+			changed = append(changed, "health_check")
+		}
+	}
+
+	if bc.PublishDirectory != "" && buildPack != "railpack" {
+		return nil, fmt.Errorf("publish_directory is only supported with build_pack=railpack (current build_pack: %s)", buildPack)
+	}
+	if bc.DockerfilePath != "" && buildPack != "dockerfile" {
+		return nil, fmt.Errorf("dockerfile_path is only supported with build_pack=dockerfile (current build_pack: %s)", buildPack)
+	}
+	// Switching build pack without an explicit port falls back to that
+	// pack's default, same as create_service.
+	if input.Port == nil && buildPack != svc.BuildPack {
+		next := k8sdeployments.DefaultServicePort(buildPack, bc.PublishDirectory)
+		setString("port", &port, &next)
+	}
+
+	envVars, err := decodeEnvVars(svc.EnvVars)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse stored env vars: %w", err)
+	}
+	envVars, envChanged := mergeEnvVars(envVars, input.SetEnvVars, input.RemoveEnvVars)
+	if envChanged {
+		changed = append(changed, "env_vars")
+	}
+
+	// Nothing to apply: report success without redeploying so retried
+	// updates don't kick off duplicate builds.
+	if len(changed) == 0 {
+		return &UpdateServiceResult{Service: svc, Changed: []string{}}, nil
+	}
+
+	envVarsJSON, _ := json.Marshal(envVars)
+	buildConfigJSON, _ := json.Marshal(bc)
+	var autoscalingJSON []byte
+	if autoscaling != nil {
+		autoscalingJSON, _ = json.Marshal(autoscaling)
+	}
+
+	updated, err := s.servicesQ.UpdateServiceConfig(ctx, services.UpdateServiceConfigParams{
+		ID:          svc.ID,
+		Branch:      branch,
+		BuildPack:   buildPack,
+		Port:        port,
+		EnvVars:     envVarsJSON,
+		BuildConfig: buildConfigJSON,
+		Memory:      memory,
+		Vcpus:       vcpus,
+		Replicas:    replicas,
+		SleepAfter:  sleepAfter,
+		Autoscaling: autoscalingJSON,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update service: %w", err)
+	}
+
+	s.logger.Info("updated service config",
+		"service_id", svc.ID,
+		"changed", changed)
+
+	result := &UpdateServiceResult{
+		Service: &updated,
+		Changed: changed,
+	}
+
+	if input.Redeploy {
+		workflowID, err := s.redeployWithTrigger(ctx, svc.ID, "manual", "")
+		if err != nil {
+			return nil, fmt.Errorf("service updated but redeploy failed: %w", err)
+		}
+		result.WorkflowID = workflowID
+	}
+
+	return result, nil
+}
+
+func equalSleepAfter(a, b *int32) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func equalAutoscaling(a, b *k8sdeployments.AutoscalingConfig) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.MinReplicas == b.MinReplicas && a.MaxReplicas == b.MaxReplicas &&
+		ptr.Equal(a.TargetCPUUtilization, b.TargetCPUUtilization) &&
+		ptr.Equal(a.TargetMemoryUtilization, b.TargetMemoryUtilization)
+}
+
+func equalHealthCheck(a, b *k8sdeployments.HealthCheck) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// decodeEnvVars reads the services.env_vars column, which is normally a JSON
+// array of EnvVar but may be a legacy {"KEY": "value"} object.
+func decodeEnvVars(raw []byte) ([]EnvVar, error) {
+	if len(raw) == 0 {
+		return []EnvVar{}, nil
+	}
+	var arr []EnvVar
+	if err := json.Unmarshal(raw, &arr); err == nil {
+		return arr, nil
+	}
+	var m map[string]string
+	if err := json.Unmarshal(raw, &m); err != nil {
+		return nil, err
+	}
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	arr = make([]EnvVar, 0, len(keys))
+	for _, k := range keys {
+		arr = append(arr, EnvVar{Key: k, Value: m[k]})
+	}
+	return arr, nil
+}
+
+// mergeEnvVars upserts set and drops remove, preserving the existing order of
+// keys. It reports whether the resulting list differs from current.
+func mergeEnvVars(current, set []EnvVar, remove []string) ([]EnvVar, bool) {
+	removeSet := make(map[string]bool, len(remove))
+	for _, k := range remove {
+		removeSet[k] = true
+	}
+
+	changed := false
+	out := make([]EnvVar, 0, len(current)+len(set))
+	index := make(map[string]int, len(current))
+	for _, ev := range current {
+		if removeSet[ev.Key] {
+			changed = true
+			continue
+		}
+		index[ev.Key] = len(out)
+		out = append(out, ev)
+	}
+
+	for _, ev := range set {
+		if i, ok := index[ev.Key]; ok {
+			if out[i].Value != ev.Value {
+				out[i].Value = ev.Value
+				changed = true
+			}
+			// Setting a var without a scope keeps the one it has.
+			if ev.Scope != "" && envScope(out[i]) != ev.Scope {
+				out[i].Scope = ev.Scope
+				changed = true
+			}
+			continue
+		}
+		index[ev.Key] = len(out)
+		out = append(out, ev)
+		changed = true
+	}
+
+	return out, changed
+}
+
+func envScope(ev EnvVar) string {
+	if ev.Scope == "" {
+		return k8sdeployments.EnvScopeBoth
+	}
+	return ev.Scope
+}
+
 func (s *Service) RedeployService(ctx context.Context, svcID string) (string, error) {
 	return s.redeployWithTrigger(ctx, svcID, "manual", "")
 }
@@ -352,6 +819,13 @@ func (s *Service) RedeployFromInternalGitPush(ctx context.Context, svcID, afterS
 	return s.redeployWithTrigger(ctx, svcID, "git_push", triggerRef)
 }
 
+// RedeployFromUpload deploys the source archive the git server just
+// stored for an upload service. The archive's digest stands in for the
+// commit SHA.
+func (s *Service) RedeployFromUpload(ctx context.Context, svcID, digest string) (string, error) {
+	return s.redeployWithTrigger(ctx, svcID, "upload", digest)
+}
+
 func (s *Service) redeployWithTrigger(ctx context.Context, svcID, trigger, triggerRef string) (string, error) {
 	svc, err := s.servicesQ.GetServiceByID(ctx, svcID)
 	if err != nil {
@@ -363,21 +837,22 @@ func (s *Service) redeployWithTrigger(ctx context.Context, svcID, trigger, trigg
 		return "", fmt.Errorf("unknown region %q for service %s", svc.Region, svcID)
 	}
 
+	// Any other redeploy of an upload service rebuilds its latest upload.
+	if svc.SourceType == k8sdeployments.SourceUpload && trigger != "upload" {
+		upload, err := s.servicesQ.GetLatestSourceUpload(ctx, svcID)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", fmt.Errorf("service %s has no uploaded source yet", svcID)
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to get latest upload: %w", err)
+		}
+		trigger, triggerRef = "upload", upload.Digest
+	}
+
 	deploymentID := shortuuid.New()
 	workflowID := fmt.Sprintf("deploy-%s", deploymentID)
 
-	cancelledWorkflows, err := s.deploymentsQ.CancelInFlightDeployments(ctx, deploymentsdb.CancelInFlightDeploymentsParams{
-		ServiceID: svcID,
-		ID:        deploymentID,
-	})
-	if err != nil {
-		s.logger.Warn("failed to cancel in-flight deployments", "serviceID", svcID, "error", err)
-	}
-	for _, wfID := range cancelledWorkflows {
-		if cancelErr := s.temporalClient.CancelWorkflow(ctx, wfID, ""); cancelErr != nil {
-			s.logger.Warn("failed to cancel Temporal workflow", "workflowID", wfID, "error", cancelErr)
-		}
-	}
+	s.cancelInFlight(ctx, svcID, deploymentID)
 
 	envVarsSnapshot := svc.EnvVars
 	if len(envVarsSnapshot) == 0 {
@@ -392,8 +867,10 @@ func (s *Service) redeployWithTrigger(ctx context.Context, svcID, trigger, trigg
 	if triggerRef != "" {
 		triggerRefPtr = &triggerRef
 	}
+	// A push names the commit and an upload its archive's digest.
+	pinsSource := trigger == "git_push" || trigger == "upload"
 	var commitHashPtr *string
-	if triggerRef != "" && trigger == "git_push" {
+	if triggerRef != "" && pinsSource {
 		commitHashPtr = &triggerRef
 	}
 
@@ -406,6 +883,8 @@ func (s *Service) redeployWithTrigger(ctx context.Context, svcID, trigger, trigg
 		EnvVarsSnapshot: envVarsSnapshot,
 		Memory:          svc.Memory,
 		Vcpus:           svc.Vcpus,
+		Replicas:        svc.Replicas,
+		Autoscaling:     svc.Autoscaling,
 		Port:            svc.Port,
 		Trigger:         trigger,
 		TriggerRef:      triggerRefPtr,
@@ -424,7 +903,7 @@ func (s *Service) redeployWithTrigger(ctx context.Context, svcID, trigger, trigg
 	}
 
 	commitSHA := ""
-	if triggerRef != "" && trigger == "git_push" {
+	if triggerRef != "" && pinsSource {
 		commitSHA = triggerRef
 	}
 
@@ -442,6 +921,7 @@ func (s *Service) redeployWithTrigger(ctx context.Context, svcID, trigger, trigg
 		InstallationID: installationID,
 		CommitSHA:      commitSHA,
 		AppsDomain:     cluster.AppsDomain,
+		Image:          serviceImage(svc),
 	})
 	if err != nil {
 		s.logger.Error("failed to start redeploy workflow",
@@ -465,6 +945,257 @@ func (s *Service) redeployWithTrigger(ctx context.Context, svcID, trigger, trigg
 	return we.GetID(), nil
 }
 
+// serviceImage is the image an image-sourced service redeploys, resolved
+// again to whatever digest its tag points at. It's "" for a service built
+// from a repo.
+func serviceImage(svc services.Service) string {
+	if svc.SourceType != k8sdeployments.SourceImage || svc.Image == nil {
+		return ""
+	}
+	return *svc.Image
+}
+
+// cancelInFlight cancels every queued/building/deploying deployment of the
+// service except keepID, along with its Temporal workflow.
+func (s *Service) cancelInFlight(ctx context.Context, svcID, keepID string) {
+	cancelledWorkflows, err := s.deploymentsQ.CancelInFlightDeployments(ctx, deploymentsdb.CancelInFlightDeploymentsParams{
+		ServiceID: svcID,
+		ID:        keepID,
+	})
+	if err != nil {
+		s.logger.Warn("failed to cancel in-flight deployments", "serviceID", svcID, "error", err)
+	}
+	for _, wfID := range cancelledWorkflows {
+		if cancelErr := s.temporalClient.CancelWorkflow(ctx, wfID, ""); cancelErr != nil {
+			s.logger.Warn("failed to cancel Temporal workflow", "workflowID", wfID, "error", cancelErr)
+		}
+	}
+}
+
+type CancelDeploymentParams struct {
+	Name    string
+	Project string
+	UserID  string
+	// DeploymentID to cancel; empty selects the service's latest deployment.
+	DeploymentID string
+}
+
+type CancelDeploymentResult struct {
+	ServiceID          string
+	Name               string
+	DeploymentID       string
+	PreviousStatus     string
+	ActiveDeploymentID *string
+}
+
+func (s *Service) CancelDeployment(ctx context.Context, params CancelDeploymentParams) (*CancelDeploymentResult, error) {
+	svc, err := s.GetServiceByName(ctx, GetServiceByNameParams{
+		Name:    params.Name,
+		Project: params.Project,
+		UserID:  params.UserID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var dep *deploymentsdb.Deployment
+	if params.DeploymentID != "" {
+		dep, err = s.GetDeployment(ctx, svc.ID, params.DeploymentID)
+	} else {
+		dep, err = s.GetLatestDeployment(ctx, svc.ID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("no deployment to cancel for service %s", params.Name)
+	}
+
+	switch dep.Status {
+	case "queued", "building", "deploying":
+	default:
+		return nil, fmt.Errorf("deployment %s is not in progress (status=%s)", dep.ID, dep.Status)
+	}
+
+	affected, err := s.deploymentsQ.MarkDeploymentCancelled(ctx, dep.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to cancel deployment: %w", err)
+	}
+	if affected == 0 {
+		return nil, fmt.Errorf("deployment %s finished before it could be cancelled", dep.ID)
+	}
+
+	// The build child is cancelled explicitly as well: it is keyed by commit
+	// and would otherwise keep building after its parent is gone.
+	if err := s.temporalClient.CancelWorkflow(ctx, dep.WorkflowID, ""); err != nil {
+		s.logger.Warn("failed to cancel Temporal workflow", "workflowID", dep.WorkflowID, "error", err)
+	}
+	buildWorkflowID := k8sdeployments.BuildWorkflowID(svc.ID, helpers.Deref(dep.CommitHash))
+	if err := s.temporalClient.CancelWorkflow(ctx, buildWorkflowID, ""); err != nil {
+		s.logger.Debug("build workflow not cancelled", "workflowID", buildWorkflowID, "error", err)
+	}
+
+	s.logger.Info("cancelled deployment",
+		"service_id", svc.ID,
+		"deployment_id", dep.ID,
+		"previous_status", dep.Status)
+
+	return &CancelDeploymentResult{
+		ServiceID:          svc.ID,
+		Name:               helpers.Deref(svc.Name),
+		DeploymentID:       dep.ID,
+		PreviousStatus:     dep.Status,
+		ActiveDeploymentID: svc.CurrentDeploymentID,
+	}, nil
+}
+
+// RollbackPrevious selects the deployment that was active before the current one.
+const RollbackPrevious = "previous"
+
+type RollbackServiceParams struct {
+	Name         string
+	Project      string
+	UserID       string
+	DeploymentID string // deployment to restore, or RollbackPrevious
+}
+
+type RollbackServiceResult struct {
+	ServiceID          string
+	Name               string
+	DeploymentID       string
+	SourceDeploymentID string
+	CommitHash         *string
+	ImageRef           string
+	WorkflowID         string
+}
+
+func (s *Service) RollbackService(ctx context.Context, params RollbackServiceParams) (*RollbackServiceResult, error) {
+	svc, err := s.GetServiceByName(ctx, GetServiceByNameParams{
+		Name:    params.Name,
+		Project: params.Project,
+		UserID:  params.UserID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	cluster, ok := s.clusters[svc.Region]
+	if !ok {
+		return nil, fmt.Errorf("unknown region %q for service %s", svc.Region, svc.ID)
+	}
+
+	target := strings.TrimSpace(params.DeploymentID)
+	if target == "" {
+		target = RollbackPrevious
+	}
+
+	var source deploymentsdb.Deployment
+	if target == RollbackPrevious {
+		source, err = s.deploymentsQ.GetPreviousDeploymentByServiceID(ctx, svc.ID)
+		if err != nil {
+			return nil, fmt.Errorf("no previous deployment to roll back to for service %s", params.Name)
+		}
+	} else {
+		source, err = s.deploymentsQ.GetDeploymentByID(ctx, target)
+		if err != nil || source.ServiceID != svc.ID {
+			return nil, fmt.Errorf("deployment %s not found for service %s", target, params.Name)
+		}
+	}
+
+	switch {
+	case source.Status == "active":
+		return nil, fmt.Errorf("deployment %s is already active", source.ID)
+	case source.Status != "superseded" || source.ImageRef == nil || *source.ImageRef == "":
+		return nil, fmt.Errorf("deployment %s cannot be rolled back to (status=%s): only previously active deployments with a built image can be restored", source.ID, source.Status)
+	}
+
+	deploymentID := shortuuid.New()
+	workflowID := fmt.Sprintf("deploy-%s", deploymentID)
+
+	s.cancelInFlight(ctx, svc.ID, deploymentID)
+
+	buildConfig := source.BuildConfig
+	if len(buildConfig) == 0 {
+		buildConfig = []byte("{}")
+	}
+	envVarsSnapshot := source.EnvVarsSnapshot
+	if len(envVarsSnapshot) == 0 {
+		envVarsSnapshot = []byte("[]")
+	}
+	sourceID := source.ID
+
+	_, err = s.deploymentsQ.CreateDeployment(ctx, deploymentsdb.CreateDeploymentParams{
+		ID:              deploymentID,
+		ServiceID:       svc.ID,
+		WorkflowID:      workflowID,
+		BuildPack:       source.BuildPack,
+		BuildConfig:     buildConfig,
+		EnvVarsSnapshot: envVarsSnapshot,
+		Memory:          source.Memory,
+		Vcpus:           source.Vcpus,
+		Replicas:        source.Replicas,
+		Autoscaling:     source.Autoscaling,
+		Port:            source.Port,
+		Trigger:         "rollback",
+		TriggerRef:      &sourceID,
+		CommitHash:      source.CommitHash,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create deployment record: %w", err)
+	}
+
+	commitSHA := ""
+	if source.CommitHash != nil {
+		commitSHA = *source.CommitHash
+	}
+
+	workflowOptions := client.StartWorkflowOptions{
+		ID:        workflowID,
+		TaskQueue: cluster.TaskQueue,
+	}
+
+	we, err := s.temporalClient.ExecuteWorkflow(ctx, workflowOptions, k8sdeployments.RollbackServiceWorkflow, k8sdeployments.RollbackServiceWorkflowInput{
+		ServiceID:          svc.ID,
+		DeploymentID:       deploymentID,
+		SourceDeploymentID: source.ID,
+		ImageRef:           *source.ImageRef,
+		CommitSHA:          commitSHA,
+		AppsDomain:         cluster.AppsDomain,
+		Prebuilt:           svc.SourceType == k8sdeployments.SourceImage,
+	})
+	if err != nil {
+		s.logger.Error("failed to start rollback workflow",
+			"workflowID", workflowID,
+			"error", err)
+		return nil, fmt.Errorf("failed to start rollback workflow: %w", err)
+	}
+
+	runID := we.GetRunID()
+	if err := s.deploymentsQ.UpdateDeploymentWorkflowRunID(ctx, deploymentsdb.UpdateDeploymentWorkflowRunIDParams{
+		ID:            deploymentID,
+		WorkflowRunID: &runID,
+	}); err != nil {
+		s.logger.Warn("failed to persist workflow run id", "deploymentID", deploymentID, "error", err)
+	}
+
+	s.logger.Info("started rollback workflow",
+		"workflowID", workflowID,
+		"runID", runID,
+		"sourceDeploymentID", source.ID)
+
+	name := ""
+	if svc.Name != nil {
+		name = *svc.Name
+	}
+
+	return &RollbackServiceResult{
+		ServiceID:          svc.ID,
+		Name:               name,
+		DeploymentID:       deploymentID,
+		SourceDeploymentID: source.ID,
+		CommitHash:         source.CommitHash,
+		ImageRef:           *source.ImageRef,
+		WorkflowID:         we.GetID(),
+	}, nil
+}
+
 type DeleteServiceParams struct {
 	Name    string
 	Project string
@@ -545,3 +1276,74 @@ func (s *Service) DeleteService(ctx context.Context, params DeleteServiceParams)
 	}, nil
 }
 
+// ErrNoServiceForHost means no live service is reachable on a host.
+var ErrNoServiceForHost = errors.New("no service for host")
+
+// WakeService wakes the service reachable on host and blocks until it is
+// ready. Concurrent callers share one WakeServiceWorkflow per service.
+func (s *Service) WakeService(ctx context.Context, host string) (*k8sdeployments.WakeServiceResult, error) {
+	svc, err := s.servicesQ.GetServiceByHost(ctx, host)
+	if err != nil {
+		return nil, fmt.Errorf("%w %s", ErrNoServiceForHost, host)
+	}
+
+	cluster, ok := s.clusters[svc.Region]
+	if !ok {
+		return nil, fmt.Errorf("unknown region %q for service %s", svc.Region, svc.ID)
+	}
+
+	workflowOptions := client.StartWorkflowOptions{
+		ID:                       k8sdeployments.WakeServiceWorkflowID(svc.ID),
+		TaskQueue:                cluster.TaskQueue,
+		WorkflowIDConflictPolicy: enumspb.WORKFLOW_ID_CONFLICT_POLICY_USE_EXISTING,
+		WorkflowIDReusePolicy:    enumspb.WORKFLOW_ID_REUSE_POLICY_ALLOW_DUPLICATE,
+	}
+
+	run, err := s.temporalClient.ExecuteWorkflow(ctx, workflowOptions, k8sdeployments.WakeServiceWorkflow, k8sdeployments.WakeServiceInput{
+		ServiceID: svc.ID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to start wake workflow: %w", err)
+	}
+
+	var result k8sdeployments.WakeServiceResult
+	if err := run.Get(ctx, &result); err != nil {
+		return nil, fmt.Errorf("wake service %s: %w", svc.ID, err)
+	}
+	return &result, nil
+}
+
+// diagnoseTimeout bounds how long get_service waits on the cluster.
+const diagnoseTimeout = 20 * time.Second
+
+// DiagnoseService classifies the state of a service's running pods.
+func (s *Service) DiagnoseService(ctx context.Context, svc *services.Service) (*k8sdeployments.ServiceHealth, error) {
+	cluster, ok := s.clusters[svc.Region]
+	if !ok {
+		return nil, fmt.Errorf("unknown region %q for service %s", svc.Region, svc.ID)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, diagnoseTimeout)
+	defer cancel()
+
+	workflowOptions := client.StartWorkflowOptions{
+		ID:                       k8sdeployments.DiagnoseServiceWorkflowID(svc.ID),
+		TaskQueue:                cluster.TaskQueue,
+		WorkflowExecutionTimeout: diagnoseTimeout,
+		WorkflowIDConflictPolicy: enumspb.WORKFLOW_ID_CONFLICT_POLICY_USE_EXISTING,
+		WorkflowIDReusePolicy:    enumspb.WORKFLOW_ID_REUSE_POLICY_ALLOW_DUPLICATE,
+	}
+
+	run, err := s.temporalClient.ExecuteWorkflow(ctx, workflowOptions, k8sdeployments.DiagnoseServiceWorkflow, k8sdeployments.DiagnoseServiceInput{
+		ServiceID: svc.ID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to start diagnose workflow: %w", err)
+	}
+
+	var health k8sdeployments.ServiceHealth
+	if err := run.Get(ctx, &health); err != nil {
+		return nil, fmt.Errorf("diagnose service %s: %w", svc.ID, err)
+	}
+	return &health, nil
+}
@@ -0,0 +1,446 @@
+package deployments
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/augustdev/autoclip/internal/k8sdeployments"
+	"github.com/augustdev/autoclip/internal/storage/pg/generated/clusters"
+	deploymentsdb "github.com/augustdev/autoclip/internal/storage/pg/generated/deployments"
+	"github.com/augustdev/autoclip/internal/storage/pg/generated/services"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/mock"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/mocks"
+	"k8s.io/utils/ptr"
+)
+
+func TestMergeEnvVars(t *testing.T) {
+	current := []EnvVar{
+		{Key: "A", Value: "1"},
+		{Key: "B", Value: "2"},
+		{Key: "C", Value: "3"},
+	}
+
+	tests := []struct {
+		name        string
+		set         []EnvVar
+		remove      []string
+		want        []EnvVar
+		wantChanged bool
+	}{
+		{
+			name:        "no-op",
+			want:        current,
+			wantChanged: false,
+		},
+		{
+			name:        "same value is not a change",
+			set:         []EnvVar{{Key: "B", Value: "2"}},
+			want:        current,
+			wantChanged: false,
+		},
+		{
+			name: "update keeps position",
+			set:  []EnvVar{{Key: "B", Value: "20"}},
+			want: []EnvVar{
+				{Key: "A", Value: "1"},
+				{Key: "B", Value: "20"},
+				{Key: "C", Value: "3"},
+			},
+			wantChanged: true,
+		},
+		{
+			name: "scope change keeps the value",
+			set:  []EnvVar{{Key: "C", Value: "3", Scope: "runtime"}},
+			want: []EnvVar{
+				{Key: "A", Value: "1"},
+				{Key: "B", Value: "2"},
+				{Key: "C", Value: "3", Scope: "runtime"},
+			},
+			wantChanged: true,
+		},
+		{
+			name:        "both matches an unscoped var",
+			set:         []EnvVar{{Key: "A", Value: "1", Scope: "both"}},
+			want:        current,
+			wantChanged: false,
+		},
+		{
+			name:   "add and remove",
+			set:    []EnvVar{{Key: "D", Value: "4"}},
+			remove: []string{"A", "MISSING"},
+			want: []EnvVar{
+				{Key: "B", Value: "2"},
+				{Key: "C", Value: "3"},
+				{Key: "D", Value: "4"},
+			},
+			wantChanged: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, changed := mergeEnvVars(current, tt.set, tt.remove)
+			if changed != tt.wantChanged {
+				t.Fatalf("changed = %v, want %v", changed, tt.wantChanged)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeEnvVarsLegacyMap(t *testing.T) {
+	got, err := decodeEnvVars([]byte(`{"B":"2","A":"1"}`))
+	if err != nil {
+		t.Fatalf("decodeEnvVars: %v", err)
+	}
+	want := []EnvVar{{Key: "A", Value: "1"}, {Key: "B", Value: "2"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+}
+
+// fakeServices holds services by ID and records config updates.
+type fakeServices struct {
+	services.Querier
+	byID    map[string]services.Service
+	updated []services.UpdateServiceConfigParams
+}
+
+func (f *fakeServices) GetServiceByID(ctx context.Context, id string) (services.Service, error) {
+	svc, ok := f.byID[id]
+	if !ok {
+		return services.Service{}, pgx.ErrNoRows
+	}
+	return svc, nil
+}
+
+func (f *fakeServices) GetServiceByNameAndUserProject(ctx context.Context, arg services.GetServiceByNameAndUserProjectParams) (services.Service, error) {
+	for _, svc := range f.byID {
+		if svc.Name != nil && *svc.Name == *arg.Name && svc.UserID == arg.UserID {
+			return svc, nil
+		}
+	}
+	return services.Service{}, pgx.ErrNoRows
+}
+
+func (f *fakeServices) UpdateServiceConfig(ctx context.Context, arg services.UpdateServiceConfigParams) (services.Service, error) {
+	f.updated = append(f.updated, arg)
+	svc := f.byID[arg.ID]
+	svc.BuildPack = arg.BuildPack
+	svc.Port = arg.Port
+	svc.BuildConfig = arg.BuildConfig
+	return svc, nil
+}
+
+func testLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+func TestUpdateService_BuildPackPortDefault(t *testing.T) {
+	name := "web"
+	tests := []struct {
+		name        string
+		buildPack   string
+		buildConfig string
+		input       UpdateServiceInput
+		wantPort    string
+	}{
+		{
+			name:      "switching to static uses its port",
+			buildPack: "railpack",
+			input:     UpdateServiceInput{BuildPack: ptr.To("static")},
+			wantPort:  "80",
+		},
+		{
+			name:      "switching to railpack with a publish directory serves on 8080",
+			buildPack: "static",
+			input:     UpdateServiceInput{BuildPack: ptr.To("railpack"), PublishDirectory: ptr.To("dist")},
+			wantPort:  "8080",
+		},
+		{
+			name:      "switching to dockerfile leaves the port to EXPOSE",
+			buildPack: "railpack",
+			input:     UpdateServiceInput{BuildPack: ptr.To("dockerfile")},
+			wantPort:  "",
+		},
+		{
+			name:      "an explicit port wins",
+			buildPack: "railpack",
+			input:     UpdateServiceInput{BuildPack: ptr.To("static"), Port: ptr.To("9000")},
+			wantPort:  "9000",
+		},
+		{
+			name:        "the same build pack keeps the port",
+			buildPack:   "railpack",
+			buildConfig: `{"publish_directory":"dist"}`,
+			input:       UpdateServiceInput{BuildPack: ptr.To("railpack"), StartCommand: ptr.To("npm start")},
+			wantPort:    "4000",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := &fakeServices{byID: map[string]services.Service{"svc-1": {
+				ID:          "svc-1",
+				UserID:      "user-1",
+				Name:        &name,
+				BuildPack:   tt.buildPack,
+				Port:        "4000",
+				BuildConfig: []byte(tt.buildConfig),
+			}}}
+			s := &Service{servicesQ: q, logger: testLogger()}
+
+			input := tt.input
+			input.UserID, input.Name = "user-1", name
+			if _, err := s.UpdateService(context.Background(), input); err != nil {
+				t.Fatalf("UpdateService() error = %v", err)
+			}
+			if len(q.updated) != 1 || q.updated[0].Port != tt.wantPort {
+				t.Fatalf("updates = %+v, want port %q", q.updated, tt.wantPort)
+			}
+		})
+	}
+}
+
+func TestUpdateService_BadStoredBuildConfig(t *testing.T) {
+	name := "web"
+	q := &fakeServices{byID: map[string]services.Service{"svc-1": {
+		ID: "svc-1", UserID: "user-1", Name: &name, BuildPack: "railpack", BuildConfig: []byte("{"),
+	}}}
+	s := &Service{servicesQ: q, logger: testLogger()}
+
+	_, err := s.UpdateService(context.Background(), UpdateServiceInput{UserID: "user-1", Name: name, BuildPack: ptr.To("static")})
+	if err == nil || len(q.updated) != 0 {
+		t.Fatalf("UpdateService() error = %v, updates = %v; want a parse error and no update", err, q.updated)
+	}
+}
+
+// fakeDeployments holds deployments newest first, the order the queries
+// return them in.
+type fakeDeployments struct {
+	deploymentsdb.Querier
+	deps    []deploymentsdb.Deployment
+	created []deploymentsdb.CreateDeploymentParams
+}
+
+func (f *fakeDeployments) GetDeploymentByID(ctx context.Context, id string) (deploymentsdb.Deployment, error) {
+	for _, d := range f.deps {
+		if d.ID == id {
+			return d, nil
+		}
+	}
+	return deploymentsdb.Deployment{}, pgx.ErrNoRows
+}
+
+func (f *fakeDeployments) GetPreviousDeploymentByServiceID(ctx context.Context, serviceID string) (deploymentsdb.Deployment, error) {
+	for _, d := range f.deps {
+		if d.ServiceID == serviceID && d.Status == "superseded" && d.ImageRef != nil {
+			return d, nil
+		}
+	}
+	return deploymentsdb.Deployment{}, pgx.ErrNoRows
+}
+
+func (f *fakeDeployments) CancelInFlightDeployments(ctx context.Context, arg deploymentsdb.CancelInFlightDeploymentsParams) ([]string, error) {
+	var workflowIDs []string
+	for i, d := range f.deps {
+		if d.ServiceID == arg.ServiceID && d.ID != arg.ID && isInFlight(d.Status) {
+			f.deps[i].Status = "cancelled"
+			workflowIDs = append(workflowIDs, d.WorkflowID)
+		}
+	}
+	return workflowIDs, nil
+}
+
+func (f *fakeDeployments) CreateDeployment(ctx context.Context, arg deploymentsdb.CreateDeploymentParams) (deploymentsdb.Deployment, error) {
+	f.created = append(f.created, arg)
+	d := deploymentsdb.Deployment{ID: arg.ID, ServiceID: arg.ServiceID, WorkflowID: arg.WorkflowID, Status: "queued"}
+	f.deps = append([]deploymentsdb.Deployment{d}, f.deps...)
+	return d, nil
+}
+
+func (f *fakeDeployments) GetLatestDeploymentByServiceID(ctx context.Context, serviceID string) (deploymentsdb.Deployment, error) {
+	for _, d := range f.deps {
+		if d.ServiceID == serviceID {
+			return d, nil
+		}
+	}
+	return deploymentsdb.Deployment{}, pgx.ErrNoRows
+}
+
+func (f *fakeDeployments) MarkDeploymentCancelled(ctx context.Context, id string) (int64, error) {
+	for i, d := range f.deps {
+		if d.ID == id && isInFlight(d.Status) {
+			f.deps[i].Status = "cancelled"
+			return 1, nil
+		}
+	}
+	return 0, nil
+}
+
+func (f *fakeDeployments) UpdateDeploymentWorkflowRunID(ctx context.Context, arg deploymentsdb.UpdateDeploymentWorkflowRunIDParams) error {
+	return nil
+}
+
+func isInFlight(status string) bool {
+	return status == "queued" || status == "building" || status == "deploying"
+}
+
+// newTestService serves svc-1 named web for user-1 and svc-2 named api for
+// user-2, both in region eu, with the given deployments.
+func newTestService(t *testing.T, deps ...deploymentsdb.Deployment) (*Service, *fakeDeployments, *mocks.Client) {
+	t.Helper()
+	name := "web"
+	temporalClient := mocks.NewClient(t)
+	deploymentsQ := &fakeDeployments{deps: deps}
+	s := &Service{
+		temporalClient: temporalClient,
+		servicesQ: &fakeServices{byID: map[string]services.Service{
+			"svc-1": {ID: "svc-1", UserID: "user-1", Name: &name, Region: "eu"},
+			"svc-2": {ID: "svc-2", UserID: "user-2", Name: ptr.To("api"), Region: "eu"},
+		}},
+		deploymentsQ: deploymentsQ,
+		clusters:     map[string]clusters.Cluster{"eu": {Region: "eu", TaskQueue: "tq-eu", AppsDomain: "apps.example.com"}},
+		logger:       testLogger(),
+	}
+	return s, deploymentsQ, temporalClient
+}
+
+func TestRollbackService_Previous(t *testing.T) {
+	s, deploymentsQ, temporalClient := newTestService(t,
+		deploymentsdb.Deployment{ID: "dep-3", ServiceID: "svc-1", Status: "active", ImageRef: ptr.To("registry/web:c3")},
+		deploymentsdb.Deployment{ID: "dep-2", ServiceID: "svc-1", Status: "superseded", ImageRef: ptr.To("registry/web:c2"), CommitHash: ptr.To("c2")},
+		deploymentsdb.Deployment{ID: "dep-1", ServiceID: "svc-1", Status: "superseded", ImageRef: ptr.To("registry/web:c1")},
+	)
+	run := mocks.NewWorkflowRun(t)
+	run.On("GetRunID").Return("run-1")
+	run.On("GetID").Return("deploy-x")
+	temporalClient.On("ExecuteWorkflow", mock.Anything,
+		mock.MatchedBy(func(o client.StartWorkflowOptions) bool { return o.TaskQueue == "tq-eu" }),
+		mock.Anything,
+		mock.MatchedBy(func(in k8sdeployments.RollbackServiceWorkflowInput) bool {
+			return in.SourceDeploymentID == "dep-2" && in.ImageRef == "registry/web:c2" && in.CommitSHA == "c2"
+		}),
+	).Return(run, nil).Once()
+
+	result, err := s.RollbackService(context.Background(), RollbackServiceParams{Name: "web", UserID: "user-1"})
+	if err != nil {
+		t.Fatalf("RollbackService() error = %v", err)
+	}
+	if result.SourceDeploymentID != "dep-2" || result.ImageRef != "registry/web:c2" {
+		t.Fatalf("result = %+v, want a rollback to dep-2", result)
+	}
+	if len(deploymentsQ.created) != 1 || deploymentsQ.created[0].Trigger != "rollback" || *deploymentsQ.created[0].TriggerRef != "dep-2" {
+		t.Fatalf("created = %+v, want one rollback deployment of dep-2", deploymentsQ.created)
+	}
+}
+
+func TestRollbackService_Refused(t *testing.T) {
+	tests := []struct {
+		name    string
+		deps    []deploymentsdb.Deployment
+		target  string
+		wantErr string
+	}{
+		{
+			name: "no earlier successful deployment",
+			deps: []deploymentsdb.Deployment{
+				{ID: "dep-2", ServiceID: "svc-1", Status: "active", ImageRef: ptr.To("registry/web:c2")},
+				{ID: "dep-1", ServiceID: "svc-1", Status: "failed"},
+			},
+			wantErr: "no previous deployment",
+		},
+		{
+			name:    "failed target",
+			deps:    []deploymentsdb.Deployment{{ID: "dep-1", ServiceID: "svc-1", Status: "failed"}},
+			target:  "dep-1",
+			wantErr: "cannot be rolled back to",
+		},
+		{
+			name:    "another service's deployment",
+			deps:    []deploymentsdb.Deployment{{ID: "dep-9", ServiceID: "svc-2", Status: "superseded", ImageRef: ptr.To("registry/api:c9")}},
+			target:  "dep-9",
+			wantErr: "not found for service web",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, deploymentsQ, _ := newTestService(t, tt.deps...)
+			_, err := s.RollbackService(context.Background(), RollbackServiceParams{Name: "web", UserID: "user-1", DeploymentID: tt.target})
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("RollbackService() error = %v, want %q", err, tt.wantErr)
+			}
+			if len(deploymentsQ.created) != 0 {
+				t.Fatalf("created = %+v, want nothing", deploymentsQ.created)
+			}
+		})
+	}
+}
+
+func TestCancelDeployment_InFlight(t *testing.T) {
+	s, deploymentsQ, temporalClient := newTestService(t,
+		deploymentsdb.Deployment{ID: "dep-2", ServiceID: "svc-1", Status: "building", WorkflowID: "deploy-dep-2", CommitHash: ptr.To("c2")},
+		deploymentsdb.Deployment{ID: "dep-1", ServiceID: "svc-1", Status: "active"},
+	)
+	temporalClient.On("CancelWorkflow", mock.Anything, "deploy-dep-2", "").Return(nil).Once()
+	temporalClient.On("CancelWorkflow", mock.Anything, k8sdeployments.BuildWorkflowID("svc-1", "c2"), "").Return(nil).Once()
+
+	result, err := s.CancelDeployment(context.Background(), CancelDeploymentParams{Name: "web", UserID: "user-1"})
+	if err != nil {
+		t.Fatalf("CancelDeployment() error = %v", err)
+	}
+	if result.DeploymentID != "dep-2" || result.PreviousStatus != "building" {
+		t.Fatalf("result = %+v, want dep-2 cancelled while building", result)
+	}
+	if deploymentsQ.deps[0].Status != "cancelled" {
+		t.Fatalf("dep-2 status = %q, want cancelled", deploymentsQ.deps[0].Status)
+	}
+}
+
+func TestCancelDeployment_Refused(t *testing.T) {
+	tests := []struct {
+		name    string
+		deps    []deploymentsdb.Deployment
+		params  CancelDeploymentParams
+		wantErr string
+	}{
+		{
+			name:    "finished deployment",
+			deps:    []deploymentsdb.Deployment{{ID: "dep-1", ServiceID: "svc-1", Status: "active", WorkflowID: "deploy-dep-1"}},
+			params:  CancelDeploymentParams{Name: "web", UserID: "user-1", DeploymentID: "dep-1"},
+			wantErr: "not in progress",
+		},
+		{
+			name:    "another user's service",
+			deps:    []deploymentsdb.Deployment{{ID: "dep-9", ServiceID: "svc-2", Status: "building", WorkflowID: "deploy-dep-9"}},
+			params:  CancelDeploymentParams{Name: "api", UserID: "user-1"},
+			wantErr: "not found",
+		},
+		{
+			name:    "another user's deployment",
+			deps:    []deploymentsdb.Deployment{{ID: "dep-9", ServiceID: "svc-2", Status: "building", WorkflowID: "deploy-dep-9"}},
+			params:  CancelDeploymentParams{Name: "web", UserID: "user-1", DeploymentID: "dep-9"},
+			wantErr: "no deployment to cancel",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The mock client fails the test on any CancelWorkflow call.
+			s, deploymentsQ, _ := newTestService(t, tt.deps...)
+			_, err := s.CancelDeployment(context.Background(), tt.params)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("CancelDeployment() error = %v, want %q", err, tt.wantErr)
+			}
+			if deploymentsQ.deps[0].Status == "cancelled" {
+				t.Fatalf("%s was marked cancelled", deploymentsQ.deps[0].ID)
+			}
+		})
+	}
+}
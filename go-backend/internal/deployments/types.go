@@ -1,7 +1,16 @@
 package deployments
 
 type EnvVar struct {
-	Key         string `json:"key"`
-	Value       string `json:"value"`
-	IsBuildTime bool   `json:"is_build_time,omitempty"`
+	Key   string `json:"key"`
+	Value string `json:"value"`
+	// Scope is k8sdeployments.EnvScopeBuild, EnvScopeRuntime or
+	// EnvScopeBoth. Empty is both.
+	Scope string `json:"scope,omitempty"`
+}
+
+// ResourceBinding injects a resource's credentials into a service's env at
+// deploy time, each name prefixed with EnvPrefix.
+type ResourceBinding struct {
+	ResourceID string
+	EnvPrefix  string
 }
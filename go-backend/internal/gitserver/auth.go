@@ -18,6 +18,8 @@ type AuthResult struct {
 	Scopes   []string
 	IsAdmin  bool
 	RepoFull *string // full_name from joined internal_repos
+	// ServiceID is set on upload tokens, which deploy only that service.
+	ServiceID *string
 }
 
 // authenticateRequest extracts Basic Auth credentials and validates them.
@@ -57,12 +59,13 @@ func (s *Server) authenticateRequest(r *http.Request) *AuthResult {
 	}()
 
 	return &AuthResult{
-		TokenID:  token.ID,
-		UserID:   token.UserID,
-		RepoID:   token.RepoID,
-		Scopes:   token.Scopes,
-		IsAdmin:  false,
-		RepoFull: token.RepoFullName,
+		TokenID:   token.ID,
+		UserID:    token.UserID,
+		RepoID:    token.RepoID,
+		Scopes:    token.Scopes,
+		IsAdmin:   false,
+		RepoFull:  token.RepoFullName,
+		ServiceID: token.ServiceID,
 	}
 }
 
@@ -93,6 +96,10 @@ func (auth *AuthResult) matchesRepo(repoFullName string) bool {
 	if auth.IsAdmin {
 		return true
 	}
+	// Upload tokens belong to a service, not to any repo
+	if auth.ServiceID != nil {
+		return false
+	}
 	// Nil repo_id = user-wide token, matches any repo
 	if auth.RepoID == nil {
 		return true
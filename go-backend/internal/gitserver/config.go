@@ -4,4 +4,23 @@ type Config struct {
 	Port       string
 	ReposRoot  string
 	AdminToken string
+
+	// UploadsRoot holds the source tarballs of upload services. Defaults
+	// to .uploads under ReposRoot, which no repo owner can be named.
+	UploadsRoot string
+	// MaxUploadBytes caps one upload's compressed size. Defaults to 200 MiB.
+	MaxUploadBytes int64
+
+	// MaxPackBytes and MaxFileBytes limit one push's pack and the largest
+	// file it may add, for repos that don't set their own. Default to
+	// 500 MiB and 100 MiB.
+	MaxPackBytes int64
+	MaxFileBytes int64
+	// HooksDir is where the pre-receive hook is written. It must allow
+	// executables. Defaults to a directory under the system temp dir.
+	HooksDir string
+
+	// GCPruneExpire is how old an unreachable object must be before the
+	// nightly gc deletes it, in git's date format. Defaults to 1.day.ago.
+	GCPruneExpire string
 }
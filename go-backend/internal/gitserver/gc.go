@@ -0,0 +1,173 @@
+package gitserver
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/augustdev/autoclip/internal/cron"
+	"github.com/augustdev/autoclip/internal/internalgit"
+	"github.com/augustdev/autoclip/internal/storage/pg/generated/internalrepos"
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/worker"
+	"go.temporal.io/sdk/workflow"
+)
+
+const (
+	// TaskQueue is the git server's own worker, the only one with the repos
+	// on disk.
+	TaskQueue = internalgit.GitServerTaskQueue
+
+	// CollectGarbageWorkflowID names the single gc cron.
+	CollectGarbageWorkflowID = "git-gc"
+
+	// gcSchedule runs gc nightly, when pushes are fewest.
+	gcSchedule = "0 3 * * *"
+
+	// defaultGCPruneExpire keeps unreachable objects for a day, which covers
+	// any push still writing them while gc runs.
+	defaultGCPruneExpire = "1.day.ago"
+)
+
+type Activities struct {
+	config         Config
+	internalReposQ internalrepos.Querier
+	logger         *slog.Logger
+}
+
+func NewActivities(config Config, internalReposQ internalrepos.Querier, logger *slog.Logger) *Activities {
+	return &Activities{
+		config:         config,
+		internalReposQ: internalReposQ,
+		logger:         logger,
+	}
+}
+
+type CollectRepoGarbageInput struct {
+	FullName string
+}
+
+type CollectRepoGarbageResult struct {
+	BeforeBytes int64
+	AfterBytes  int64
+}
+
+type CollectGarbageResult struct {
+	Collected  int
+	Failed     int
+	FreedBytes int64
+}
+
+// ListRepos returns the full names of the repos on disk.
+func (a *Activities) ListRepos(ctx context.Context) ([]string, error) {
+	names, err := listBareRepos(a.config.ReposRoot)
+	if err != nil {
+		return nil, fmt.Errorf("list repos: %w", err)
+	}
+	return names, nil
+}
+
+// CollectRepoGarbage repacks a repo, prunes its unreachable objects and
+// records its size afterwards.
+func (a *Activities) CollectRepoGarbage(ctx context.Context, input CollectRepoGarbageInput) (CollectRepoGarbageResult, error) {
+	owner, repo, ok := strings.Cut(input.FullName, "/")
+	if !ok {
+		return CollectRepoGarbageResult{}, temporal.NewNonRetryableApplicationError("invalid repo name", "InvalidRepo", nil, input.FullName)
+	}
+	repoPath := barePath(a.config.ReposRoot, owner, repo)
+	if _, err := os.Stat(repoPath); os.IsNotExist(err) {
+		return CollectRepoGarbageResult{}, nil
+	}
+
+	before, err := repoDiskSize(repoPath)
+	if err != nil {
+		return CollectRepoGarbageResult{}, fmt.Errorf("measure repo: %w", err)
+	}
+
+	expire := a.config.GCPruneExpire
+	if expire == "" {
+		expire = defaultGCPruneExpire
+	}
+	cmd := exec.CommandContext(ctx, "git", "-C", repoPath, "gc", "--quiet", "--prune="+expire)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return CollectRepoGarbageResult{}, fmt.Errorf("git gc: %w\n%s", err, out)
+	}
+
+	after, err := repoDiskSize(repoPath)
+	if err != nil {
+		return CollectRepoGarbageResult{}, fmt.Errorf("measure repo: %w", err)
+	}
+	if err := a.internalReposQ.UpdateInternalRepoSize(ctx, internalrepos.UpdateInternalRepoSizeParams{
+		FullName:  input.FullName,
+		SizeBytes: after,
+	}); err != nil {
+		return CollectRepoGarbageResult{}, fmt.Errorf("record repo size: %w", err)
+	}
+	return CollectRepoGarbageResult{BeforeBytes: before, AfterBytes: after}, nil
+}
+
+// DeleteRepo removes a repo from disk. A repo that is already gone counts
+// as deleted.
+func (a *Activities) DeleteRepo(ctx context.Context, input internalgit.DeleteRepoInput) error {
+	owner, repo, ok := strings.Cut(input.FullName, "/")
+	if !ok || !filepath.IsLocal(owner) || !filepath.IsLocal(repo) || strings.ContainsRune(repo, '/') {
+		return temporal.NewNonRetryableApplicationError("invalid repo name", "InvalidRepo", nil, input.FullName)
+	}
+	if err := os.RemoveAll(barePath(a.config.ReposRoot, owner, repo)); err != nil {
+		return fmt.Errorf("delete repo %s: %w", input.FullName, err)
+	}
+	a.logger.Info("Deleted repo from disk", "repo", input.FullName)
+	return nil
+}
+
+// CollectGarbageWorkflow garbage-collects every repo on disk and reports
+// the space freed. Repos that fail are counted in Failed instead of failing
+// the run.
+func CollectGarbageWorkflow(ctx workflow.Context) (CollectGarbageResult, error) {
+	logger := workflow.GetLogger(ctx)
+
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: 30 * time.Minute,
+		RetryPolicy:         &temporal.RetryPolicy{MaximumAttempts: 3},
+	})
+
+	var activities *Activities
+	var names []string
+	if err := workflow.ExecuteActivity(ctx, activities.ListRepos).Get(ctx, &names); err != nil {
+		return CollectGarbageResult{}, err
+	}
+
+	var result CollectGarbageResult
+	for _, name := range names {
+		var collected CollectRepoGarbageResult
+		if err := workflow.ExecuteActivity(ctx, activities.CollectRepoGarbage, CollectRepoGarbageInput{
+			FullName: name,
+		}).Get(ctx, &collected); err != nil {
+			logger.Warn("Failed to collect repo garbage", "repo", name, "error", err)
+			result.Failed++
+			continue
+		}
+		result.Collected++
+		result.FreedBytes += collected.BeforeBytes - collected.AfterBytes
+	}
+	return result, nil
+}
+
+func RegisterWorkflowsAndActivities(w worker.Worker, activities *Activities) {
+	w.RegisterWorkflow(CollectGarbageWorkflow)
+	w.RegisterActivity(activities.ListRepos)
+	w.RegisterActivity(activities.CollectRepoGarbage)
+	w.RegisterActivityWithOptions(activities.DeleteRepo, activity.RegisterOptions{Name: internalgit.DeleteRepoActivityName})
+}
+
+// StartGCSchedule starts the nightly CollectGarbageWorkflow cron.
+func StartGCSchedule(ctx context.Context, c client.Client) error {
+	return cron.Start(ctx, c, CollectGarbageWorkflowID, TaskQueue, gcSchedule, CollectGarbageWorkflow)
+}
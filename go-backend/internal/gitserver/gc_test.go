@@ -0,0 +1,169 @@
+package gitserver
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/augustdev/autoclip/internal/internalgit"
+	"github.com/augustdev/autoclip/internal/storage/pg/generated/internalrepos"
+)
+
+// fakeRepos serves one repo and records the sizes written for it.
+type fakeRepos struct {
+	internalrepos.Querier
+	repo      internalrepos.InternalRepo
+	userUsage int64
+	sizes     map[string]int64
+}
+
+func (f *fakeRepos) GetInternalRepoByFullName(ctx context.Context, fullName string) (internalrepos.InternalRepo, error) {
+	return f.repo, nil
+}
+
+func (f *fakeRepos) GetUserRepoUsage(ctx context.Context, userID string) (int64, error) {
+	return f.userUsage, nil
+}
+
+func (f *fakeRepos) UpdateInternalRepoSize(ctx context.Context, arg internalrepos.UpdateInternalRepoSizeParams) error {
+	if f.sizes == nil {
+		f.sizes = map[string]int64{}
+	}
+	f.sizes[arg.FullName] = arg.SizeBytes
+	return nil
+}
+
+func TestPushPolicy_Quota(t *testing.T) {
+	gitConfig := internalgit.Config{MaxRepoBytes: 1000, MaxUserBytes: 5000}
+	tests := []struct {
+		name         string
+		repoBytes    int64
+		userBytes    int64
+		wantPack     int64
+		wantExceeded string
+	}{
+		{"room in both", 100, 1000, 900, ""},
+		{"user quota is tighter", 100, 4700, 300, ""},
+		{"repo over quota", 1000, 1000, 0, "repository is over its 1000 B quota"},
+		{"user over quota", 100, 6000, 0, "your repositories are over their 4.9 KiB quota"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Server{
+				config:    Config{MaxPackBytes: 1 << 20},
+				gitConfig: gitConfig,
+				internalReposQ: &fakeRepos{
+					repo:      internalrepos.InternalRepo{UserID: "u1", SizeBytes: tt.repoBytes},
+					userUsage: tt.userBytes,
+				},
+				logger: slog.New(slog.NewTextHandler(io.Discard, nil)),
+			}
+			policy := s.pushPolicy(context.Background(), "owner/repo")
+			if tt.wantExceeded != "" {
+				if !strings.Contains(policy.QuotaExceeded, tt.wantExceeded) {
+					t.Fatalf("QuotaExceeded = %q, want %q", policy.QuotaExceeded, tt.wantExceeded)
+				}
+				return
+			}
+			if policy.QuotaExceeded != "" || policy.MaxPackBytes != tt.wantPack {
+				t.Fatalf("policy = %+v, want MaxPackBytes %d", policy, tt.wantPack)
+			}
+		})
+	}
+}
+
+func TestListBareRepos(t *testing.T) {
+	root := t.TempDir()
+	for _, dir := range []string{"alice/app.git", "alice/site.git", "bob/api.git", "alice/notes", ".uploads/svc", ".hooks"} {
+		if err := os.MkdirAll(filepath.Join(root, dir), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	names, err := listBareRepos(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	slices.Sort(names)
+	want := []string{"alice/app", "alice/site", "bob/api"}
+	if !slices.Equal(names, want) {
+		t.Fatalf("listBareRepos() = %v, want %v", names, want)
+	}
+
+	if names, err := listBareRepos(filepath.Join(root, "missing")); err != nil || names != nil {
+		t.Fatalf("listBareRepos(missing) = %v, %v", names, err)
+	}
+}
+
+func TestCollectRepoGarbage(t *testing.T) {
+	f := newPushFixture(t, pushPolicy{})
+	f.commit("a.txt", []byte("a"))
+	if out, ok := f.push("main", "main:feature"); !ok {
+		t.Fatalf("push failed:\n%s", out)
+	}
+	f.commit("b.txt", []byte(strings.Repeat("b", 4096)))
+	if out, ok := f.push("main:feature"); !ok {
+		t.Fatalf("push failed:\n%s", out)
+	}
+	orphan := strings.TrimSpace(f.git(f.clone, "rev-parse", "HEAD"))
+	if out, ok := f.push(":feature"); !ok {
+		t.Fatalf("delete failed:\n%s", out)
+	}
+
+	// The fixture's bare repo lives at <root>/repo.git; gc finds repos as
+	// <ReposRoot>/<owner>/<repo>.git.
+	root := filepath.Dir(filepath.Dir(f.bare))
+	fullName := filepath.Base(filepath.Dir(f.bare)) + "/repo"
+	repos := &fakeRepos{}
+	a := NewActivities(Config{ReposRoot: root, GCPruneExpire: "now"}, repos, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	result, err := a.CollectRepoGarbage(context.Background(), CollectRepoGarbageInput{FullName: fullName})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.AfterBytes <= 0 || repos.sizes[fullName] != result.AfterBytes {
+		t.Fatalf("result = %+v, recorded sizes = %v", result, repos.sizes)
+	}
+	if out, err := exec.Command("git", "-C", f.bare, "cat-file", "-e", orphan).CombinedOutput(); err == nil {
+		t.Fatalf("unreachable commit %s survived gc\n%s", orphan, out)
+	}
+
+	size, err := repoDiskSize(f.bare)
+	if err != nil || size != result.AfterBytes {
+		t.Fatalf("repoDiskSize() = %d, %v, want %d", size, err, result.AfterBytes)
+	}
+}
+
+func TestDeleteRepo(t *testing.T) {
+	root := t.TempDir()
+	bare := barePath(root, "user1", "api")
+	if err := os.MkdirAll(filepath.Join(bare, "objects"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	a := NewActivities(Config{ReposRoot: root}, &fakeRepos{}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	for range 2 {
+		// A second delete finds the repo gone and still succeeds.
+		if err := a.DeleteRepo(context.Background(), internalgit.DeleteRepoInput{FullName: "user1/api"}); err != nil {
+			t.Fatalf("DeleteRepo() error = %v", err)
+		}
+	}
+	if _, err := os.Stat(bare); !os.IsNotExist(err) {
+		t.Fatalf("repo still on disk: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "user1")); err != nil {
+		t.Fatalf("owner dir removed: %v", err)
+	}
+
+	for _, name := range []string{"user1", "../api", "user1/../../etc", "user1/a/b", "/api"} {
+		if err := a.DeleteRepo(context.Background(), internalgit.DeleteRepoInput{FullName: name}); err == nil {
+			t.Errorf("DeleteRepo(%q) succeeded, want an invalid repo error", name)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"os"
 	"os/exec"
 
 	"github.com/go-chi/chi/v5"
@@ -120,10 +121,24 @@ func (s *Server) handleReceivePack(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	hooksDir, err := s.installHooks()
+	if err != nil {
+		s.logger.Error("failed to install git hooks", "error", err)
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+	policy := s.pushPolicy(r.Context(), repoFullName)
+
 	w.Header().Set("Content-Type", "application/x-git-receive-pack-result")
 	w.Header().Set("Cache-Control", "no-cache")
 
-	cmd := exec.CommandContext(r.Context(), "git", "receive-pack", "--stateless-rpc", repoPath)
+	// Rejections come back from receive-pack as ng lines in its report:
+	// "unpacker error" for an oversized pack and "pre-receive hook
+	// declined" for a policy violation, with the hook's reasons on the
+	// sideband.
+	args := append(policy.receivePackArgs(hooksDir), "receive-pack", "--stateless-rpc", repoPath)
+	cmd := exec.CommandContext(r.Context(), "git", args...)
+	cmd.Env = append(os.Environ(), policy.hookEnv()...)
 	cmd.Stdin = r.Body
 	cmd.Stdout = w
 	cmd.Stderr = io.Discard
@@ -133,6 +148,8 @@ func (s *Server) handleReceivePack(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	s.recordRepoSize(r.Context(), repoFullName, repoPath)
+
 	// Snapshot refs after the push and trigger deploys for changed branches
 	after, err := snapshotRefs(repoPath)
 	if err != nil {
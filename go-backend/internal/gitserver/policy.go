@@ -0,0 +1,178 @@
+package gitserver
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/augustdev/autoclip/internal/storage/pg/generated/internalrepos"
+)
+
+//go:embed hooks/pre-receive
+var preReceiveHook []byte
+
+const (
+	defaultMaxPackBytes = 500 << 20
+	defaultMaxFileBytes = 100 << 20
+)
+
+// pushPolicy is what a push to a repo is checked against. git enforces
+// MaxPackBytes itself (receive.maxInputSize); the pre-receive hook checks
+// the rest.
+type pushPolicy struct {
+	ProtectedBranches []string
+	MaxPackBytes      int64
+	MaxFileBytes      int64
+	// QuotaExceeded says which quota the repo is over, refusing every
+	// update but deletes. Empty while there's room.
+	QuotaExceeded string
+}
+
+// pushPolicy loads a repo's policy, falling back to the server's limits
+// for any it doesn't set. A repo without a record (admin pushes to an
+// unregistered path) gets the limits and no protected branches.
+func (s *Server) pushPolicy(ctx context.Context, repoFullName string) pushPolicy {
+	policy := pushPolicy{
+		MaxPackBytes: s.config.MaxPackBytes,
+		MaxFileBytes: s.config.MaxFileBytes,
+	}
+	if policy.MaxPackBytes <= 0 {
+		policy.MaxPackBytes = defaultMaxPackBytes
+	}
+	if policy.MaxFileBytes <= 0 {
+		policy.MaxFileBytes = defaultMaxFileBytes
+	}
+
+	repo, err := s.internalReposQ.GetInternalRepoByFullName(ctx, repoFullName)
+	if err != nil {
+		return policy
+	}
+	policy.ProtectedBranches = repo.ProtectedBranches
+	if repo.MaxPackBytes != nil {
+		policy.MaxPackBytes = *repo.MaxPackBytes
+	}
+	if repo.MaxFileBytes != nil {
+		policy.MaxFileBytes = *repo.MaxFileBytes
+	}
+	s.applyQuota(ctx, &policy, repo)
+	return policy
+}
+
+// applyQuota holds a push to what's left of the repo's and its owner's
+// quotas: the pack may be no larger than the smaller of the two, and once
+// either is used up only deletes get through. Sizes are as of the last
+// measurement, so a repo can overshoot by at most one push.
+func (s *Server) applyQuota(ctx context.Context, policy *pushPolicy, repo internalrepos.InternalRepo) {
+	repoQuota := s.gitConfig.RepoQuota()
+	repoLeft := repoQuota - repo.SizeBytes
+	if repoLeft <= 0 {
+		policy.QuotaExceeded = fmt.Sprintf("repository is over its %s quota (%s used)", formatBytes(repoQuota), formatBytes(repo.SizeBytes))
+		return
+	}
+	policy.MaxPackBytes = min(policy.MaxPackBytes, repoLeft)
+
+	used, err := s.internalReposQ.GetUserRepoUsage(ctx, repo.UserID)
+	if err != nil {
+		s.logger.Warn("failed to get user repo usage", "userID", repo.UserID, "error", err)
+		return
+	}
+	userQuota := s.gitConfig.UserQuota()
+	userLeft := userQuota - used
+	if userLeft <= 0 {
+		policy.QuotaExceeded = fmt.Sprintf("your repositories are over their %s quota (%s used)", formatBytes(userQuota), formatBytes(used))
+		return
+	}
+	policy.MaxPackBytes = min(policy.MaxPackBytes, userLeft)
+}
+
+// recordRepoSize measures a repo after a push, so the next push is held
+// to the quota that's left.
+func (s *Server) recordRepoSize(ctx context.Context, repoFullName, repoPath string) {
+	size, err := repoDiskSize(repoPath)
+	if err != nil {
+		s.logger.Warn("failed to measure repo", "repo", repoFullName, "error", err)
+		return
+	}
+	if err := s.internalReposQ.UpdateInternalRepoSize(ctx, internalrepos.UpdateInternalRepoSizeParams{
+		FullName:  repoFullName,
+		SizeBytes: size,
+	}); err != nil {
+		s.logger.Warn("failed to record repo size", "repo", repoFullName, "error", err)
+	}
+}
+
+// receivePackArgs are the git arguments that run receive-pack under the
+// policy, ahead of the receive-pack subcommand.
+func (p pushPolicy) receivePackArgs(hooksDir string) []string {
+	return []string{
+		"-c", "core.hooksPath=" + hooksDir,
+		"-c", "receive.maxInputSize=" + strconv.FormatInt(p.MaxPackBytes, 10),
+	}
+}
+
+// hookEnv passes the policy to the pre-receive hook.
+func (p pushPolicy) hookEnv() []string {
+	return []string{
+		"PUSH_PROTECTED_BRANCHES=" + strings.Join(p.ProtectedBranches, " "),
+		"PUSH_MAX_FILE_BYTES=" + strconv.FormatInt(p.MaxFileBytes, 10),
+		"PUSH_QUOTA_EXCEEDED=" + p.QuotaExceeded,
+	}
+}
+
+// installHooks writes the server's hooks once per process and returns the
+// directory receive-pack should run them from.
+func (s *Server) installHooks() (string, error) {
+	s.hooksOnce.Do(func() {
+		dir := s.config.HooksDir
+		if dir == "" {
+			dir = filepath.Join(os.TempDir(), "git-server-hooks")
+		}
+		s.hooksErr = writeHooks(dir)
+		s.hooksPath = dir
+	})
+	return s.hooksPath, s.hooksErr
+}
+
+func writeHooks(dir string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("create hooks dir: %w", err)
+	}
+	// Write and rename so a receive-pack still running an older copy never
+	// sees a half-written file.
+	tmp, err := os.CreateTemp(dir, "pre-receive-*")
+	if err != nil {
+		return fmt.Errorf("create hook: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(preReceiveHook); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write hook: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("write hook: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), 0o755); err != nil {
+		return fmt.Errorf("chmod hook: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), filepath.Join(dir, "pre-receive")); err != nil {
+		return fmt.Errorf("install hook: %w", err)
+	}
+	return nil
+}
+
+// formatBytes writes n the way quotas are reported to pushers.
+func formatBytes(n int64) string {
+	switch {
+	case n >= 1<<30:
+		return strconv.FormatFloat(float64(n)/(1<<30), 'f', 1, 64) + " GiB"
+	case n >= 1<<20:
+		return strconv.FormatFloat(float64(n)/(1<<20), 'f', 1, 64) + " MiB"
+	case n >= 1<<10:
+		return strconv.FormatFloat(float64(n)/(1<<10), 'f', 1, 64) + " KiB"
+	}
+	return strconv.FormatInt(n, 10) + " B"
+}
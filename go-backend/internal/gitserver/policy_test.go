@@ -0,0 +1,187 @@
+package gitserver
+
+import (
+	"crypto/rand"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// pushFixture is a bare repo running the server's pre-receive hook under
+// a policy, and a clone to push to it from. Pushes go over the local
+// transport, whose receive-pack inherits the test's environment.
+type pushFixture struct {
+	t     *testing.T
+	bare  string
+	clone string
+}
+
+func newPushFixture(t *testing.T, policy pushPolicy) *pushFixture {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+	for _, kv := range policy.hookEnv() {
+		k, v, _ := strings.Cut(kv, "=")
+		t.Setenv(k, v)
+	}
+
+	root := t.TempDir()
+	hooksDir := filepath.Join(root, "hooks")
+	if err := writeHooks(hooksDir); err != nil {
+		t.Fatal(err)
+	}
+
+	f := &pushFixture{t: t, bare: filepath.Join(root, "repo.git"), clone: filepath.Join(root, "clone")}
+	f.git(root, "init", "--bare", "-b", "main", f.bare)
+	args := policy.receivePackArgs(hooksDir)
+	for i := 1; i < len(args); i += 2 {
+		k, v, _ := strings.Cut(args[i], "=")
+		f.git(f.bare, "config", k, v)
+	}
+	f.git(root, "init", "-b", "main", f.clone)
+	f.git(f.clone, "config", "user.name", "test")
+	f.git(f.clone, "config", "user.email", "test@example.com")
+	f.git(f.clone, "remote", "add", "origin", f.bare)
+	return f
+}
+
+func (f *pushFixture) git(dir string, args ...string) string {
+	f.t.Helper()
+	out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput()
+	if err != nil {
+		f.t.Fatalf("git %s: %v\n%s", strings.Join(args, " "), err, out)
+	}
+	return string(out)
+}
+
+func (f *pushFixture) commit(name string, content []byte) {
+	f.t.Helper()
+	if err := os.WriteFile(filepath.Join(f.clone, name), content, 0o644); err != nil {
+		f.t.Fatal(err)
+	}
+	f.git(f.clone, "add", name)
+	f.git(f.clone, "commit", "-q", "-m", "add "+name)
+}
+
+// push runs git push --porcelain and returns its output and whether it
+// succeeded.
+func (f *pushFixture) push(args ...string) (string, bool) {
+	f.t.Helper()
+	cmd := exec.Command("git", append([]string{"-C", f.clone, "push", "--porcelain", "origin"}, args...)...)
+	out, err := cmd.CombinedOutput()
+	return string(out), err == nil
+}
+
+func TestPreReceive_ProtectedBranch(t *testing.T) {
+	f := newPushFixture(t, pushPolicy{ProtectedBranches: []string{"main", "release/*"}})
+	f.commit("a.txt", []byte("a"))
+	if out, ok := f.push("main", "main:release/1", "main:feature"); !ok {
+		t.Fatalf("initial push failed:\n%s", out)
+	}
+
+	f.commit("b.txt", []byte("b"))
+	if out, ok := f.push("main", "main:release/1"); !ok {
+		t.Fatalf("fast-forward push failed:\n%s", out)
+	}
+
+	f.git(f.clone, "reset", "-q", "--hard", "HEAD~1")
+	f.commit("c.txt", []byte("c"))
+	for _, ref := range []string{"+main", "+main:release/1"} {
+		out, ok := f.push(ref)
+		if ok {
+			t.Fatalf("force push %s was accepted", ref)
+		}
+		if !strings.Contains(out, "[remote rejected] (pre-receive hook declined)") || !strings.Contains(out, "is a protected branch") {
+			t.Fatalf("force push %s output:\n%s", ref, out)
+		}
+	}
+	if out, ok := f.push("+main:feature"); !ok {
+		t.Fatalf("force push to an unprotected branch failed:\n%s", out)
+	}
+
+	out, ok := f.push(":main")
+	if ok || !strings.Contains(out, "can't be deleted") {
+		t.Fatalf("deleting main: ok=%v\n%s", ok, out)
+	}
+	if out, ok := f.push(":feature"); !ok {
+		t.Fatalf("deleting an unprotected branch failed:\n%s", out)
+	}
+}
+
+func TestPreReceive_MaxFileBytes(t *testing.T) {
+	f := newPushFixture(t, pushPolicy{MaxFileBytes: 100, MaxPackBytes: 1 << 20})
+	f.commit("small.txt", []byte("small"))
+	if out, ok := f.push("main"); !ok {
+		t.Fatalf("push failed:\n%s", out)
+	}
+
+	f.commit("big file.bin", []byte(strings.Repeat("x", 101)))
+	out, ok := f.push("main")
+	if ok {
+		t.Fatal("a push adding a file over the limit was accepted")
+	}
+	if !strings.Contains(out, "big file.bin is 101 bytes, over the 100 byte file limit") {
+		t.Fatalf("push output:\n%s", out)
+	}
+}
+
+func TestPreReceive_MaxPackBytes(t *testing.T) {
+	f := newPushFixture(t, pushPolicy{MaxPackBytes: 1024})
+	random := make([]byte, 4096)
+	if _, err := rand.Read(random); err != nil {
+		t.Fatal(err)
+	}
+	f.commit("random.bin", random)
+
+	out, ok := f.push("main")
+	if ok {
+		t.Fatal("a pack over the limit was accepted")
+	}
+	if !strings.Contains(out, "unpacker error") && !strings.Contains(out, "maximum allowed size") {
+		t.Fatalf("push output:\n%s", out)
+	}
+}
+
+func TestPreReceive_QuotaExceeded(t *testing.T) {
+	f := newPushFixture(t, pushPolicy{QuotaExceeded: "repository is over its 1.0 KiB quota (2.0 KiB used)"})
+	f.commit("a.txt", []byte("a"))
+	out, ok := f.push("main")
+	if ok {
+		t.Fatal("a push over quota was accepted")
+	}
+	if !strings.Contains(out, "over its 1.0 KiB quota") {
+		t.Fatalf("push output:\n%s", out)
+	}
+}
+
+func TestPreReceive_QuotaExceededAllowsDeletes(t *testing.T) {
+	f := newPushFixture(t, pushPolicy{})
+	f.commit("a.txt", []byte("a"))
+	if out, ok := f.push("main", "main:feature"); !ok {
+		t.Fatalf("initial push failed:\n%s", out)
+	}
+
+	t.Setenv("PUSH_QUOTA_EXCEEDED", "repository is over quota")
+	if out, ok := f.push(":feature"); !ok {
+		t.Fatalf("deleting a branch over quota failed:\n%s", out)
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	tests := map[int64]string{
+		512:     "512 B",
+		2048:    "2.0 KiB",
+		5 << 20: "5.0 MiB",
+		3 << 29: "1.5 GiB",
+	}
+	for n, want := range tests {
+		if got := formatBytes(n); got != want {
+			t.Errorf("formatBytes(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
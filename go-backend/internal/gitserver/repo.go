@@ -2,6 +2,7 @@ package gitserver
 
 import (
 	"fmt"
+	"io/fs"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -90,3 +91,60 @@ func diffRefs(before, after RefSnapshot) []ChangedRef {
 func barePath(reposRoot, owner, repo string) string {
 	return filepath.Join(reposRoot, owner, repo+".git")
 }
+
+// repoDiskSize sums the sizes of the files in a bare repo.
+func repoDiskSize(barePath string) (int64, error) {
+	var size int64
+	err := filepath.WalkDir(barePath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			// Files come and go while git writes; only the root must exist.
+			if path != barePath && os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if os.IsNotExist(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		size += info.Size()
+		return nil
+	})
+	return size, err
+}
+
+// listBareRepos returns the full names of the bare repos under reposRoot.
+// Dot directories (uploads, hooks) aren't owners and are skipped.
+func listBareRepos(reposRoot string) ([]string, error) {
+	owners, err := os.ReadDir(reposRoot)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, owner := range owners {
+		if !owner.IsDir() || strings.HasPrefix(owner.Name(), ".") {
+			continue
+		}
+		repos, err := os.ReadDir(filepath.Join(reposRoot, owner.Name()))
+		if err != nil {
+			return nil, err
+		}
+		for _, repo := range repos {
+			name, ok := strings.CutSuffix(repo.Name(), ".git")
+			if !repo.IsDir() || !ok || name == "" {
+				continue
+			}
+			names = append(names, owner.Name()+"/"+name)
+		}
+	}
+	return names, nil
+}
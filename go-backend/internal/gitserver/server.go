@@ -3,36 +3,49 @@ package gitserver
 import (
 	"log/slog"
 	"net/http"
+	"sync"
 
 	"github.com/augustdev/autoclip/internal/deployments"
+	"github.com/augustdev/autoclip/internal/internalgit"
 	"github.com/augustdev/autoclip/internal/storage/pg/generated/gittokens"
+	"github.com/augustdev/autoclip/internal/storage/pg/generated/internalrepos"
 	"github.com/augustdev/autoclip/internal/storage/pg/generated/services"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 )
 
 type Server struct {
-	config        Config
-	gitTokensQ    gittokens.Querier
-	servicesQ     services.Querier
-	deployService *deployments.Service
-	logger        *slog.Logger
-	router        chi.Router
+	config         Config
+	gitConfig      internalgit.Config
+	gitTokensQ     gittokens.Querier
+	internalReposQ internalrepos.Querier
+	servicesQ      services.Querier
+	deployService  *deployments.Service
+	logger         *slog.Logger
+	router         chi.Router
+
+	hooksOnce sync.Once
+	hooksPath string
+	hooksErr  error
 }
 
 func NewServer(
 	config Config,
+	gitConfig internalgit.Config,
 	gitTokensQ gittokens.Querier,
+	internalReposQ internalrepos.Querier,
 	servicesQ services.Querier,
 	deployService *deployments.Service,
 	logger *slog.Logger,
 ) *Server {
 	s := &Server{
-		config:        config,
-		gitTokensQ:    gitTokensQ,
-		servicesQ:     servicesQ,
-		deployService: deployService,
-		logger:        logger,
+		config:         config,
+		gitConfig:      gitConfig,
+		gitTokensQ:     gitTokensQ,
+		internalReposQ: internalReposQ,
+		servicesQ:      servicesQ,
+		deployService:  deployService,
+		logger:         logger,
 	}
 
 	r := chi.NewRouter()
@@ -49,6 +62,10 @@ func NewServer(
 	r.Post("/{owner}/{repo}.git/git-upload-pack", s.handleUploadPack)
 	r.Post("/{owner}/{repo}.git/git-receive-pack", s.handleReceivePack)
 
+	// Source tarball uploads, and their download by the build worker
+	r.Post("/uploads", s.handleSourceUpload)
+	r.Get("/uploads/{serviceID}/{digest}.tar.gz", s.handleGetUpload)
+
 	s.router = r
 	return s
 }
@@ -0,0 +1,237 @@
+package gitserver
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/augustdev/autoclip/internal/k8sdeployments"
+	"github.com/augustdev/autoclip/internal/storage/pg/generated/services"
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	defaultMaxUploadBytes = 200 << 20
+
+	// keepUploads is how many of a service's archives stay on disk. Older
+	// ones can't be rebuilt, but their images remain for rollbacks.
+	keepUploads = 3
+)
+
+var (
+	digestPattern    = regexp.MustCompile(`^[0-9a-f]{64}$`)
+	serviceIDPattern = regexp.MustCompile(`^[A-Za-z0-9-]+$`)
+)
+
+// UploadResult is the JSON answer to a source upload.
+type UploadResult struct {
+	ServiceID  string `json:"service_id"`
+	Digest     string `json:"digest"`
+	SizeBytes  int64  `json:"size_bytes"`
+	WorkflowID string `json:"workflow_id"`
+}
+
+// handleSourceUpload handles POST /uploads: a gzipped tarball of an upload
+// service's source, authenticated with that service's upload token. The
+// archive is stored under its sha256 digest and deployed.
+func (s *Server) handleSourceUpload(w http.ResponseWriter, r *http.Request) {
+	auth := s.requireAuth(w, r)
+	if auth == nil {
+		return
+	}
+	if auth.ServiceID == nil || !auth.hasScope("upload") {
+		http.Error(w, "not an upload token", http.StatusForbidden)
+		return
+	}
+	serviceID := *auth.ServiceID
+
+	svc, err := s.servicesQ.GetServiceByID(r.Context(), serviceID)
+	if err != nil || svc.SourceType != k8sdeployments.SourceUpload {
+		http.Error(w, "service not found", http.StatusNotFound)
+		return
+	}
+
+	maxBytes := s.config.MaxUploadBytes
+	if maxBytes <= 0 {
+		maxBytes = defaultMaxUploadBytes
+	}
+	body := http.MaxBytesReader(w, r.Body, maxBytes)
+
+	digest, size, err := storeUpload(s.uploadsRoot(), serviceID, body)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			http.Error(w, fmt.Sprintf("upload is larger than %d bytes", maxBytes), http.StatusRequestEntityTooLarge)
+			return
+		}
+		if errors.Is(err, errInvalidArchive) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		s.logger.Error("failed to store upload", "serviceID", serviceID, "error", err)
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	if _, err := s.servicesQ.UpsertSourceUpload(r.Context(), services.UpsertSourceUploadParams{
+		ServiceID: serviceID,
+		Digest:    digest,
+		SizeBytes: size,
+	}); err != nil {
+		s.logger.Error("failed to record upload", "serviceID", serviceID, "error", err)
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	workflowID, err := s.deployService.RedeployFromUpload(r.Context(), serviceID, digest)
+	if err != nil {
+		s.logger.Error("failed to start upload deploy", "serviceID", serviceID, "error", err)
+		http.Error(w, "failed to start deploy", http.StatusInternalServerError)
+		return
+	}
+	s.logger.Info("triggered upload deploy",
+		"serviceID", serviceID,
+		"workflowID", workflowID,
+		"digest", digest,
+		"size", size)
+
+	s.pruneUploads(r, serviceID)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(UploadResult{
+		ServiceID:  serviceID,
+		Digest:     digest,
+		SizeBytes:  size,
+		WorkflowID: workflowID,
+	})
+}
+
+// handleGetUpload handles GET /uploads/{serviceID}/{digest}.tar.gz, which
+// only the build worker's admin token may read.
+func (s *Server) handleGetUpload(w http.ResponseWriter, r *http.Request) {
+	auth := s.requireAuth(w, r)
+	if auth == nil {
+		return
+	}
+	if !auth.IsAdmin {
+		http.Error(w, "forbidden", http.StatusForbidden)
+		return
+	}
+
+	serviceID := chi.URLParam(r, "serviceID")
+	digest := chi.URLParam(r, "digest")
+	if !serviceIDPattern.MatchString(serviceID) || !digestPattern.MatchString(digest) {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/gzip")
+	http.ServeFile(w, r, uploadPath(s.uploadsRoot(), serviceID, digest))
+}
+
+// pruneUploads drops all but a service's newest keepUploads archives.
+func (s *Server) pruneUploads(r *http.Request, serviceID string) {
+	stale, err := s.servicesQ.ListStaleSourceUploads(r.Context(), services.ListStaleSourceUploadsParams{
+		ServiceID: serviceID,
+		Offset:    keepUploads,
+	})
+	if err != nil {
+		s.logger.Warn("failed to list stale uploads", "serviceID", serviceID, "error", err)
+		return
+	}
+	for _, upload := range stale {
+		if err := os.Remove(uploadPath(s.uploadsRoot(), serviceID, upload.Digest)); err != nil && !os.IsNotExist(err) {
+			s.logger.Warn("failed to remove stale upload", "serviceID", serviceID, "digest", upload.Digest, "error", err)
+			continue
+		}
+		if err := s.servicesQ.DeleteSourceUpload(r.Context(), upload.ID); err != nil {
+			s.logger.Warn("failed to delete stale upload record", "serviceID", serviceID, "digest", upload.Digest, "error", err)
+		}
+	}
+}
+
+func (s *Server) uploadsRoot() string {
+	if s.config.UploadsRoot != "" {
+		return s.config.UploadsRoot
+	}
+	return filepath.Join(s.config.ReposRoot, ".uploads")
+}
+
+func uploadPath(root, serviceID, digest string) string {
+	return filepath.Join(root, serviceID, digest+".tar.gz")
+}
+
+var errInvalidArchive = errors.New("upload must be a gzipped tarball")
+
+// storeUpload writes an archive to root/<serviceID>/<digest>.tar.gz and
+// returns its sha256 digest and size. The archive is checked to be a
+// readable gzipped tarball before it's kept.
+func storeUpload(root, serviceID string, body io.Reader) (string, int64, error) {
+	dir := filepath.Join(root, serviceID)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", 0, fmt.Errorf("create upload dir: %w", err)
+	}
+	tmp, err := os.CreateTemp(dir, "upload-*.tmp")
+	if err != nil {
+		return "", 0, fmt.Errorf("create upload file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(tmp, hash), body)
+	if err != nil {
+		return "", 0, err
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return "", 0, err
+	}
+	if err := checkArchive(tmp); err != nil {
+		return "", 0, fmt.Errorf("%w: %v", errInvalidArchive, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return "", 0, err
+	}
+
+	digest := hex.EncodeToString(hash.Sum(nil))
+	if err := os.Rename(tmp.Name(), uploadPath(root, serviceID, digest)); err != nil {
+		return "", 0, fmt.Errorf("store upload: %w", err)
+	}
+	return digest, size, nil
+}
+
+// checkArchive reads through a gzipped tarball, failing if it's corrupt
+// or has no entries.
+func checkArchive(r io.Reader) error {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	entries := 0
+	for {
+		_, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		entries++
+	}
+	if entries == 0 {
+		return errors.New("archive is empty")
+	}
+	return nil
+}
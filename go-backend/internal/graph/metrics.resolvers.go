@@ -62,6 +62,7 @@ func (r *queryResolver) ServiceMetrics(ctx context.Context, serviceID string, ti
 		MemoryUsageMb:              toModelSeries(metrics.MemoryUsageMB),
 		NetworkReceiveBytesPerSec:  toModelSeries(metrics.NetworkReceiveBytesPerSec),
 		NetworkTransmitBytesPerSec: toModelSeries(metrics.NetworkTransmitBytesPerSec),
+		ReplicaCount:               toModelSeries(metrics.ReplicaCount),
 		MemoryLimitMb:              memoryLimitMB,
 		CPULimitVCPUs:              cpuLimitVCPUs,
 	}, nil
@@ -18,20 +18,56 @@ type APIKey struct {
 	CreatedAt  time.Time  `json:"createdAt"`
 }
 
+type CancelDeploymentResult struct {
+	ServiceID          string  `json:"serviceId"`
+	DeploymentID       string  `json:"deploymentId"`
+	PreviousStatus     string  `json:"previousStatus"`
+	ActiveDeploymentID *string `json:"activeDeploymentId,omitempty"`
+	Message            string  `json:"message"`
+}
+
 type CreateAPIKeyResult struct {
 	APIKey *APIKey `json:"apiKey"`
 	Secret string  `json:"secret"`
 }
 
+type DeleteProjectResult struct {
+	ProjectID string `json:"projectId"`
+	Name      string `json:"name"`
+	Message   string `json:"message"`
+}
+
 type DeleteServiceResult struct {
 	ServiceID string `json:"serviceId"`
 	Name      string `json:"name"`
 	Message   string `json:"message"`
 }
 
+type Deployment struct {
+	ID              string     `json:"id"`
+	Status          string     `json:"status"`
+	Trigger         string     `json:"trigger"`
+	TriggerRef      *string    `json:"triggerRef,omitempty"`
+	CommitHash      *string    `json:"commitHash,omitempty"`
+	ImageRef        *string    `json:"imageRef,omitempty"`
+	ErrorMessage    *string    `json:"errorMessage,omitempty"`
+	BuildProgress   *string    `json:"buildProgress,omitempty"`
+	CreatedAt       time.Time  `json:"createdAt"`
+	StartedAt       *time.Time `json:"startedAt,omitempty"`
+	FinishedAt      *time.Time `json:"finishedAt,omitempty"`
+	DurationSeconds *int32     `json:"durationSeconds,omitempty"`
+}
+
+type DeploymentConnection struct {
+	Nodes      []*Deployment `json:"nodes"`
+	PageInfo   *PageInfo     `json:"pageInfo"`
+	TotalCount int32         `json:"totalCount"`
+}
+
 type EnvVar struct {
 	Key   string `json:"key"`
 	Value string `json:"value"`
+	Scope string `json:"scope"`
 }
 
 type MetricDataPoint struct {
@@ -55,12 +91,13 @@ type PageInfo struct {
 }
 
 type Project struct {
-	ID        string     `json:"id"`
-	Name      string     `json:"name"`
-	Ref       string     `json:"ref"`
-	Services  []*Service `json:"services"`
-	CreatedAt time.Time  `json:"createdAt"`
-	UpdatedAt time.Time  `json:"updatedAt"`
+	ID        string      `json:"id"`
+	Name      string      `json:"name"`
+	Ref       string      `json:"ref"`
+	Services  []*Service  `json:"services"`
+	Resources []*Resource `json:"resources"`
+	CreatedAt time.Time   `json:"createdAt"`
+	UpdatedAt time.Time   `json:"updatedAt"`
 }
 
 type ProjectConnection struct {
@@ -93,31 +130,56 @@ type ResourceConnection struct {
 }
 
 type ResourceMetadata struct {
-	Size     *string `json:"size,omitempty"`
-	Hostname *string `json:"hostname,omitempty"`
-	Group    *string `json:"group,omitempty"`
+	Size           *string    `json:"size,omitempty"`
+	Hostname       *string    `json:"hostname,omitempty"`
+	Group          *string    `json:"group,omitempty"`
+	ForkedFrom     *string    `json:"forkedFrom,omitempty"`
+	ForkedAt       *string    `json:"forkedAt,omitempty"`
+	RestoredTo     *string    `json:"restoredTo,omitempty"`
+	RestoredAt     *string    `json:"restoredAt,omitempty"`
+	StorageBytes   *int       `json:"storageBytes,omitempty"`
+	SizeLimitBytes *int       `json:"sizeLimitBytes,omitempty"`
+	RowsRead       *int       `json:"rowsRead,omitempty"`
+	RowsWritten    *int       `json:"rowsWritten,omitempty"`
+	WritesBlocked  *bool      `json:"writesBlocked,omitempty"`
+	UsageWarning   *string    `json:"usageWarning,omitempty"`
+	UsageUpdatedAt *time.Time `json:"usageUpdatedAt,omitempty"`
+}
+
+type RollbackServiceResult struct {
+	ServiceID          string  `json:"serviceId"`
+	DeploymentID       string  `json:"deploymentId"`
+	SourceDeploymentID string  `json:"sourceDeploymentId"`
+	CommitHash         *string `json:"commitHash,omitempty"`
+	Message            string  `json:"message"`
 }
 
 type Service struct {
-	ID                 string         `json:"id"`
-	ProjectID          string         `json:"projectId"`
-	Project            *Project       `json:"project,omitempty"`
-	Name               *string        `json:"name,omitempty"`
-	Repo               string         `json:"repo"`
-	Branch             string         `json:"branch"`
-	Status             *ServiceStatus `json:"status"`
-	ErrorMessage       *string        `json:"errorMessage,omitempty"`
-	EnvVars            []*EnvVar      `json:"envVars"`
-	Fqdn               *string        `json:"fqdn,omitempty"`
-	Port               string         `json:"port"`
-	GitProvider        string         `json:"gitProvider"`
-	CommitHash         *string        `json:"commitHash,omitempty"`
-	Memory             string         `json:"memory"`
-	Vcpus              string         `json:"vcpus"`
-	CustomDomain       *string        `json:"customDomain,omitempty"`
-	CustomDomainStatus *string        `json:"customDomainStatus,omitempty"`
-	CreatedAt          time.Time      `json:"createdAt"`
-	UpdatedAt          time.Time      `json:"updatedAt"`
+	ID                 string                `json:"id"`
+	ProjectID          string                `json:"projectId"`
+	Project            *Project              `json:"project,omitempty"`
+	Name               *string               `json:"name,omitempty"`
+	Repo               string                `json:"repo"`
+	Branch             string                `json:"branch"`
+	Status             *ServiceStatus        `json:"status"`
+	ErrorMessage       *string               `json:"errorMessage,omitempty"`
+	EnvVars            []*EnvVar             `json:"envVars"`
+	Fqdn               *string               `json:"fqdn,omitempty"`
+	Port               string                `json:"port"`
+	GitProvider        string                `json:"gitProvider"`
+	SourceType         string                `json:"sourceType"`
+	Image              *string               `json:"image,omitempty"`
+	CommitHash         *string               `json:"commitHash,omitempty"`
+	Memory             string                `json:"memory"`
+	Vcpus              string                `json:"vcpus"`
+	Replicas           int32                 `json:"replicas"`
+	SleepAfterSeconds  *int32                `json:"sleepAfterSeconds,omitempty"`
+	SleepingSince      *time.Time            `json:"sleepingSince,omitempty"`
+	CustomDomain       *string               `json:"customDomain,omitempty"`
+	CustomDomainStatus *string               `json:"customDomainStatus,omitempty"`
+	CreatedAt          time.Time             `json:"createdAt"`
+	UpdatedAt          time.Time             `json:"updatedAt"`
+	Deployments        *DeploymentConnection `json:"deployments"`
 }
 
 type ServiceConnection struct {
@@ -131,6 +193,7 @@ type ServiceMetrics struct {
 	MemoryUsageMb              *MetricSeries `json:"memoryUsageMB"`
 	NetworkReceiveBytesPerSec  *MetricSeries `json:"networkReceiveBytesPerSec"`
 	NetworkTransmitBytesPerSec *MetricSeries `json:"networkTransmitBytesPerSec"`
+	ReplicaCount               *MetricSeries `json:"replicaCount"`
 	MemoryLimitMb              float64       `json:"memoryLimitMB"`
 	CPULimitVCPUs              float64       `json:"cpuLimitVCPUs"`
 }
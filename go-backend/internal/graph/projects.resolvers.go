@@ -14,6 +14,60 @@ import (
 	"github.com/augustdev/autoclip/internal/storage/pg/generated/projects"
 )
 
+// CreateProject is the resolver for the createProject field.
+func (r *mutationResolver) CreateProject(ctx context.Context, name string) (*model.Project, error) {
+	userID := authz.For(ctx).GetUserID()
+
+	dbProject, err := r.DeployService.CreateProject(ctx, userID, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return dbProjectToModel(dbProject, nil), nil
+}
+
+// RenameProject is the resolver for the renameProject field.
+func (r *mutationResolver) RenameProject(ctx context.Context, project string, name string) (*model.Project, error) {
+	userID := authz.For(ctx).GetUserID()
+
+	dbProject, err := r.DeployService.RenameProject(ctx, userID, project, name)
+	if err != nil {
+		return nil, err
+	}
+
+	projectServices, err := r.getServicesForProject(ctx, dbProject.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get services for project: %w", err)
+	}
+
+	return dbProjectToModel(dbProject, projectServices), nil
+}
+
+// DeleteProject is the resolver for the deleteProject field.
+func (r *mutationResolver) DeleteProject(ctx context.Context, project string) (*model.DeleteProjectResult, error) {
+	userID := authz.For(ctx).GetUserID()
+
+	result, err := r.DeployService.DeleteProject(ctx, userID, project)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.DeleteProjectResult{
+		ProjectID: result.ProjectID,
+		Name:      result.Ref,
+		Message:   "Project deletion initiated",
+	}, nil
+}
+
+// Resources is the resolver for the resources field.
+func (r *projectResolver) Resources(ctx context.Context, obj *model.Project) ([]*model.Resource, error) {
+	projectResources, err := r.getResourcesForProject(ctx, authz.For(ctx).GetUserID(), obj.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get resources for project: %w", err)
+	}
+	return projectResources, nil
+}
+
 // ListProjects is the resolver for the listProjects field.
 func (r *queryResolver) ListProjects(ctx context.Context, first *int32, after *string) (*model.ProjectConnection, error) {
 	userID := authz.For(ctx).GetUserID()
@@ -83,3 +137,8 @@ func (r *queryResolver) ProjectDetails(ctx context.Context, id string) (*model.P
 
 	return dbProjectToModel(&dbProject, projectServices), nil
 }
+
+// Project returns ProjectResolver implementation.
+func (r *Resolver) Project() ProjectResolver { return &projectResolver{r} }
+
+type projectResolver struct{ *Resolver }
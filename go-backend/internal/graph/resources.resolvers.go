@@ -35,9 +35,14 @@ func (r *queryResolver) ListResources(ctx context.Context, first *int32, after *
 		return nil, fmt.Errorf("failed to list resources: %w", err)
 	}
 
+	usage, err := r.resourceUsageByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list resource usage: %w", err)
+	}
+
 	nodes := make([]*model.Resource, len(dbResources))
 	for i, dbResource := range dbResources {
-		nodes[i] = dbResourceToModel(&dbResource)
+		nodes[i] = dbResourceToModel(&dbResource, usage[dbResource.ID])
 	}
 
 	var startCursor, endCursor *string
@@ -71,7 +76,12 @@ func (r *queryResolver) ResourceDetails(ctx context.Context, id string) (*model.
 		return nil, fmt.Errorf("resource not found")
 	}
 
-	return dbResourceToModel(&dbResource), nil
+	var usage *resources.ResourceUsage
+	if u, err := r.ResourceQueries.GetResourceUsage(ctx, dbResource.ID); err == nil {
+		usage = &u
+	}
+
+	return dbResourceToModel(&dbResource, usage), nil
 }
 
 // Project is the resolver for the project field.
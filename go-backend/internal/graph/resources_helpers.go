@@ -1,24 +1,84 @@
 package graph
 
 import (
+	"context"
 	"encoding/json"
 
 	"github.com/augustdev/autoclip/internal/graph/model"
+	resourcesvc "github.com/augustdev/autoclip/internal/resources"
 	"github.com/augustdev/autoclip/internal/storage/pg/generated/resources"
 )
 
-func dbResourceToModel(dbResource *resources.Resource) *model.Resource {
+func (r *Resolver) getResourcesForProject(ctx context.Context, userID, projectID string) ([]*model.Resource, error) {
+	dbResources, err := r.ResourceQueries.ListResourcesByProject(ctx, resources.ListResourcesByProjectParams{
+		ProjectID: projectID,
+		Limit:     1000,
+		Offset:    0,
+	})
+	if err != nil {
+		return nil, err
+	}
+	usage, err := r.resourceUsageByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*model.Resource, len(dbResources))
+	for i, dbResource := range dbResources {
+		result[i] = dbResourceToModel(&dbResource, usage[dbResource.ID])
+	}
+	return result, nil
+}
+
+// resourceUsageByID returns the recorded usage of the user's resources.
+func (r *Resolver) resourceUsageByID(ctx context.Context, userID string) (map[string]*resources.ResourceUsage, error) {
+	rows, err := r.ResourceQueries.ListResourceUsageByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	usage := make(map[string]*resources.ResourceUsage, len(rows))
+	for i := range rows {
+		usage[rows[i].ResourceID] = &rows[i]
+	}
+	return usage, nil
+}
+
+// dbResourceToModel converts a resource and its recorded usage, which is
+// nil for resources that don't report any.
+func dbResourceToModel(dbResource *resources.Resource, usage *resources.ResourceUsage) *model.Resource {
 	var metadata *model.ResourceMetadata
 	if dbResource.Metadata != nil {
 		var m map[string]string
 		if err := json.Unmarshal(dbResource.Metadata, &m); err == nil {
 			metadata = &model.ResourceMetadata{
-				Size:     strPtr(m["size"]),
-				Hostname: strPtr(m["hostname"]),
-				Group:    strPtr(m["group"]),
+				Size:       strPtr(m["size"]),
+				Hostname:   strPtr(m["hostname"]),
+				Group:      strPtr(m["group"]),
+				ForkedFrom: strPtr(m["forked_from"]),
+				ForkedAt:   strPtr(m["forked_at"]),
+				RestoredTo: strPtr(m["restored_to"]),
+				RestoredAt: strPtr(m["restored_at"]),
 			}
 		}
 	}
+	if usage != nil {
+		if metadata == nil {
+			metadata = &model.ResourceMetadata{}
+		}
+		storageBytes := int(usage.StorageBytes)
+		rowsRead := int(usage.RowsRead)
+		rowsWritten := int(usage.RowsWritten)
+		metadata.StorageBytes = &storageBytes
+		metadata.RowsRead = &rowsRead
+		metadata.RowsWritten = &rowsWritten
+		if usage.SizeLimitBytes > 0 {
+			sizeLimitBytes := int(usage.SizeLimitBytes)
+			metadata.SizeLimitBytes = &sizeLimitBytes
+		}
+		metadata.WritesBlocked = &usage.WritesBlocked
+		metadata.UsageWarning = strPtr(resourcesvc.UsageWarning(usage.StorageBytes, usage.SizeLimitBytes, usage.WritesBlocked))
+		metadata.UsageUpdatedAt = &usage.UpdatedAt.Time
+	}
 
 	return &model.Resource{
 		ID:        dbResource.ID,
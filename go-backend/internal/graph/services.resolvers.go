@@ -8,6 +8,7 @@ package graph
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/augustdev/autoclip/internal/authz"
 	"github.com/augustdev/autoclip/internal/deployments"
@@ -40,6 +41,72 @@ func (r *mutationResolver) DeleteService(ctx context.Context, name string, proje
 	}, nil
 }
 
+// RollbackService is the resolver for the rollbackService field.
+func (r *mutationResolver) RollbackService(ctx context.Context, name string, project *string, deploymentID *string) (*model.RollbackServiceResult, error) {
+	userID := authz.For(ctx).GetUserID()
+
+	projectRef := "default"
+	if project != nil && *project != "" {
+		projectRef = *project
+	}
+
+	target := deployments.RollbackPrevious
+	if deploymentID != nil && *deploymentID != "" {
+		target = *deploymentID
+	}
+
+	result, err := r.DeployService.RollbackService(ctx, deployments.RollbackServiceParams{
+		Name:         name,
+		Project:      projectRef,
+		UserID:       userID,
+		DeploymentID: target,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.RollbackServiceResult{
+		ServiceID:          result.ServiceID,
+		DeploymentID:       result.DeploymentID,
+		SourceDeploymentID: result.SourceDeploymentID,
+		CommitHash:         result.CommitHash,
+		Message:            "Rollback initiated",
+	}, nil
+}
+
+// CancelDeployment is the resolver for the cancelDeployment field.
+func (r *mutationResolver) CancelDeployment(ctx context.Context, name string, project *string, deploymentID *string) (*model.CancelDeploymentResult, error) {
+	userID := authz.For(ctx).GetUserID()
+
+	projectRef := "default"
+	if project != nil && *project != "" {
+		projectRef = *project
+	}
+
+	target := ""
+	if deploymentID != nil {
+		target = *deploymentID
+	}
+
+	result, err := r.DeployService.CancelDeployment(ctx, deployments.CancelDeploymentParams{
+		Name:         name,
+		Project:      projectRef,
+		UserID:       userID,
+		DeploymentID: target,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.CancelDeploymentResult{
+		ServiceID:          result.ServiceID,
+		DeploymentID:       result.DeploymentID,
+		PreviousStatus:     result.PreviousStatus,
+		ActiveDeploymentID: result.ActiveDeploymentID,
+		Message:            "Deployment cancelled",
+	}, nil
+}
+
 // ListServices is the resolver for the listServices field.
 func (r *queryResolver) ListServices(ctx context.Context, first *int32, after *string) (*model.ServiceConnection, error) {
 	userID := authz.For(ctx).GetUserID()
@@ -128,6 +195,57 @@ func (r *serviceResolver) Project(ctx context.Context, obj *model.Service) (*mod
 	return dbProjectToModel(&dbProject, nil), nil
 }
 
+// Deployments is the resolver for the deployments field.
+func (r *serviceResolver) Deployments(ctx context.Context, obj *model.Service, first *int32, after *string) (*model.DeploymentConnection, error) {
+	limit := int32(20)
+	if first != nil && *first > 0 {
+		limit = min(*first, 100)
+	}
+
+	cursor := ""
+	if after != nil {
+		cursor = *after
+	}
+
+	// Fetch one extra row to know whether another page exists.
+	dbDeployments, totalCount, err := r.DeployService.ListDeployments(ctx, deployments.ListDeploymentsParams{
+		ServiceID: obj.ID,
+		Limit:     limit + 1,
+		After:     cursor,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	hasNextPage := int32(len(dbDeployments)) > limit
+	if hasNextPage {
+		dbDeployments = dbDeployments[:limit]
+	}
+
+	now := time.Now()
+	nodes := make([]*model.Deployment, len(dbDeployments))
+	for i := range dbDeployments {
+		nodes[i] = dbDeploymentToModel(&dbDeployments[i], now)
+	}
+
+	var startCursor, endCursor *string
+	if len(nodes) > 0 {
+		startCursor = &nodes[0].ID
+		endCursor = &nodes[len(nodes)-1].ID
+	}
+
+	return &model.DeploymentConnection{
+		Nodes: nodes,
+		PageInfo: &model.PageInfo{
+			HasNextPage:     hasNextPage,
+			HasPreviousPage: cursor != "",
+			StartCursor:     startCursor,
+			EndCursor:       endCursor,
+		},
+		TotalCount: int32(totalCount),
+	}, nil
+}
+
 // Service returns ServiceResolver implementation.
 func (r *Resolver) Service() ServiceResolver { return &serviceResolver{r} }
 
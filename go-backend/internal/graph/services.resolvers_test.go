@@ -0,0 +1,145 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/augustdev/autoclip/internal/auth"
+	"github.com/augustdev/autoclip/internal/deployments"
+	"github.com/augustdev/autoclip/internal/graph/model"
+	deploymentsdb "github.com/augustdev/autoclip/internal/storage/pg/generated/deployments"
+	"github.com/jackc/pgx/v5"
+	"k8s.io/utils/ptr"
+)
+
+// pagedDeployments holds deployments newest first and pages them the way
+// the deployment queries do.
+type pagedDeployments struct {
+	deploymentsdb.Querier
+	deps []deploymentsdb.Deployment
+}
+
+func (p *pagedDeployments) of(serviceID string) []deploymentsdb.Deployment {
+	var deps []deploymentsdb.Deployment
+	for _, d := range p.deps {
+		if d.ServiceID == serviceID {
+			deps = append(deps, d)
+		}
+	}
+	return deps
+}
+
+func (p *pagedDeployments) CountDeploymentsByServiceID(ctx context.Context, serviceID string) (int64, error) {
+	return int64(len(p.of(serviceID))), nil
+}
+
+func (p *pagedDeployments) GetDeploymentByID(ctx context.Context, id string) (deploymentsdb.Deployment, error) {
+	for _, d := range p.deps {
+		if d.ID == id {
+			return d, nil
+		}
+	}
+	return deploymentsdb.Deployment{}, pgx.ErrNoRows
+}
+
+func (p *pagedDeployments) ListDeploymentsByServiceID(ctx context.Context, arg deploymentsdb.ListDeploymentsByServiceIDParams) ([]deploymentsdb.Deployment, error) {
+	deps := p.of(arg.ServiceID)
+	deps = deps[min(int(arg.Offset), len(deps)):]
+	return deps[:min(int(arg.Limit), len(deps))], nil
+}
+
+func (p *pagedDeployments) ListDeploymentsByServiceIDAfter(ctx context.Context, arg deploymentsdb.ListDeploymentsByServiceIDAfterParams) ([]deploymentsdb.Deployment, error) {
+	deps := p.of(arg.ServiceID)
+	i := slices.IndexFunc(deps, func(d deploymentsdb.Deployment) bool { return d.ID == arg.ID })
+	if i < 0 {
+		return nil, nil
+	}
+	deps = deps[i+1:]
+	return deps[:min(int(arg.Limit), len(deps))], nil
+}
+
+// newDeploymentsResolver serves n deployments of svc-1, dep-n newest, and
+// one of svc-2.
+func newDeploymentsResolver(n int) *serviceResolver {
+	q := &pagedDeployments{}
+	for i := n; i > 0; i-- {
+		q.deps = append(q.deps, deploymentsdb.Deployment{ID: fmt.Sprintf("dep-%d", i), ServiceID: "svc-1", Status: "superseded"})
+	}
+	q.deps = append(q.deps, deploymentsdb.Deployment{ID: "other", ServiceID: "svc-2", Status: "active"})
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	svc := deployments.NewService(nil, nil, q, nil, nil, nil, nil, auth.Config{}, logger)
+	return &serviceResolver{&Resolver{DeployService: svc, Logger: logger}}
+}
+
+func TestDeployments_FirstClamping(t *testing.T) {
+	r := newDeploymentsResolver(120)
+	tests := []struct {
+		name  string
+		first *int32
+		want  int
+	}{
+		{"default", nil, 20},
+		{"zero uses the default", ptr.To(int32(0)), 20},
+		{"negative uses the default", ptr.To(int32(-5)), 20},
+		{"within range", ptr.To(int32(7)), 7},
+		{"capped at 100", ptr.To(int32(500)), 100},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn, err := r.Deployments(context.Background(), &model.Service{ID: "svc-1"}, tt.first, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(conn.Nodes) != tt.want || !conn.PageInfo.HasNextPage || conn.TotalCount != 120 {
+				t.Fatalf("got %d nodes, hasNextPage %v, total %d; want %d nodes of 120 and a next page",
+					len(conn.Nodes), conn.PageInfo.HasNextPage, conn.TotalCount, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeployments_HasNextPageAtBoundary(t *testing.T) {
+	r := newDeploymentsResolver(4)
+	first := ptr.To(int32(2))
+
+	page1, err := r.Deployments(context.Background(), &model.Service{ID: "svc-1"}, first, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !page1.PageInfo.HasNextPage || *page1.PageInfo.EndCursor != "dep-3" {
+		t.Fatalf("page 1 = %+v, want a next page after dep-3", page1.PageInfo)
+	}
+
+	// Exactly first deployments are left: the last page has no next one.
+	page2, err := r.Deployments(context.Background(), &model.Service{ID: "svc-1"}, first, page1.PageInfo.EndCursor)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for _, n := range page2.Nodes {
+		ids = append(ids, n.ID)
+	}
+	if !slices.Equal(ids, []string{"dep-2", "dep-1"}) || page2.PageInfo.HasNextPage {
+		t.Fatalf("page 2 = %v, hasNextPage %v; want dep-2, dep-1 and no next page", ids, page2.PageInfo.HasNextPage)
+	}
+}
+
+func TestDeployments_InvalidCursor(t *testing.T) {
+	r := newDeploymentsResolver(3)
+	for name, cursor := range map[string]string{
+		"malformed":         "not-a-deployment",
+		"another service's": "other",
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := r.Deployments(context.Background(), &model.Service{ID: "svc-1"}, nil, &cursor)
+			if err == nil || !strings.Contains(err.Error(), "deployment not found") {
+				t.Fatalf("Deployments(after=%q) error = %v, want deployment not found", cursor, err)
+			}
+		})
+	}
+}
@@ -2,9 +2,11 @@ package graph
 
 import (
 	"encoding/json"
+	"time"
 
-	deploymentsdb "github.com/augustdev/autoclip/internal/storage/pg/generated/deployments"
 	"github.com/augustdev/autoclip/internal/graph/model"
+	"github.com/augustdev/autoclip/internal/k8sdeployments"
+	deploymentsdb "github.com/augustdev/autoclip/internal/storage/pg/generated/deployments"
 	"github.com/augustdev/autoclip/internal/storage/pg/generated/services"
 )
 
@@ -14,13 +16,19 @@ func dbServiceToModel(dbService *services.Service) *model.Service {
 		var rawEnvVars []struct {
 			Key   string `json:"key"`
 			Value string `json:"value"`
+			Scope string `json:"scope"`
 		}
 		if err := json.Unmarshal(dbService.EnvVars, &rawEnvVars); err == nil {
 			envVars = make([]*model.EnvVar, len(rawEnvVars))
 			for i, ev := range rawEnvVars {
+				scope := ev.Scope
+				if scope == "" {
+					scope = k8sdeployments.EnvScopeBoth
+				}
 				envVars[i] = &model.EnvVar{
 					Key:   ev.Key,
 					Value: ev.Value,
+					Scope: scope,
 				}
 			}
 		}
@@ -29,6 +37,11 @@ func dbServiceToModel(dbService *services.Service) *model.Service {
 		envVars = []*model.EnvVar{}
 	}
 
+	var sleepingSince *time.Time
+	if dbService.SleptAt.Valid {
+		sleepingSince = &dbService.SleptAt.Time
+	}
+
 	return &model.Service{
 		ID:        dbService.ID,
 		ProjectID: dbService.ProjectID,
@@ -39,14 +52,19 @@ func dbServiceToModel(dbService *services.Service) *model.Service {
 			Build:   "none",
 			Runtime: "pending",
 		},
-		EnvVars:     envVars,
-		Fqdn:        dbService.Fqdn,
-		Port:        dbService.Port,
-		GitProvider: dbService.GitProvider,
-		Memory:      dbService.Memory,
-		Vcpus:       dbService.Vcpus,
-		CreatedAt:   dbService.CreatedAt.Time,
-		UpdatedAt:   dbService.UpdatedAt.Time,
+		EnvVars:           envVars,
+		Fqdn:              dbService.Fqdn,
+		Port:              dbService.Port,
+		GitProvider:       dbService.GitProvider,
+		SourceType:        dbService.SourceType,
+		Image:             dbService.Image,
+		Memory:            dbService.Memory,
+		Vcpus:             dbService.Vcpus,
+		Replicas:          dbService.Replicas,
+		SleepAfterSeconds: dbService.SleepAfter,
+		SleepingSince:     sleepingSince,
+		CreatedAt:         dbService.CreatedAt.Time,
+		UpdatedAt:         dbService.UpdatedAt.Time,
 	}
 }
 
@@ -79,3 +97,36 @@ func enrichServiceWithDeployment(svc *model.Service, dep *deploymentsdb.Deployme
 	svc.CommitHash = dep.CommitHash
 	svc.ErrorMessage = dep.ErrorMessage
 }
+
+func dbDeploymentToModel(dep *deploymentsdb.Deployment, now time.Time) *model.Deployment {
+	m := &model.Deployment{
+		ID:           dep.ID,
+		Status:       dep.Status,
+		Trigger:      dep.Trigger,
+		TriggerRef:   dep.TriggerRef,
+		CommitHash:   dep.CommitHash,
+		ImageRef:     dep.ImageRef,
+		ErrorMessage: dep.ErrorMessage,
+		CreatedAt:    dep.CreatedAt.Time,
+	}
+	if len(dep.BuildProgress) > 0 {
+		progress := string(dep.BuildProgress)
+		m.BuildProgress = &progress
+	}
+	if dep.StartedAt.Valid {
+		startedAt := dep.StartedAt.Time
+		m.StartedAt = &startedAt
+
+		end := now
+		if dep.FinishedAt.Valid {
+			end = dep.FinishedAt.Time
+		}
+		secs := int32(end.Sub(startedAt).Seconds())
+		m.DurationSeconds = &secs
+	}
+	if dep.FinishedAt.Valid {
+		finishedAt := dep.FinishedAt.Time
+		m.FinishedAt = &finishedAt
+	}
+	return m
+}
@@ -4,9 +4,33 @@ import "time"
 
 type Config struct {
 	PublicGitURL string // e.g. https://git.ml.ink
+
+	// MaxRepoBytes and MaxUserBytes are the disk quotas of one repo and of
+	// all of a user's repos. Pushes are refused once either is used up.
+	MaxRepoBytes int64
+	MaxUserBytes int64
 }
 
 const (
 	DefaultTimeout       = 30 * time.Second
 	DefaultTokenDuration = 1 * time.Hour
+
+	DefaultMaxRepoBytes = 1 << 30
+	DefaultMaxUserBytes = 5 << 30
 )
+
+// RepoQuota is MaxRepoBytes, or its default when unset.
+func (c Config) RepoQuota() int64 {
+	if c.MaxRepoBytes > 0 {
+		return c.MaxRepoBytes
+	}
+	return DefaultMaxRepoBytes
+}
+
+// UserQuota is MaxUserBytes, or its default when unset.
+func (c Config) UserQuota() int64 {
+	if c.MaxUserBytes > 0 {
+		return c.MaxUserBytes
+	}
+	return DefaultMaxUserBytes
+}
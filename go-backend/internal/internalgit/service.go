@@ -9,6 +9,8 @@ import (
 	"fmt"
 	"math/big"
 	"net/url"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/augustdev/autoclip/internal/storage/pg"
@@ -25,6 +27,8 @@ const (
 	maxSlugRetries = 5
 )
 
+var repoScopes = []string{"push", "pull"}
+
 type Service struct {
 	config      Config
 	repoQueries internalrepos.Querier
@@ -68,7 +72,7 @@ func (s *Service) CreateRepo(ctx context.Context, userID, projectID, repoName, d
 			return nil, fmt.Errorf("repo belongs to another user")
 		}
 		owner, gitName := splitFullName(existingRepo.FullName)
-		rawToken, err := s.createToken(ctx, userID, &existingRepo.ID, nil)
+		rawToken, err := s.createToken(ctx, userID, &existingRepo.ID, nil, repoScopes, nil)
 		if err != nil {
 			return nil, fmt.Errorf("create token: %w", err)
 		}
@@ -113,7 +117,7 @@ func (s *Service) CreateRepo(ctx context.Context, userID, projectID, repoName, d
 		return nil, fmt.Errorf("failed to store repo in database: %w", err)
 	}
 
-	rawToken, err := s.createToken(ctx, userID, &repo.ID, nil)
+	rawToken, err := s.createToken(ctx, userID, &repo.ID, nil, repoScopes, nil)
 	if err != nil {
 		return nil, fmt.Errorf("create token: %w", err)
 	}
@@ -141,7 +145,7 @@ func (s *Service) GetPushToken(ctx context.Context, userID, repoFullName string)
 		return nil, fmt.Errorf("invalid repo full name: %s", repoFullName)
 	}
 
-	rawToken, err := s.createToken(ctx, userID, &repo.ID, nil)
+	rawToken, err := s.createToken(ctx, userID, &repo.ID, nil, repoScopes, nil)
 	if err != nil {
 		return nil, fmt.Errorf("create token: %w", err)
 	}
@@ -174,6 +178,117 @@ func (s *Service) DeleteRepo(ctx context.Context, userID, repoFullName string) e
 	return nil
 }
 
+// CreateUploadToken returns a short-lived token that can upload source
+// tarballs for one upload service, along with the command that does it.
+func (s *Service) CreateUploadToken(ctx context.Context, userID, serviceID string) (*CreateUploadResult, error) {
+	expiresAt := time.Now().Add(DefaultTokenDuration)
+	rawToken, err := s.createToken(ctx, userID, nil, &serviceID, []string{"upload"}, &expiresAt)
+	if err != nil {
+		return nil, fmt.Errorf("create token: %w", err)
+	}
+
+	uploadURL := s.uploadURL()
+	return &CreateUploadResult{
+		UploadURL: uploadURL,
+		Command: fmt.Sprintf(
+			"tar -czf - --exclude=.git --exclude=node_modules . | curl --fail-with-body -u x-upload-token:%s -H 'Content-Type: application/gzip' --data-binary @- %s",
+			rawToken, uploadURL),
+		ExpiresAt: expiresAt.Format(time.RFC3339),
+	}, nil
+}
+
+// UpdateRepoPolicy changes the push policy the git server enforces on a
+// repo: its protected branches and pack and file size limits.
+func (s *Service) UpdateRepoPolicy(ctx context.Context, userID, repoFullName string, update RepoPolicyUpdate) (internalrepos.InternalRepo, error) {
+	repo, err := s.repoQueries.GetInternalRepoByFullName(ctx, repoFullName)
+	if err != nil {
+		return internalrepos.InternalRepo{}, fmt.Errorf("repo not found: %w", err)
+	}
+	if repo.UserID != userID {
+		return internalrepos.InternalRepo{}, fmt.Errorf("unauthorized: repo belongs to another user")
+	}
+
+	params := internalrepos.UpdateInternalRepoPolicyParams{
+		ID:                repo.ID,
+		ProtectedBranches: repo.ProtectedBranches,
+		MaxPackBytes:      repo.MaxPackBytes,
+		MaxFileBytes:      repo.MaxFileBytes,
+	}
+	if update.ProtectedBranches != nil {
+		params.ProtectedBranches = make([]string, 0, len(update.ProtectedBranches))
+		for _, branch := range update.ProtectedBranches {
+			branch = strings.TrimPrefix(strings.TrimSpace(branch), "refs/heads/")
+			if err := validateBranchPattern(branch); err != nil {
+				return internalrepos.InternalRepo{}, err
+			}
+			if !slices.Contains(params.ProtectedBranches, branch) {
+				params.ProtectedBranches = append(params.ProtectedBranches, branch)
+			}
+		}
+	}
+	for _, limit := range []struct {
+		name   string
+		value  *int64
+		target **int64
+	}{
+		{"max pack size", update.MaxPackBytes, &params.MaxPackBytes},
+		{"max file size", update.MaxFileBytes, &params.MaxFileBytes},
+	} {
+		switch {
+		case limit.value == nil:
+		case *limit.value < 0:
+			return internalrepos.InternalRepo{}, fmt.Errorf("%s can't be negative", limit.name)
+		case *limit.value == 0:
+			*limit.target = nil
+		default:
+			*limit.target = limit.value
+		}
+	}
+
+	return s.repoQueries.UpdateInternalRepoPolicy(ctx, params)
+}
+
+// ListRepos returns a user's repos, or only those of a project when
+// projectID is set, with their sizes as of the last push or gc.
+func (s *Service) ListRepos(ctx context.Context, userID, projectID string) (*ListReposResult, error) {
+	var repos []internalrepos.InternalRepo
+	var err error
+	if projectID != "" {
+		repos, err = s.repoQueries.ListInternalReposByProjectID(ctx, projectID)
+		repos = slices.DeleteFunc(repos, func(r internalrepos.InternalRepo) bool { return r.UserID != userID })
+	} else {
+		repos, err = s.repoQueries.ListInternalReposByUserID(ctx, userID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list repos: %w", err)
+	}
+
+	used, err := s.repoQueries.GetUserRepoUsage(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repo usage: %w", err)
+	}
+
+	return &ListReposResult{
+		Repos:          repos,
+		UsedBytes:      used,
+		RepoQuotaBytes: s.config.RepoQuota(),
+		UserQuotaBytes: s.config.UserQuota(),
+	}, nil
+}
+
+// validateBranchPattern checks a protected branch name or glob such as
+// release/*.
+func validateBranchPattern(pattern string) error {
+	switch {
+	case pattern == "":
+		return fmt.Errorf("protected branch names can't be empty")
+	case strings.HasPrefix(pattern, "-"), strings.Contains(pattern, ".."),
+		strings.ContainsAny(pattern, " \t\n\\~^:"):
+		return fmt.Errorf("invalid protected branch %q", pattern)
+	}
+	return nil
+}
+
 func (s *Service) GetRepoByFullName(ctx context.Context, fullName string) (internalrepos.InternalRepo, error) {
 	return s.repoQueries.GetInternalRepoByFullName(ctx, fullName)
 }
@@ -185,7 +300,7 @@ func (s *Service) GetRepoByProjectAndName(ctx context.Context, projectID, name s
 	})
 }
 
-func (s *Service) createToken(ctx context.Context, userID string, repoID *string, expiresAt *time.Time) (string, error) {
+func (s *Service) createToken(ctx context.Context, userID string, repoID *string, serviceID *string, scopes []string, expiresAt *time.Time) (string, error) {
 	rawBytes := make([]byte, 32)
 	if _, err := rand.Read(rawBytes); err != nil {
 		return "", fmt.Errorf("generate random token: %w", err)
@@ -207,8 +322,9 @@ func (s *Service) createToken(ctx context.Context, userID string, repoID *string
 		TokenPrefix: prefix,
 		UserID:      userID,
 		RepoID:      repoID,
-		Scopes:      []string{"push", "pull"},
+		Scopes:      scopes,
 		ExpiresAt:   expiresAtPg,
+		ServiceID:   serviceID,
 	})
 	if err != nil {
 		return "", fmt.Errorf("store token: %w", err)
@@ -224,6 +340,12 @@ func (s *Service) cloneURL(owner, repoName, token string) string {
 	return u.String()
 }
 
+func (s *Service) uploadURL() string {
+	u, _ := url.Parse(s.config.PublicGitURL)
+	u.Path = "/uploads"
+	return u.String()
+}
+
 func (s *Service) cloneURLWithoutAuth(owner, repoName string) string {
 	u, _ := url.Parse(s.config.PublicGitURL)
 	u.Path = fmt.Sprintf("/%s/%s.git", owner, repoName)
@@ -1,5 +1,7 @@
 package internalgit
 
+import "github.com/augustdev/autoclip/internal/storage/pg/generated/internalrepos"
+
 // CreateRepoResult is returned after creating a repo
 type CreateRepoResult struct {
 	Repo      string `json:"repo"`
@@ -13,3 +15,40 @@ type GetPushTokenResult struct {
 	GitRemote string `json:"git_remote"`
 	ExpiresAt string `json:"expires_at"`
 }
+
+// CreateUploadResult is returned when issuing a source upload token
+type CreateUploadResult struct {
+	UploadURL string `json:"upload_url"`
+	Command   string `json:"command"`
+	ExpiresAt string `json:"expires_at"`
+}
+
+// ListReposResult is a user's repos with their disk usage. UsedBytes
+// counts all of the user's repos, whichever were listed.
+type ListReposResult struct {
+	Repos          []internalrepos.InternalRepo
+	UsedBytes      int64
+	RepoQuotaBytes int64
+	UserQuotaBytes int64
+}
+
+// RepoPolicyUpdate changes a repo's push policy. Nil fields are kept; a
+// zero byte limit falls back to the git server's default.
+type RepoPolicyUpdate struct {
+	ProtectedBranches []string
+	MaxPackBytes      *int64
+	MaxFileBytes      *int64
+}
+
+// Repos live on the git server's disk, so deleting one is an activity of
+// the git server's worker. Other workers start it by these names rather
+// than by importing the git server.
+const (
+	GitServerTaskQueue     = "git-server"
+	DeleteRepoActivityName = "DeleteRepo"
+)
+
+// DeleteRepoInput names a repo as owner/repo.
+type DeleteRepoInput struct {
+	FullName string
+}
@@ -4,12 +4,14 @@ import (
 	"log/slog"
 
 	"github.com/augustdev/autoclip/internal/githubapp"
+	"github.com/augustdev/autoclip/internal/prometheus"
 	deploymentsdb "github.com/augustdev/autoclip/internal/storage/pg/generated/deployments"
 	"github.com/augustdev/autoclip/internal/storage/pg/generated/projects"
 	"github.com/augustdev/autoclip/internal/storage/pg/generated/services"
 	"github.com/augustdev/autoclip/internal/storage/pg/generated/users"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 )
 
 type Activities struct {
@@ -21,7 +23,9 @@ type Activities struct {
 	deploymentsQ deploymentsdb.Querier
 	projectsQ    projects.Querier
 	usersQ       users.Querier
+	prometheus   *prometheus.Client
 	config       Config
+	execInPod    podExecutor
 }
 
 func NewActivities(
@@ -33,7 +37,9 @@ func NewActivities(
 	deploymentsQ deploymentsdb.Querier,
 	projectsQ projects.Querier,
 	usersQ users.Querier,
+	prometheusClient *prometheus.Client,
 	config Config,
+	restConfig *rest.Config,
 ) *Activities {
 	return &Activities{
 		logger:       logger,
@@ -44,6 +50,8 @@ func NewActivities(
 		deploymentsQ: deploymentsQ,
 		projectsQ:    projectsQ,
 		usersQ:       usersQ,
+		prometheus:   prometheusClient,
 		config:       config,
+		execInPod:    newPodExecutor(k8s, restConfig),
 	}
 }
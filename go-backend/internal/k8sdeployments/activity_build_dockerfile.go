@@ -20,12 +20,16 @@ func (a *Activities) DockerfileBuild(ctx context.Context, input BuildImageInput)
 
 	lokiLogger := a.newBuildLokiLogger(input.Name, input.Namespace)
 
+	cacheName := input.Name
+	if input.CacheName != "" {
+		cacheName = input.CacheName
+	}
 	cacheRef := ""
 	if a.config.RegistryAddress != "" {
-		cacheRef = fmt.Sprintf("%s/cache/%s/%s:buildcache", a.config.RegistryAddress, input.Namespace, input.Name)
+		cacheRef = fmt.Sprintf("%s/cache/%s/%s:buildcache", a.config.RegistryAddress, input.Namespace, cacheName)
 	}
 
-	lokiLogger.Log("Building image from Dockerfile with BuildKit...")
+	lokiLogger.Log(fmt.Sprintf("Building %s from Dockerfile with BuildKit...", input.ImageRef))
 
 	err := buildWithDockerfile(ctx, buildkitSolveOpts{
 		BuildkitHost:   a.config.BuildkitHost,
@@ -22,6 +22,19 @@ func (a *Activities) CloneRepo(ctx context.Context, input CloneRepoInput) (*Clon
 		return nil, fmt.Errorf("create temp dir: %w", err)
 	}
 
+	if input.GitProvider == SourceUpload {
+		recordHeartbeat(ctx, "unpacking upload")
+		if err := a.fetchUpload(ctx, input.ServiceID, input.CommitSHA, dir); err != nil {
+			os.RemoveAll(dir)
+			return nil, err
+		}
+		a.logger.Info("CloneRepo unpacked upload", "serviceID", input.ServiceID, "digest", input.CommitSHA, "dir", dir)
+		return &CloneRepoResult{
+			SourcePath: dir,
+			CommitSHA:  input.CommitSHA,
+		}, nil
+	}
+
 	cloneURL, err := a.resolveCloneURL(ctx, input)
 	if err != nil {
 		os.RemoveAll(dir)
@@ -34,24 +34,56 @@ func (a *Activities) DeleteService(ctx context.Context, input DeleteServiceInput
 		return nil, fmt.Errorf("delete service: %w", err)
 	}
 
+	// Delete the activator Service (only present while the service sleeps)
+	err = a.k8s.CoreV1().Services(input.Namespace).Delete(ctx, wakeServiceName(input.Name), metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("delete wake service: %w", err)
+	}
+
 	// Delete Deployment
 	err = a.k8s.AppsV1().Deployments(input.Namespace).Delete(ctx, input.Name, metav1.DeleteOptions{})
 	if err != nil && !apierrors.IsNotFound(err) {
 		return nil, fmt.Errorf("delete deployment: %w", err)
 	}
 
+	// Delete HorizontalPodAutoscaler (only present for autoscaled services)
+	err = a.k8s.AutoscalingV2().HorizontalPodAutoscalers(input.Namespace).Delete(ctx, input.Name, metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("delete horizontal pod autoscaler: %w", err)
+	}
+
+	// Delete PodDisruptionBudget (only present for multi-replica services)
+	err = a.k8s.PolicyV1().PodDisruptionBudgets(input.Namespace).Delete(ctx, input.Name, metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("delete pod disruption budget: %w", err)
+	}
+
 	// Delete Secret
 	err = a.k8s.CoreV1().Secrets(input.Namespace).Delete(ctx, input.Name+"-env", metav1.DeleteOptions{})
 	if err != nil && !apierrors.IsNotFound(err) {
 		return nil, fmt.Errorf("delete secret: %w", err)
 	}
 
-	// Clean up namespace if no deployments remain
+	// Delete the image pull secret (only present for private registry images)
+	err = a.k8s.CoreV1().Secrets(input.Namespace).Delete(ctx, registryPullSecretName(input.Name), metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("delete registry pull secret: %w", err)
+	}
+
+	// Delete a compose stack's other components
+	if err := a.pruneComposeStack(ctx, input.Namespace, input.Name, nil, nil); err != nil {
+		return nil, fmt.Errorf("delete compose stack: %w", err)
+	}
+
+	// Clean up namespace if no deployments or databases remain
 	deployments, err := a.k8s.AppsV1().Deployments(input.Namespace).List(ctx, metav1.ListOptions{})
 	if err != nil {
 		a.logger.Warn("Failed to list deployments for namespace cleanup",
 			"namespace", input.Namespace, "error", err)
-	} else if len(deployments.Items) == 0 {
+	} else if statefulSets, err := a.k8s.AppsV1().StatefulSets(input.Namespace).List(ctx, metav1.ListOptions{}); err != nil {
+		a.logger.Warn("Failed to list statefulsets for namespace cleanup",
+			"namespace", input.Namespace, "error", err)
+	} else if len(deployments.Items) == 0 && len(statefulSets.Items) == 0 {
 		if err := a.k8s.CoreV1().Namespaces().Delete(ctx, input.Namespace, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
 			a.logger.Warn("Failed to delete empty namespace",
 				"namespace", input.Namespace, "error", err)
@@ -67,3 +99,17 @@ func (a *Activities) DeleteService(ctx context.Context, input DeleteServiceInput
 
 	return &DeleteServiceResult{Status: StatusDeleted}, nil
 }
+
+// DeleteNamespace removes a project's namespace along with whatever is left
+// in it, such as the volumes of its databases.
+func (a *Activities) DeleteNamespace(ctx context.Context, input DeleteNamespaceInput) error {
+	err := a.k8s.CoreV1().Namespaces().Delete(ctx, input.Namespace, metav1.DeleteOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("delete namespace: %w", err)
+	}
+	a.logger.Info("Deleted namespace", "namespace", input.Namespace)
+	return nil
+}
@@ -4,9 +4,17 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"maps"
+	"time"
 
+	"github.com/augustdev/autoclip/internal/resources"
+	"github.com/augustdev/autoclip/internal/storage/pg/generated/services"
+	"go.temporal.io/sdk/temporal"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 )
 
 func (a *Activities) Deploy(ctx context.Context, input DeployInput) (*DeployResult, error) {
@@ -20,17 +28,50 @@ func (a *Activities) Deploy(ctx context.Context, input DeployInput) (*DeployResu
 		return nil, err
 	}
 
-	bc := parseBuildConfig(id.Service.BuildConfig)
+	cfg, err := a.resolveDeployConfig(ctx, id.Service, input.SnapshotDeploymentID)
+	if err != nil {
+		return nil, err
+	}
+
 	// Prefer port resolved during build phase (carries EXPOSE detection).
 	// Fall back to DB value for in-flight workflows that predate the Port field.
 	appPort := input.Port
 	if appPort == "" {
-		appPort = id.Service.Port
+		appPort = cfg.Port
 	}
-	port := effectiveAppPort(id.Service.BuildPack, appPort, bc.PublishDirectory)
+	port := effectiveAppPort(cfg.BuildPack, appPort, cfg.BuildConfig.PublishDirectory)
 	portInt := ParsePortString(port)
 
-	envVars := parseEnvVars(id.Service.EnvVars)
+	stack := input.Stack
+	if stack == nil {
+		stack = cfg.Stack
+	}
+	var public *ComposeComponent
+	if stack != nil {
+		public = stack.Public()
+	}
+
+	// The service's own env vars win over its bound resources' credentials,
+	// which win over the compose file's defaults.
+	envVars, err := a.runtimeEnvVars(ctx, id.Service, cfg.EnvVars)
+	if err != nil {
+		return nil, err
+	}
+	resourceEnv, err := a.resourceEnvVars(ctx, id.Service.ID)
+	if err != nil {
+		return nil, err
+	}
+	var composeEnv map[string]string
+	if public != nil {
+		composeEnv = public.Environment
+	}
+	for _, defaults := range []map[string]string{resourceEnv, composeEnv} {
+		for k, v := range defaults {
+			if _, ok := envVars[k]; !ok {
+				envVars[k] = v
+			}
+		}
+	}
 	envVars["PORT"] = port
 
 	// Ensure namespace
@@ -38,16 +79,38 @@ func (a *Activities) Deploy(ctx context.Context, input DeployInput) (*DeployResu
 		return nil, fmt.Errorf("ensure namespace: %w", err)
 	}
 
+	// Apply the other components of a compose stack and drop any that left it
+	components, err := a.applyComposeStack(ctx, id.Namespace, id.Name, stack, cfg.Memory, cfg.VCPUs)
+	if err != nil {
+		return nil, fmt.Errorf("apply compose stack: %w", err)
+	}
+
 	// Apply Secret
 	if err := a.applySecret(ctx, id.Namespace, id.Name, envVars); err != nil {
 		return nil, fmt.Errorf("apply secret: %w", err)
 	}
 
+	// Apply or remove the private registry's image pull secret
+	pullSecret, err := a.applyRegistryPullSecret(ctx, id.Namespace, id.Name, input.ImageRef, id.Service)
+	if err != nil {
+		return nil, fmt.Errorf("apply registry pull secret: %w", err)
+	}
+
 	// Apply Deployment
-	if err := a.applyDeployment(ctx, id.Namespace, id.Name, input.ImageRef, portInt, id.Service.Memory, id.Service.Vcpus); err != nil {
+	if err := a.applyDeployment(ctx, id.Namespace, id.Name, input.ImageRef, portInt, cfg.Memory, cfg.VCPUs, cfg.Replicas, cfg.Autoscaling, cfg.BuildConfig.HealthCheck, public, id.MaxReplicas, pullSecret); err != nil {
 		return nil, fmt.Errorf("apply deployment: %w", err)
 	}
 
+	// Apply or remove HorizontalPodAutoscaler
+	if err := a.applyHorizontalPodAutoscaler(ctx, id.Namespace, id.Name, cfg.Autoscaling); err != nil {
+		return nil, fmt.Errorf("apply horizontal pod autoscaler: %w", err)
+	}
+
+	// Apply or remove PodDisruptionBudget
+	if err := a.applyPodDisruptionBudget(ctx, id.Namespace, id.Name, maxPodCount(cfg.Replicas, cfg.Autoscaling)); err != nil {
+		return nil, fmt.Errorf("apply pod disruption budget: %w", err)
+	}
+
 	// Apply Service
 	if err := a.applyService(ctx, id.Namespace, id.Name, portInt); err != nil {
 		return nil, fmt.Errorf("apply service: %w", err)
@@ -59,6 +122,13 @@ func (a *Activities) Deploy(ctx context.Context, input DeployInput) (*DeployResu
 		return nil, fmt.Errorf("apply ingress: %w", err)
 	}
 
+	// A deploy wakes a sleeping service; drop what routed it to the activator.
+	if id.Service.SleptAt.Valid {
+		if err := a.finishWake(ctx, id.Namespace, id.Name, id.Service.ID, portInt); err != nil {
+			return nil, fmt.Errorf("finish wake: %w", err)
+		}
+	}
+
 	url := fmt.Sprintf("https://%s", host)
 	a.logger.Info("Deploy completed",
 		"serviceID", input.ServiceID,
@@ -70,9 +140,165 @@ func (a *Activities) Deploy(ctx context.Context, input DeployInput) (*DeployResu
 		Namespace:      id.Namespace,
 		DeploymentName: id.Name,
 		URL:            url,
+		Port:           port,
+		Stack:          stack,
+		Components:     components,
+	}, nil
+}
+
+// deployConfig is the runtime config a Deploy applies: either the service's
+// live config or the snapshot frozen on a deployment row.
+type deployConfig struct {
+	BuildPack   string
+	BuildConfig BuildConfig
+	EnvVars     []byte
+	Port        string
+	Memory      string
+	VCPUs       string
+	Replicas    int32
+	Autoscaling *AutoscalingConfig
+	Stack       *ComposeStack
+}
+
+func (a *Activities) resolveDeployConfig(ctx context.Context, svc services.Service, snapshotDeploymentID string) (deployConfig, error) {
+	if snapshotDeploymentID == "" {
+		return deployConfig{
+			BuildPack:   svc.BuildPack,
+			BuildConfig: parseBuildConfig(svc.BuildConfig),
+			EnvVars:     svc.EnvVars,
+			Port:        svc.Port,
+			Memory:      svc.Memory,
+			VCPUs:       svc.Vcpus,
+			Replicas:    svc.Replicas,
+			Autoscaling: ParseAutoscaling(svc.Autoscaling),
+		}, nil
+	}
+
+	dep, err := a.deploymentsQ.GetDeploymentByID(ctx, snapshotDeploymentID)
+	if err != nil {
+		return deployConfig{}, fmt.Errorf("get snapshot deployment %s: %w", snapshotDeploymentID, err)
+	}
+	if dep.ServiceID != svc.ID {
+		return deployConfig{}, fmt.Errorf("deployment %s does not belong to service %s", snapshotDeploymentID, svc.ID)
+	}
+	return deployConfig{
+		BuildPack:   dep.BuildPack,
+		BuildConfig: parseBuildConfig(dep.BuildConfig),
+		EnvVars:     dep.EnvVarsSnapshot,
+		Port:        dep.Port,
+		Memory:      dep.Memory,
+		VCPUs:       dep.Vcpus,
+		Replicas:    dep.Replicas,
+		Autoscaling: ParseAutoscaling(dep.Autoscaling),
+		Stack:       parseComposeStack(dep.Stack),
 	}, nil
 }
 
+// resourceEnvVars returns the credentials of the resources bound to a
+// service as env vars. They are read on every deploy, so rotated
+// credentials reach the service on its next one. A resource created along
+// with the service may still be provisioning; the deploy waits for it
+// rather than starting the app without its database.
+func (a *Activities) resourceEnvVars(ctx context.Context, serviceID string) (map[string]string, error) {
+	var bound []services.ListServiceResourcesRow
+	for {
+		recordHeartbeat(ctx)
+		var err error
+		bound, err = a.servicesQ.ListServiceResources(ctx, serviceID)
+		if err != nil {
+			return nil, fmt.Errorf("list bound resources: %w", err)
+		}
+		pending := ""
+		for _, r := range bound {
+			switch r.Status {
+			case resources.StatusFailed:
+				return nil, temporal.NewNonRetryableApplicationError(
+					fmt.Sprintf("resource %s failed to provision; delete and recreate it", r.Name),
+					"resource_failed",
+					nil,
+				)
+			case resources.StatusProvisioning:
+				pending = r.Name
+			}
+		}
+		if pending == "" {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("wait for resource %s to provision: %w", pending, ctx.Err())
+		case <-time.After(waitForRolloutPollInterval):
+		}
+	}
+
+	env := make(map[string]string)
+	for _, r := range bound {
+		if len(r.Credentials) == 0 {
+			continue
+		}
+		creds, err := resources.DecryptCredentials(r.Credentials, a.config.CredentialsEncryptionKey)
+		if err != nil {
+			return nil, fmt.Errorf("decrypt credentials of resource %s: %w", r.Name, err)
+		}
+		maps.Copy(env, resources.EnvVars(r.EnvPrefix, r.Type, creds))
+	}
+	return env, nil
+}
+
+// runtimeEnvVars decodes the env vars the service runs with, its resource
+// references resolved in the service's project.
+func (a *Activities) runtimeEnvVars(ctx context.Context, svc services.Service, raw json.RawMessage) (map[string]string, error) {
+	envVars := parseEnvVars(raw, EnvScopeRuntime)
+	if err := a.expandResourceRefs(ctx, svc.ProjectID, envVars); err != nil {
+		return nil, err
+	}
+	return envVars, nil
+}
+
+// expandResourceRefs replaces ${{resources.<name>.<field>}} references in
+// env var values with the current credentials of the resource of that name
+// in the service's project. A missing resource fails the deploy rather than
+// starting the app without them.
+func (a *Activities) expandResourceRefs(ctx context.Context, projectID string, envVars map[string]string) error {
+	cache := make(map[string]*resources.Credentials)
+	lookup := func(name string) (*resources.Credentials, error) {
+		if creds, ok := cache[name]; ok {
+			return creds, nil
+		}
+		r, err := a.servicesQ.GetReferencedResource(ctx, services.GetReferencedResourceParams{
+			ProjectID: projectID,
+			Name:      name,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("resource %q not found", name)
+		}
+		if r.Status != resources.StatusActive {
+			return nil, fmt.Errorf("resource %q is %s", name, r.Status)
+		}
+		creds := &resources.Credentials{}
+		if len(r.Credentials) > 0 {
+			if creds, err = resources.DecryptCredentials(r.Credentials, a.config.CredentialsEncryptionKey); err != nil {
+				return nil, fmt.Errorf("decrypt credentials of resource %s: %w", name, err)
+			}
+		}
+		cache[name] = creds
+		return creds, nil
+	}
+
+	for k, v := range envVars {
+		expanded, err := resources.ExpandEnvRefs(v, lookup)
+		if err != nil {
+			return temporal.NewNonRetryableApplicationError(
+				fmt.Sprintf("env var %s: %v", k, err),
+				"env_reference_invalid",
+				err,
+			)
+		}
+		envVars[k] = expanded
+	}
+	return nil
+}
+
 func (a *Activities) ensureNamespace(ctx context.Context, namespace, tenant, project string) error {
 	ns := buildNamespace(namespace, tenant, project)
 	nsData, err := json.Marshal(ns)
@@ -127,16 +353,123 @@ func (a *Activities) applySecret(ctx context.Context, namespace, name string, en
 	return err
 }
 
-func (a *Activities) applyDeployment(ctx context.Context, namespace, name, imageRef string, port int32, memory, vcpus string) error {
-	if err := validateResourceLimits(memory, vcpus); err != nil {
+// applyRegistryPullSecret writes the pull secret of a service whose image
+// is in a private registry and returns its name, or deletes one left over
+// from earlier credentials and returns "".
+func (a *Activities) applyRegistryPullSecret(ctx context.Context, namespace, name, imageRef string, svc services.Service) (string, error) {
+	creds, err := a.registryCredentials(svc)
+	if err != nil {
+		return "", err
+	}
+	if svc.SourceType != SourceImage || creds == nil {
+		err := a.k8s.CoreV1().Secrets(namespace).Delete(ctx, registryPullSecretName(name), metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return "", err
+		}
+		return "", nil
+	}
+
+	dockerConfig, err := dockerConfigJSON(imageRef, *creds)
+	if err != nil {
+		return "", err
+	}
+	data, err := json.Marshal(buildRegistryPullSecret(namespace, name, dockerConfig))
+	if err != nil {
+		return "", fmt.Errorf("marshal registry pull secret: %w", err)
+	}
+	_, err = a.k8s.CoreV1().Secrets(namespace).Patch(ctx, registryPullSecretName(name),
+		types.ApplyPatchType, data,
+		metav1.PatchOptions{FieldManager: "temporal-worker"})
+	if err != nil {
+		return "", err
+	}
+	return registryPullSecretName(name), nil
+}
+
+// maxPodCount is the most pods a service can run, which decides whether it
+// gets a PodDisruptionBudget and topology spread.
+func maxPodCount(replicas int32, autoscaling *AutoscalingConfig) int32 {
+	if autoscaling != nil {
+		return autoscaling.MaxReplicas
+	}
+	return replicas
+}
+
+func (a *Activities) applyDeployment(ctx context.Context, namespace, name, imageRef string, port int32, memory, vcpus string, replicas int32, autoscaling *AutoscalingConfig, healthCheck *HealthCheck, public *ComposeComponent, maxReplicas int32, pullSecret string) error {
+	if err := validateResourceLimits(memory, vcpus, replicas, maxReplicas); err != nil {
+		return err
+	}
+	if err := ValidateAutoscaling(autoscaling, maxReplicas); err != nil {
 		return err
 	}
-	deployment := buildDeployment(namespace, name, imageRef, port, memory, vcpus)
+	deployment := buildDeployment(namespace, name, imageRef, port, memory, vcpus, maxPodCount(replicas, autoscaling), healthCheck)
+	if autoscaling != nil {
+		// The HPA owns the replica count, but leaving it out of the applied
+		// object would have the API server reset a deployment that had a
+		// fixed count back to 1. Applying what runs now keeps it in place.
+		current, err := a.k8s.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("get deployment: %w", err)
+		}
+		var running *int32
+		if err == nil {
+			running = current.Spec.Replicas
+		}
+		deployment.Spec.Replicas = ptr.To(autoscaledReplicas(running, *autoscaling))
+	}
+	if public != nil {
+		setComposeCommand(&deployment.Spec.Template.Spec.Containers[0], *public)
+	}
+	if pullSecret != "" {
+		deployment.Spec.Template.Spec.ImagePullSecrets = []corev1.LocalObjectReference{{Name: pullSecret}}
+	}
 	data, err := json.Marshal(deployment)
 	if err != nil {
 		return fmt.Errorf("marshal deployment: %w", err)
 	}
+	// Force takes spec.replicas back from the sleep scaler if the service
+	// was asleep.
 	_, err = a.k8s.AppsV1().Deployments(namespace).Patch(ctx, name,
+		types.ApplyPatchType, data,
+		metav1.PatchOptions{FieldManager: "temporal-worker", Force: ptr.To(true)})
+	return err
+}
+
+func (a *Activities) applyPodDisruptionBudget(ctx context.Context, namespace, name string, replicas int32) error {
+	if replicas <= 1 {
+		// A single replica gains nothing from a PDB; drop any left over from
+		// an earlier multi-replica config.
+		err := a.k8s.PolicyV1().PodDisruptionBudgets(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+		return nil
+	}
+	pdb := buildPodDisruptionBudget(namespace, name)
+	data, err := json.Marshal(pdb)
+	if err != nil {
+		return fmt.Errorf("marshal pod disruption budget: %w", err)
+	}
+	_, err = a.k8s.PolicyV1().PodDisruptionBudgets(namespace).Patch(ctx, name,
+		types.ApplyPatchType, data,
+		metav1.PatchOptions{FieldManager: "temporal-worker"})
+	return err
+}
+
+func (a *Activities) applyHorizontalPodAutoscaler(ctx context.Context, namespace, name string, cfg *AutoscalingConfig) error {
+	if cfg == nil {
+		err := a.k8s.AutoscalingV2().HorizontalPodAutoscalers(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+		return nil
+	}
+	hpa := buildHorizontalPodAutoscaler(namespace, name, *cfg)
+	data, err := json.Marshal(hpa)
+	if err != nil {
+		return fmt.Errorf("marshal horizontal pod autoscaler: %w", err)
+	}
+	_, err = a.k8s.AutoscalingV2().HorizontalPodAutoscalers(namespace).Patch(ctx, name,
 		types.ApplyPatchType, data,
 		metav1.PatchOptions{FieldManager: "temporal-worker"})
 	return err
@@ -0,0 +1,238 @@
+package k8sdeployments
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// stackLabel marks the resources a compose stack adds next to its service's
+// own, naming the service they belong to, so they can be pruned and deleted
+// with it.
+const stackLabel = "dp.ml.ink/stack"
+
+// applyComposeStack applies a Secret, Deployment and Service for each
+// component other than the public one, which Deploy runs as the service
+// itself. Every component is reachable under its compose name, so the
+// public one gets a second Service too. Whatever an earlier version of the
+// stack had that this one doesn't is removed; a nil stack removes it all.
+// It returns the Deployments to wait for besides the service's own.
+func (a *Activities) applyComposeStack(ctx context.Context, namespace, name string, stack *ComposeStack, memory, vcpus string) ([]string, error) {
+	keepDeployments := make(map[string]bool)
+	keepServices := make(map[string]bool)
+	var components []string
+
+	if stack != nil {
+		if err := a.checkComposeNames(ctx, namespace, name, stack); err != nil {
+			return nil, err
+		}
+		for _, c := range stack.Components {
+			if c.Public {
+				if c.Name != name {
+					if err := a.applyComposeService(ctx, namespace, c.Name, name, name, c.Ports); err != nil {
+						return nil, fmt.Errorf("apply service %s: %w", c.Name, err)
+					}
+					keepServices[c.Name] = true
+				}
+				continue
+			}
+
+			componentName := ComposeComponentName(name, c.Name)
+			if err := a.applyComposeComponent(ctx, namespace, name, componentName, c, memory, vcpus); err != nil {
+				return nil, fmt.Errorf("apply %s: %w", c.Name, err)
+			}
+			keepDeployments[componentName] = true
+			components = append(components, componentName)
+
+			if len(c.Ports) > 0 {
+				if err := a.applyComposeService(ctx, namespace, c.Name, componentName, name, c.Ports); err != nil {
+					return nil, fmt.Errorf("apply service %s: %w", c.Name, err)
+				}
+				keepServices[c.Name] = true
+			}
+		}
+	}
+
+	if err := a.pruneComposeStack(ctx, namespace, name, keepDeployments, keepServices); err != nil {
+		return nil, fmt.Errorf("prune: %w", err)
+	}
+	return components, nil
+}
+
+// checkComposeNames refuses a stack whose names are already taken in the
+// namespace by another service, which applying would otherwise take over.
+func (a *Activities) checkComposeNames(ctx context.Context, namespace, name string, stack *ComposeStack) error {
+	for _, c := range stack.Components {
+		if c.Name != name {
+			existing, err := a.k8s.CoreV1().Services(namespace).Get(ctx, c.Name, metav1.GetOptions{})
+			switch {
+			case apierrors.IsNotFound(err):
+			case err != nil:
+				return fmt.Errorf("get service %s: %w", c.Name, err)
+			case existing.Labels[stackLabel] != name:
+				return fmt.Errorf("compose service %q clashes with service %q in this project; rename one of them", c.Name, c.Name)
+			}
+		}
+		if c.Public {
+			continue
+		}
+		componentName := ComposeComponentName(name, c.Name)
+		existing, err := a.k8s.AppsV1().Deployments(namespace).Get(ctx, componentName, metav1.GetOptions{})
+		switch {
+		case apierrors.IsNotFound(err):
+		case err != nil:
+			return fmt.Errorf("get deployment %s: %w", componentName, err)
+		case existing.Labels[stackLabel] != name:
+			return fmt.Errorf("compose service %q clashes with service %q in this project; rename one of them", c.Name, componentName)
+		}
+	}
+	return nil
+}
+
+func (a *Activities) applyComposeComponent(ctx context.Context, namespace, stackName, name string, c ComposeComponent, memory, vcpus string) error {
+	secret := buildSecret(namespace, name, c.Environment)
+	secret.Labels = map[string]string{stackLabel: stackName}
+	secretData, err := json.Marshal(secret)
+	if err != nil {
+		return fmt.Errorf("marshal secret: %w", err)
+	}
+	if _, err := a.k8s.CoreV1().Secrets(namespace).Patch(ctx, secret.Name,
+		types.ApplyPatchType, secretData,
+		metav1.PatchOptions{FieldManager: "temporal-worker"}); err != nil {
+		return fmt.Errorf("apply secret: %w", err)
+	}
+
+	deployment := buildComposeDeployment(namespace, stackName, name, c, memory, vcpus)
+	data, err := json.Marshal(deployment)
+	if err != nil {
+		return fmt.Errorf("marshal deployment: %w", err)
+	}
+	if _, err := a.k8s.AppsV1().Deployments(namespace).Patch(ctx, name,
+		types.ApplyPatchType, data,
+		metav1.PatchOptions{FieldManager: "temporal-worker"}); err != nil {
+		return fmt.Errorf("apply deployment: %w", err)
+	}
+	return nil
+}
+
+func (a *Activities) applyComposeService(ctx context.Context, namespace, name, app, stackName string, ports []int32) error {
+	svc := buildComposeService(namespace, name, app, stackName, ports)
+	data, err := json.Marshal(svc)
+	if err != nil {
+		return fmt.Errorf("marshal service: %w", err)
+	}
+	_, err = a.k8s.CoreV1().Services(namespace).Patch(ctx, name,
+		types.ApplyPatchType, data,
+		metav1.PatchOptions{FieldManager: "temporal-worker"})
+	return err
+}
+
+// pruneComposeStack deletes the stack resources of service name that aren't
+// in keepDeployments or keepServices.
+func (a *Activities) pruneComposeStack(ctx context.Context, namespace, name string, keepDeployments, keepServices map[string]bool) error {
+	selector := metav1.ListOptions{LabelSelector: stackLabel + "=" + name}
+
+	deployments, err := a.k8s.AppsV1().Deployments(namespace).List(ctx, selector)
+	if err != nil {
+		return fmt.Errorf("list deployments: %w", err)
+	}
+	for _, d := range deployments.Items {
+		if keepDeployments[d.Name] {
+			continue
+		}
+		if err := a.k8s.AppsV1().Deployments(namespace).Delete(ctx, d.Name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("delete deployment %s: %w", d.Name, err)
+		}
+	}
+
+	secrets, err := a.k8s.CoreV1().Secrets(namespace).List(ctx, selector)
+	if err != nil {
+		return fmt.Errorf("list secrets: %w", err)
+	}
+	for _, s := range secrets.Items {
+		if keepDeployments[strings.TrimSuffix(s.Name, "-env")] {
+			continue
+		}
+		if err := a.k8s.CoreV1().Secrets(namespace).Delete(ctx, s.Name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("delete secret %s: %w", s.Name, err)
+		}
+	}
+
+	services, err := a.k8s.CoreV1().Services(namespace).List(ctx, selector)
+	if err != nil {
+		return fmt.Errorf("list services: %w", err)
+	}
+	for _, s := range services.Items {
+		if keepServices[s.Name] {
+			continue
+		}
+		if err := a.k8s.CoreV1().Services(namespace).Delete(ctx, s.Name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("delete service %s: %w", s.Name, err)
+		}
+	}
+	return nil
+}
+
+// setComposeCommand applies a component's entrypoint and command, which
+// override the image's the way docker compose does.
+func setComposeCommand(container *corev1.Container, c ComposeComponent) {
+	container.Command = c.Entrypoint
+	container.Args = c.Command
+}
+
+// buildComposeDeployment runs one non-public component as a single replica
+// with the service's resource limits. Components without ports have nothing
+// to probe and count as ready once started.
+func buildComposeDeployment(namespace, stackName, name string, c ComposeComponent, memory, vcpus string) *appsv1.Deployment {
+	var port int32
+	if len(c.Ports) > 0 {
+		port = c.Ports[0]
+	}
+	deployment := buildDeployment(namespace, name, c.Image, port, memory, vcpus, 1, nil)
+	deployment.Labels[stackLabel] = stackName
+
+	container := &deployment.Spec.Template.Spec.Containers[0]
+	container.Ports = nil
+	for _, p := range c.Ports {
+		container.Ports = append(container.Ports, corev1.ContainerPort{ContainerPort: p})
+	}
+	if len(c.Ports) == 0 {
+		container.ReadinessProbe = nil
+	}
+	setComposeCommand(container, c)
+	return deployment
+}
+
+// buildComposeService makes a component reachable under its compose name on
+// each of its ports.
+func buildComposeService(namespace, name, app, stackName string, ports []int32) *corev1.Service {
+	servicePorts := make([]corev1.ServicePort, 0, len(ports))
+	for _, p := range ports {
+		servicePorts = append(servicePorts, corev1.ServicePort{
+			Name:       "tcp-" + strconv.Itoa(int(p)),
+			Port:       p,
+			TargetPort: intstr.FromInt32(p),
+		})
+	}
+	return &corev1.Service{
+		TypeMeta: metav1.TypeMeta{Kind: "Service", APIVersion: "v1"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    map[string]string{stackLabel: stackName},
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{"app": app},
+			Ports:    servicePorts,
+		},
+	}
+}
@@ -0,0 +1,217 @@
+package k8sdeployments
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/augustdev/autoclip/internal/resources"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	postgresImage = "postgres:16-alpine"
+	postgresPort  = 5432
+	postgresUser  = "app"
+	postgresDB    = "app"
+)
+
+// ApplyPostgres applies the objects of a postgres resource and stores its
+// credentials.
+func (a *Activities) ApplyPostgres(ctx context.Context, input resources.PostgresWorkflowInput) error {
+	namespace := NamespaceName(input.Tenant, input.ProjectRef)
+	name := PostgresName(input.Name)
+
+	a.logger.Info("ApplyPostgres activity started",
+		"resourceID", input.ResourceID,
+		"namespace", namespace,
+		"name", name)
+
+	if err := a.ensureNamespace(ctx, namespace, input.Tenant, input.ProjectRef); err != nil {
+		return fmt.Errorf("ensure namespace: %w", err)
+	}
+	if err := a.checkResourceName(ctx, namespace, name, input.ResourceID, input.Name); err != nil {
+		return err
+	}
+
+	password, err := a.resourcePassword(ctx, namespace, name, "POSTGRES_PASSWORD")
+	if err != nil {
+		return err
+	}
+	if err := a.applyResourceObjects(ctx, namespace, name, input.ResourceID, postgresEnv(password), "postgres", postgresPort); err != nil {
+		return err
+	}
+	if err := a.applyResourceStatefulSet(ctx, buildPostgresStatefulSet(namespace, name, input.ResourceID, input.Tier)); err != nil {
+		return err
+	}
+
+	host := postgresHost(namespace, name)
+	return a.storeResourceCredentials(ctx, input.ResourceID, postgresURL(host, password), resources.Metadata{Hostname: host, Namespace: namespace})
+}
+
+// RotatePostgresPassword gives a postgres resource's role a new password
+// and stores the connection string using it. The role has one password, so
+// the old one stops working at once.
+func (a *Activities) RotatePostgresPassword(ctx context.Context, input resources.PostgresWorkflowInput) error {
+	namespace := NamespaceName(input.Tenant, input.ProjectRef)
+	name := PostgresName(input.Name)
+
+	a.logger.Info("RotatePostgresPassword activity started",
+		"resourceID", input.ResourceID,
+		"namespace", namespace,
+		"name", name)
+
+	// psql connects over the local socket, which needs no password, so a
+	// retry gets in whichever password is current. The new one goes in on
+	// stdin to keep it out of the pod's process list.
+	password := rand.Text()
+	stmt := fmt.Sprintf("ALTER ROLE %s WITH PASSWORD '%s';\n", postgresUser, password)
+	if err := a.execInPod(ctx, namespace, name+"-0", "postgres",
+		[]string{"psql", "-v", "ON_ERROR_STOP=1", "-U", postgresUser, "-d", postgresDB},
+		strings.NewReader(stmt)); err != nil {
+		return fmt.Errorf("alter role: %w", err)
+	}
+
+	// ApplyPostgres reuses the password in the Secret, which has to follow
+	// or a later apply would store the old one again.
+	if err := a.applyResourceSecret(ctx, namespace, name, input.ResourceID, postgresEnv(password)); err != nil {
+		return err
+	}
+
+	host := postgresHost(namespace, name)
+	return a.storeResourceCredentials(ctx, input.ResourceID, postgresURL(host, password), resources.Metadata{
+		Hostname:  host,
+		Namespace: namespace,
+		RotatedAt: time.Now().UTC().Format(time.RFC3339),
+	})
+}
+
+func postgresEnv(password string) map[string]string {
+	return map[string]string{
+		"POSTGRES_USER":     postgresUser,
+		"POSTGRES_PASSWORD": password,
+		"POSTGRES_DB":       postgresDB,
+	}
+}
+
+func postgresHost(namespace, name string) string {
+	return fmt.Sprintf("%s.%s.svc.cluster.local", name, namespace)
+}
+
+func postgresURL(host, password string) string {
+	u := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(postgresUser, password),
+		Host:     fmt.Sprintf("%s:%d", host, postgresPort),
+		Path:     "/" + postgresDB,
+		RawQuery: "sslmode=disable",
+	}
+	return u.String()
+}
+
+// WaitForPostgres waits for the database to accept connections. A new
+// volume is provisioned and initialized first, which can take a while.
+func (a *Activities) WaitForPostgres(ctx context.Context, input resources.PostgresWorkflowInput) error {
+	return a.waitForStatefulSet(ctx, NamespaceName(input.Tenant, input.ProjectRef), PostgresName(input.Name))
+}
+
+// DeletePostgres removes a postgres resource's objects, its volume
+// included.
+func (a *Activities) DeletePostgres(ctx context.Context, input resources.PostgresWorkflowInput) error {
+	namespace := NamespaceName(input.Tenant, input.ProjectRef)
+	name := PostgresName(input.Name)
+
+	a.logger.Info("DeletePostgres activity started",
+		"resourceID", input.ResourceID,
+		"namespace", namespace,
+		"name", name)
+
+	return a.deleteResourceObjects(ctx, namespace, name, input.ResourceID)
+}
+
+// buildPostgresStatefulSet runs a single postgres instance on a volume of
+// the tier's size, sandboxed like the services next to it.
+func buildPostgresStatefulSet(namespace, name, resourceID string, tier resources.PostgresTier) *appsv1.StatefulSet {
+	memLimit := resource.MustParse(tier.Memory)
+	cpuLimit := resource.MustParse(tier.VCPUs)
+	labels := map[string]string{"app": name, resourceLabel: resourceID}
+
+	return &appsv1.StatefulSet{
+		TypeMeta: metav1.TypeMeta{Kind: "StatefulSet", APIVersion: "apps/v1"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    labels,
+		},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas:    ptr.To(int32(1)),
+			ServiceName: name,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"app": name},
+			},
+			PersistentVolumeClaimRetentionPolicy: &appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy{
+				WhenDeleted: appsv1.DeletePersistentVolumeClaimRetentionPolicyType,
+				WhenScaled:  appsv1.RetainPersistentVolumeClaimRetentionPolicyType,
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					RuntimeClassName:             ptr.To("gvisor"),
+					AutomountServiceAccountToken: ptr.To(false),
+					Containers: []corev1.Container{{
+						Name:  "postgres",
+						Image: postgresImage,
+						Ports: []corev1.ContainerPort{{ContainerPort: postgresPort}},
+						EnvFrom: []corev1.EnvFromSource{{SecretRef: &corev1.SecretEnvSource{
+							LocalObjectReference: corev1.LocalObjectReference{Name: name + "-env"},
+						}}},
+						// initdb refuses a mount point that isn't empty.
+						Env: []corev1.EnvVar{{Name: "PGDATA", Value: "/var/lib/postgresql/data/pgdata"}},
+						VolumeMounts: []corev1.VolumeMount{{
+							Name:      "data",
+							MountPath: "/var/lib/postgresql/data",
+						}},
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceCPU:    cpuLimit.DeepCopy(),
+								corev1.ResourceMemory: memLimit.DeepCopy(),
+							},
+							Limits: corev1.ResourceList{
+								corev1.ResourceCPU:    cpuLimit,
+								corev1.ResourceMemory: memLimit,
+							},
+						},
+						SecurityContext: &corev1.SecurityContext{
+							AllowPrivilegeEscalation: ptr.To(false),
+						},
+						ReadinessProbe: &corev1.Probe{
+							ProbeHandler: corev1.ProbeHandler{Exec: &corev1.ExecAction{
+								Command: []string{"pg_isready", "-h", "127.0.0.1", "-U", postgresUser, "-d", postgresDB},
+							}},
+							PeriodSeconds:    5,
+							FailureThreshold: 3,
+						},
+					}},
+				},
+			},
+			VolumeClaimTemplates: []corev1.PersistentVolumeClaim{{
+				ObjectMeta: metav1.ObjectMeta{Name: "data", Labels: labels},
+				Spec: corev1.PersistentVolumeClaimSpec{
+					AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+					Resources: corev1.VolumeResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceStorage: resource.MustParse(tier.Storage),
+						},
+					},
+				},
+			}},
+		},
+	}
+}
@@ -0,0 +1,206 @@
+package k8sdeployments
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/augustdev/autoclip/internal/resources"
+	"github.com/augustdev/autoclip/internal/storage/pg/generated/services"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+type credentialsRecorder struct {
+	services.Querier
+	stored []services.SetResourceCredentialsParams
+}
+
+func (r *credentialsRecorder) SetResourceCredentials(_ context.Context, arg services.SetResourceCredentialsParams) error {
+	r.stored = append(r.stored, arg)
+	return nil
+}
+
+func TestApplyPostgres(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewClientset()
+	q := &credentialsRecorder{}
+	a := &Activities{
+		k8s:       client,
+		servicesQ: q,
+		logger:    slog.New(slog.DiscardHandler),
+		config:    Config{CredentialsEncryptionKey: "key"},
+	}
+	input := resources.PostgresWorkflowInput{
+		ResourceID: "res-1",
+		Tenant:     "user1",
+		ProjectRef: "default",
+		Name:       "main-db",
+		Tier:       resources.PostgresTiers["5gb"],
+	}
+	namespace := NamespaceName("user1", "default")
+
+	if err := a.ApplyPostgres(ctx, input); err != nil {
+		t.Fatalf("ApplyPostgres() error = %v", err)
+	}
+	// The API server folds stringData into data; the fake client doesn't.
+	secret, err := client.CoreV1().Secrets(namespace).Get(ctx, "pg-main-db-env", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("get secret: %v", err)
+	}
+	secret.Data = map[string][]byte{"POSTGRES_PASSWORD": []byte(secret.StringData["POSTGRES_PASSWORD"])}
+	if _, err := client.CoreV1().Secrets(namespace).Update(ctx, secret, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("update secret: %v", err)
+	}
+	if err := a.ApplyPostgres(ctx, input); err != nil {
+		t.Fatalf("ApplyPostgres() retry error = %v", err)
+	}
+
+	sts, err := client.AppsV1().StatefulSets(namespace).Get(ctx, "pg-main-db", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("get statefulset: %v", err)
+	}
+	storage := sts.Spec.VolumeClaimTemplates[0].Spec.Resources.Requests[corev1.ResourceStorage]
+	if storage.String() != "5Gi" {
+		t.Fatalf("volume size = %s, want 5Gi", storage.String())
+	}
+
+	if len(q.stored) != 2 {
+		t.Fatalf("credentials stored %d times, want 2", len(q.stored))
+	}
+	first, err := resources.DecryptCredentials(q.stored[0].Credentials, "key")
+	if err != nil {
+		t.Fatalf("DecryptCredentials() error = %v", err)
+	}
+	second, _ := resources.DecryptCredentials(q.stored[1].Credentials, "key")
+	if first.URL != second.URL {
+		t.Fatalf("retry changed the connection string: %s then %s", first.URL, second.URL)
+	}
+	if want := "@pg-main-db." + namespace + ".svc.cluster.local:5432/app"; !strings.Contains(first.URL, want) {
+		t.Fatalf("URL = %s, want it to contain %s", first.URL, want)
+	}
+
+	// The fake client doesn't run the StatefulSet controller, so add the
+	// claim it would have created.
+	if _, err := client.CoreV1().PersistentVolumeClaims(namespace).Create(ctx, &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "data-pg-main-db-0", Labels: map[string]string{resourceLabel: "res-1"}},
+	}, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("create claim: %v", err)
+	}
+	if err := a.DeletePostgres(ctx, input); err != nil {
+		t.Fatalf("DeletePostgres() error = %v", err)
+	}
+	claims, _ := client.CoreV1().PersistentVolumeClaims(namespace).List(ctx, metav1.ListOptions{})
+	sets, _ := client.AppsV1().StatefulSets(namespace).List(ctx, metav1.ListOptions{})
+	if len(claims.Items) != 0 || len(sets.Items) != 0 {
+		t.Fatalf("DeletePostgres left %d claims and %d statefulsets", len(claims.Items), len(sets.Items))
+	}
+}
+
+func TestApplyPostgres_RefusesTakenName(t *testing.T) {
+	namespace := NamespaceName("user1", "default")
+	client := fake.NewClientset(&corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "pg-main-db", Namespace: namespace},
+	})
+	a := &Activities{k8s: client, logger: slog.New(slog.DiscardHandler)}
+
+	err := a.ApplyPostgres(context.Background(), resources.PostgresWorkflowInput{
+		ResourceID: "res-1",
+		Tenant:     "user1",
+		ProjectRef: "default",
+		Name:       "main-db",
+		Tier:       resources.PostgresTiers["1gb"],
+	})
+	if err == nil || !strings.Contains(err.Error(), "clashes with service") {
+		t.Fatalf("ApplyPostgres() error = %v, want a name clash", err)
+	}
+}
+
+func TestRotatePostgresPassword(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewClientset()
+	q := &credentialsRecorder{}
+	var execs []string
+	a := &Activities{
+		k8s:       client,
+		servicesQ: q,
+		logger:    slog.New(slog.DiscardHandler),
+		config:    Config{CredentialsEncryptionKey: "key"},
+		execInPod: func(_ context.Context, namespace, pod, container string, cmd []string, stdin io.Reader) error {
+			sql, _ := io.ReadAll(stdin)
+			execs = append(execs, fmt.Sprintf("%s/%s/%s: %s <- %s", namespace, pod, container, strings.Join(cmd, " "), sql))
+			return nil
+		},
+	}
+	input := resources.PostgresWorkflowInput{ResourceID: "res-1", Tenant: "user1", ProjectRef: "default", Name: "main-db"}
+	namespace := NamespaceName("user1", "default")
+
+	if err := a.RotatePostgresPassword(ctx, input); err != nil {
+		t.Fatalf("RotatePostgresPassword() error = %v", err)
+	}
+
+	if len(q.stored) != 1 {
+		t.Fatalf("credentials stored %d times, want once", len(q.stored))
+	}
+	creds, err := resources.DecryptCredentials(q.stored[0].Credentials, "key")
+	if err != nil {
+		t.Fatalf("DecryptCredentials() error = %v", err)
+	}
+	u, err := url.Parse(creds.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	password, _ := u.User.Password()
+
+	// The password reaches psql on stdin, not on the command line.
+	want := fmt.Sprintf("%s/pg-main-db-0/postgres: psql -v ON_ERROR_STOP=1 -U app -d app <- ALTER ROLE app WITH PASSWORD '%s';\n", namespace, password)
+	if len(execs) != 1 || execs[0] != want {
+		t.Fatalf("execs = %q, want %q", execs, want)
+	}
+
+	secret, err := client.CoreV1().Secrets(namespace).Get(ctx, "pg-main-db-env", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("get secret: %v", err)
+	}
+	if secret.StringData["POSTGRES_PASSWORD"] != password {
+		t.Fatal("secret still holds the old password")
+	}
+	var metadata resources.Metadata
+	if err := json.Unmarshal(q.stored[0].Metadata, &metadata); err != nil {
+		t.Fatal(err)
+	}
+	if metadata.RotatedAt == "" {
+		t.Fatalf("metadata = %+v, want rotated_at set", metadata)
+	}
+}
+
+func TestRotatePostgresPassword_ExecFails(t *testing.T) {
+	q := &credentialsRecorder{}
+	a := &Activities{
+		k8s:       fake.NewClientset(),
+		servicesQ: q,
+		logger:    slog.New(slog.DiscardHandler),
+		execInPod: func(context.Context, string, string, string, []string, io.Reader) error {
+			return errors.New("pod not running")
+		},
+	}
+	err := a.RotatePostgresPassword(context.Background(), resources.PostgresWorkflowInput{
+		ResourceID: "res-1",
+		Tenant:     "user1",
+		ProjectRef: "default",
+		Name:       "main-db",
+	})
+	if err == nil || !strings.Contains(err.Error(), "alter role") {
+		t.Fatalf("RotatePostgresPassword() error = %v, want an alter role error", err)
+	}
+	if len(q.stored) != 0 {
+		t.Fatal("credentials were stored although the password didn't change")
+	}
+}
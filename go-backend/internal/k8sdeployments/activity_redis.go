@@ -0,0 +1,188 @@
+package k8sdeployments
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/augustdev/autoclip/internal/resources"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	redisImage = "valkey/valkey:8-alpine"
+	redisPort  = 6379
+)
+
+// ApplyRedis applies the objects of a redis resource and stores its
+// credentials.
+func (a *Activities) ApplyRedis(ctx context.Context, input resources.RedisWorkflowInput) error {
+	namespace := NamespaceName(input.Tenant, input.ProjectRef)
+	name := RedisName(input.Name)
+
+	a.logger.Info("ApplyRedis activity started",
+		"resourceID", input.ResourceID,
+		"namespace", namespace,
+		"name", name,
+		"persistent", input.Persistent)
+
+	if err := a.ensureNamespace(ctx, namespace, input.Tenant, input.ProjectRef); err != nil {
+		return fmt.Errorf("ensure namespace: %w", err)
+	}
+	if err := a.checkResourceName(ctx, namespace, name, input.ResourceID, input.Name); err != nil {
+		return err
+	}
+
+	password, err := a.resourcePassword(ctx, namespace, name, "REDIS_PASSWORD")
+	if err != nil {
+		return err
+	}
+	if err := a.applyResourceObjects(ctx, namespace, name, input.ResourceID, map[string]string{
+		"REDIS_PASSWORD": password,
+	}, "redis", redisPort); err != nil {
+		return err
+	}
+	if err := a.applyResourceStatefulSet(ctx, buildRedisStatefulSet(namespace, name, input)); err != nil {
+		return err
+	}
+
+	host := fmt.Sprintf("%s.%s.svc.cluster.local", name, namespace)
+	return a.storeResourceCredentials(ctx, input.ResourceID, redisURL(host, password), resources.Metadata{Hostname: host, Namespace: namespace})
+}
+
+func redisURL(host, password string) string {
+	u := url.URL{
+		Scheme: "redis",
+		User:   url.UserPassword("default", password),
+		Host:   fmt.Sprintf("%s:%d", host, redisPort),
+	}
+	return u.String()
+}
+
+// WaitForRedis waits for the instance to answer, which with persistence
+// includes loading its data back from disk.
+func (a *Activities) WaitForRedis(ctx context.Context, input resources.RedisWorkflowInput) error {
+	return a.waitForStatefulSet(ctx, NamespaceName(input.Tenant, input.ProjectRef), RedisName(input.Name))
+}
+
+// DeleteRedis removes a redis resource's objects, its volume included.
+func (a *Activities) DeleteRedis(ctx context.Context, input resources.RedisWorkflowInput) error {
+	namespace := NamespaceName(input.Tenant, input.ProjectRef)
+	name := RedisName(input.Name)
+
+	a.logger.Info("DeleteRedis activity started",
+		"resourceID", input.ResourceID,
+		"namespace", namespace,
+		"name", name)
+
+	return a.deleteResourceObjects(ctx, namespace, name, input.ResourceID)
+}
+
+// redisArgs configures the server. Without persistence it's a cache that
+// evicts the least recently used keys when full; with it, writes fail
+// instead so queued data isn't silently dropped.
+func redisArgs(tier resources.RedisTier, persistent bool) []string {
+	args := []string{
+		"--requirepass", "$(REDIS_PASSWORD)",
+		"--maxmemory", tier.MaxMemory,
+	}
+	if persistent {
+		return append(args,
+			"--maxmemory-policy", "noeviction",
+			"--appendonly", "yes",
+			"--dir", "/data",
+		)
+	}
+	return append(args,
+		"--maxmemory-policy", "allkeys-lru",
+		"--appendonly", "no",
+		"--save", "",
+	)
+}
+
+// buildRedisStatefulSet runs a single instance, sandboxed like the services
+// next to it. Only a persistent one gets a volume.
+func buildRedisStatefulSet(namespace, name string, input resources.RedisWorkflowInput) *appsv1.StatefulSet {
+	memLimit := resource.MustParse(input.Tier.Memory)
+	cpuLimit := resource.MustParse(input.Tier.VCPUs)
+	labels := map[string]string{"app": name, resourceLabel: input.ResourceID}
+
+	container := corev1.Container{
+		Name:  "redis",
+		Image: redisImage,
+		Args:  redisArgs(input.Tier, input.Persistent),
+		Ports: []corev1.ContainerPort{{ContainerPort: redisPort}},
+		EnvFrom: []corev1.EnvFromSource{{SecretRef: &corev1.SecretEnvSource{
+			LocalObjectReference: corev1.LocalObjectReference{Name: name + "-env"},
+		}}},
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    cpuLimit.DeepCopy(),
+				corev1.ResourceMemory: memLimit.DeepCopy(),
+			},
+			Limits: corev1.ResourceList{
+				corev1.ResourceCPU:    cpuLimit,
+				corev1.ResourceMemory: memLimit,
+			},
+		},
+		SecurityContext: &corev1.SecurityContext{
+			AllowPrivilegeEscalation: ptr.To(false),
+		},
+		// PING answers LOADING until the append-only file is read back.
+		ReadinessProbe: &corev1.Probe{
+			ProbeHandler: corev1.ProbeHandler{Exec: &corev1.ExecAction{
+				Command: []string{"sh", "-c", `valkey-cli --no-auth-warning -a "$REDIS_PASSWORD" ping | grep -q PONG`},
+			}},
+			PeriodSeconds:    5,
+			FailureThreshold: 3,
+		},
+	}
+
+	sts := &appsv1.StatefulSet{
+		TypeMeta: metav1.TypeMeta{Kind: "StatefulSet", APIVersion: "apps/v1"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    labels,
+		},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas:    ptr.To(int32(1)),
+			ServiceName: name,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"app": name},
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					RuntimeClassName:             ptr.To("gvisor"),
+					AutomountServiceAccountToken: ptr.To(false),
+				},
+			},
+		},
+	}
+
+	if input.Persistent {
+		container.VolumeMounts = []corev1.VolumeMount{{Name: "data", MountPath: "/data"}}
+		sts.Spec.PersistentVolumeClaimRetentionPolicy = &appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy{
+			WhenDeleted: appsv1.DeletePersistentVolumeClaimRetentionPolicyType,
+			WhenScaled:  appsv1.RetainPersistentVolumeClaimRetentionPolicyType,
+		}
+		sts.Spec.VolumeClaimTemplates = []corev1.PersistentVolumeClaim{{
+			ObjectMeta: metav1.ObjectMeta{Name: "data", Labels: labels},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+				Resources: corev1.VolumeResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceStorage: resource.MustParse(input.Tier.Storage),
+					},
+				},
+			},
+		}}
+	}
+	sts.Spec.Template.Spec.Containers = []corev1.Container{container}
+	return sts
+}
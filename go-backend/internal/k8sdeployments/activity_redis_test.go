@@ -0,0 +1,89 @@
+package k8sdeployments
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/augustdev/autoclip/internal/resources"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestApplyRedis(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewClientset()
+	q := &credentialsRecorder{}
+	a := &Activities{
+		k8s:       client,
+		servicesQ: q,
+		logger:    slog.New(slog.DiscardHandler),
+		config:    Config{CredentialsEncryptionKey: "key"},
+	}
+	namespace := NamespaceName("user1", "default")
+
+	for _, persistent := range []bool{false, true} {
+		input := resources.RedisWorkflowInput{
+			ResourceID: "res-cache",
+			Tenant:     "user1",
+			ProjectRef: "default",
+			Name:       "cache",
+			Tier:       resources.RedisTiers["256mb"],
+			Persistent: persistent,
+		}
+		if persistent {
+			input.ResourceID, input.Name = "res-queue", "queue"
+		}
+		if err := a.ApplyRedis(ctx, input); err != nil {
+			t.Fatalf("ApplyRedis(persistent=%v) error = %v", persistent, err)
+		}
+
+		sts, err := client.AppsV1().StatefulSets(namespace).Get(ctx, RedisName(input.Name), metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("get statefulset: %v", err)
+		}
+		args := sts.Spec.Template.Spec.Containers[0].Args
+		if persistent != slices.Contains(args, "noeviction") || persistent != (len(sts.Spec.VolumeClaimTemplates) == 1) {
+			t.Fatalf("persistent=%v got args %v and %d volume claims", persistent, args, len(sts.Spec.VolumeClaimTemplates))
+		}
+		if got := sts.Spec.Template.Spec.Containers[0].Resources.Limits.Memory().String(); got != "384Mi" {
+			t.Fatalf("memory limit = %s, want 384Mi", got)
+		}
+
+		np, err := client.NetworkingV1().NetworkPolicies(namespace).Get(ctx, RedisName(input.Name), metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("get network policy: %v", err)
+		}
+		from := np.Spec.Ingress[0].From
+		if np.Spec.PodSelector.MatchLabels[resourceLabel] != input.ResourceID || len(from) != 1 || from[0].NamespaceSelector != nil {
+			t.Fatalf("network policy = %+v, want only this namespace let in", np.Spec)
+		}
+	}
+
+	creds, err := resources.DecryptCredentials(q.stored[0].Credentials, "key")
+	if err != nil {
+		t.Fatalf("DecryptCredentials() error = %v", err)
+	}
+	if want := "@redis-cache." + namespace + ".svc.cluster.local:6379"; !strings.HasPrefix(creds.URL, "redis://default:") || !strings.HasSuffix(creds.URL, want) {
+		t.Fatalf("URL = %s, want redis://default:...%s", creds.URL, want)
+	}
+
+	// The namespace-wide policy must not let the ingress controller reach
+	// resource pods.
+	shared, err := client.NetworkingV1().NetworkPolicies(namespace).Get(ctx, "ingress-isolation", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("get ingress-isolation: %v", err)
+	}
+	if exprs := shared.Spec.PodSelector.MatchExpressions; len(exprs) != 1 || exprs[0].Key != resourceLabel {
+		t.Fatalf("ingress-isolation selects %+v, want resource pods excluded", shared.Spec.PodSelector)
+	}
+
+	if err := a.DeleteRedis(ctx, resources.RedisWorkflowInput{ResourceID: "res-cache", Tenant: "user1", ProjectRef: "default", Name: "cache"}); err != nil {
+		t.Fatalf("DeleteRedis() error = %v", err)
+	}
+	if _, err := client.NetworkingV1().NetworkPolicies(namespace).Get(ctx, "redis-cache", metav1.GetOptions{}); err == nil {
+		t.Fatal("DeleteRedis left the network policy")
+	}
+}
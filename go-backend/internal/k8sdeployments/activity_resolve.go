@@ -3,11 +3,14 @@ package k8sdeployments
 import (
 	"context"
 	"crypto/sha256"
-	"encoding/json"
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
+	"strconv"
 
+	"github.com/augustdev/autoclip/internal/resources"
 	"github.com/augustdev/autoclip/internal/storage/pg/generated/services"
 	"go.temporal.io/sdk/temporal"
 )
@@ -80,6 +83,7 @@ func (a *Activities) ResolveBuildContext(ctx context.Context, input ResolveBuild
 	imageRef := fmt.Sprintf("%s/%s/%s:%s", a.config.RegistryAddress, id.Namespace, id.Name, tag)
 
 	// Determine build pack
+	var stack *ComposeStack
 	buildPack := id.Service.BuildPack
 	switch buildPack {
 	case "railpack", "nixpacks":
@@ -103,7 +107,30 @@ func (a *Activities) ResolveBuildContext(ctx context.Context, input ResolveBuild
 	case "static":
 		id.Service.Port = "8080"
 	case "dockercompose":
-		return nil, fmt.Errorf("dockercompose build pack is not supported on k8s")
+		composePath, err := findComposeFile(effectiveSourcePath)
+		if err != nil {
+			return nil, err
+		}
+		data, err := os.ReadFile(composePath)
+		if err != nil {
+			return nil, fmt.Errorf("read compose file: %w", err)
+		}
+		stack, err = resolveComposeStack(data, id.Service.Port, func(component string) string {
+			return fmt.Sprintf("%s/%s/%s:%s", a.config.RegistryAddress, id.Namespace, ComposeComponentName(id.Name, component), tag)
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range stack.Components {
+			if name := ComposeComponentName(id.Name, c.Name); len(name) > 63 {
+				return nil, fmt.Errorf("compose service %q: %q is longer than 63 characters; use a shorter service or compose service name", c.Name, name)
+			}
+		}
+		public := stack.Public()
+		imageRef = public.Image
+		if id.Service.Port == "" || !slices.Contains(public.Ports, ParsePortString(id.Service.Port)) {
+			id.Service.Port = strconv.Itoa(int(public.Ports[0]))
+		}
 	default:
 		// Auto-detect: check for Dockerfile (custom path or default), else railpack
 		dockerfileName := "Dockerfile"
@@ -125,7 +152,10 @@ func (a *Activities) ResolveBuildContext(ctx context.Context, input ResolveBuild
 
 	id.Service.Port = effectiveAppPort(buildPack, id.Service.Port, bc.PublishDirectory)
 
-	envVars := parseEnvVars(id.Service.EnvVars)
+	// Resource references only resolve at runtime: builds never see the
+	// credentials, and rotating them doesn't bust the build cache.
+	envVars := parseEnvVars(id.Service.EnvVars, EnvScopeBuild)
+	maps.DeleteFunc(envVars, func(_, v string) bool { return resources.HasEnvRefs(v) })
 	envVars["PORT"] = id.Service.Port
 
 	a.logger.Info("ResolveBuildContext completed",
@@ -148,34 +178,18 @@ func (a *Activities) ResolveBuildContext(ctx context.Context, input ResolveBuild
 		DockerfilePath:      bc.DockerfilePath,
 		BuildCommand:        bc.BuildCommand,
 		StartCommand:        bc.StartCommand,
+		Stack:               stack,
 	}, nil
 }
 
-func parseEnvVars(raw json.RawMessage) map[string]string {
-	envVars := make(map[string]string)
-	if len(raw) == 0 {
-		return envVars
-	}
-	if err := json.Unmarshal(raw, &envVars); err != nil {
-		var envArr []struct {
-			Key   string `json:"key"`
-			Value string `json:"value"`
-		}
-		if err := json.Unmarshal(raw, &envArr); err == nil {
-			for _, e := range envArr {
-				envVars[e.Key] = e.Value
-			}
-		}
-	}
-	return envVars
-}
-
 type serviceIdentity struct {
 	Namespace  string
 	Name       string
 	Tenant     string
 	ProjectRef string
 	Service    services.Service
+	// MaxReplicas is the owner's per-service replica quota.
+	MaxReplicas int32
 }
 
 func (a *Activities) resolveServiceIdentity(ctx context.Context, serviceID string) (*serviceIdentity, error) {
@@ -203,10 +217,11 @@ func (a *Activities) resolveServiceIdentity(ctx context.Context, serviceID strin
 	}
 
 	return &serviceIdentity{
-		Namespace:  NamespaceName(tenant, project.Ref),
-		Name:       ServiceName(*svc.Name),
-		Tenant:     tenant,
-		ProjectRef: project.Ref,
-		Service:    svc,
+		Namespace:   NamespaceName(tenant, project.Ref),
+		Name:        ServiceName(*svc.Name),
+		Tenant:      tenant,
+		ProjectRef:  project.Ref,
+		Service:     svc,
+		MaxReplicas: user.MaxReplicas,
 	}, nil
 }
@@ -0,0 +1,64 @@
+package k8sdeployments
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/augustdev/autoclip/internal/resources"
+	"github.com/augustdev/autoclip/internal/storage/pg/generated/services"
+	"go.temporal.io/sdk/temporal"
+)
+
+// ResolveImage pins an image-sourced service's image to the digest its tag
+// points at right now, so the deployment keeps running exactly that image
+// and a redeploy picks up whatever the tag has moved to since.
+func (a *Activities) ResolveImage(ctx context.Context, input ResolveImageInput) (*ResolveImageResult, error) {
+	svc, err := a.servicesQ.GetServiceByID(ctx, input.ServiceID)
+	if err != nil {
+		return nil, fmt.Errorf("get service: %w", err)
+	}
+	creds, err := a.registryCredentials(svc)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := resolveImageManifestTarget(input.Image, a.config.RegistryAddress)
+	if err != nil {
+		return nil, temporal.NewNonRetryableApplicationError(err.Error(), "invalid_image", err)
+	}
+
+	manifest, err := lookupManifest(ctx, newPublicRegistryClient(), target.ManifestURL, creds)
+	if errors.Is(err, errInternalRegistry) {
+		msg := fmt.Sprintf("image %s resolves to an internal host; use a public registry", input.Image)
+		return nil, temporal.NewNonRetryableApplicationError(msg, "invalid_image", err)
+	}
+	if errors.Is(err, errRegistryDenied) {
+		msg := fmt.Sprintf("registry denied access to image %s; check the registry credentials", input.Image)
+		return nil, temporal.NewNonRetryableApplicationError(msg, "image_access_denied", err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("look up image %s: %w", input.Image, err)
+	}
+	if !manifest.Found {
+		msg := fmt.Sprintf("image %s not found", input.Image)
+		return nil, temporal.NewNonRetryableApplicationError(msg, "image_not_found", nil)
+	}
+
+	imageRef := target.Name + "@" + manifest.Digest
+	a.logger.Info("Resolved image", "serviceID", input.ServiceID, "image", input.Image, "imageRef", imageRef)
+	return &ResolveImageResult{ImageRef: imageRef, Digest: manifest.Digest}, nil
+}
+
+// registryCredentials decrypts an image-sourced service's registry login.
+// It returns nil for a service that pulls anonymously.
+func (a *Activities) registryCredentials(svc services.Service) (*RegistryCredentials, error) {
+	if len(svc.RegistryCredentials) == 0 {
+		return nil, nil
+	}
+	var creds RegistryCredentials
+	if err := resources.DecryptSecret(svc.RegistryCredentials, a.config.CredentialsEncryptionKey, &creds); err != nil {
+		return nil, fmt.Errorf("decrypt registry credentials: %w", err)
+	}
+	return &creds, nil
+}
@@ -1,11 +1,16 @@
 package k8sdeployments
 
 import (
+	"context"
 	"encoding/json"
+	"log/slog"
+	"maps"
 	"strings"
 	"testing"
 
+	"github.com/augustdev/autoclip/internal/storage/pg/generated/projects"
 	"github.com/augustdev/autoclip/internal/storage/pg/generated/services"
+	"github.com/augustdev/autoclip/internal/storage/pg/generated/users"
 )
 
 func mustMarshalBuildConfig(bc BuildConfig) []byte {
@@ -155,3 +160,65 @@ func TestBuildImageTag_DockerfilePathDrivesTag(t *testing.T) {
 		t.Fatalf("dockerfile_path vs default should produce different tags")
 	}
 }
+
+// identityFake serves one service in one project for resolveServiceIdentity.
+type identityFake struct {
+	services.Querier
+	t       *testing.T
+	service services.Service
+}
+
+func (f *identityFake) GetServiceByID(ctx context.Context, id string) (services.Service, error) {
+	return f.service, nil
+}
+
+func (f *identityFake) GetReferencedResource(ctx context.Context, arg services.GetReferencedResourceParams) (services.GetReferencedResourceRow, error) {
+	f.t.Fatalf("build looked up resource %q", arg.Name)
+	return services.GetReferencedResourceRow{}, nil
+}
+
+type projectFake struct{ projects.Querier }
+
+func (projectFake) GetProjectByID(ctx context.Context, id string) (projects.Project, error) {
+	return projects.Project{ID: id, Ref: "default"}, nil
+}
+
+type userFake struct{ users.Querier }
+
+func (userFake) GetUserByID(ctx context.Context, id string) (users.User, error) {
+	return users.User{ID: id}, nil
+}
+
+func TestResolveBuildContext_LeavesResourceRefsToRuntime(t *testing.T) {
+	name := "web"
+	q := &identityFake{t: t, service: services.Service{
+		ID:        "svc-1",
+		UserID:    "user-1",
+		ProjectID: "proj-1",
+		Name:      &name,
+		BuildPack: "railpack",
+		Port:      "3000",
+		EnvVars: []byte(`[
+			{"key":"NODE_ENV","value":"production"},
+			{"key":"DATABASE_URL","value":"${{resources.main-db.url}}"}
+		]`),
+	}}
+	a := &Activities{
+		servicesQ: q,
+		projectsQ: projectFake{},
+		usersQ:    userFake{},
+		logger:    slog.New(slog.DiscardHandler),
+	}
+
+	result, err := a.ResolveBuildContext(context.Background(), ResolveBuildContextInput{
+		ServiceID:  "svc-1",
+		SourcePath: t.TempDir(),
+	})
+	if err != nil {
+		t.Fatalf("ResolveBuildContext() error = %v", err)
+	}
+	want := map[string]string{"NODE_ENV": "production", "PORT": "3000"}
+	if !maps.Equal(result.EnvVars, want) {
+		t.Fatalf("EnvVars = %v, want %v", result.EnvVars, want)
+	}
+}
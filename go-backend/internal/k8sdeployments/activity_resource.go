@@ -0,0 +1,241 @@
+package k8sdeployments
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/augustdev/autoclip/internal/resources"
+	"github.com/augustdev/autoclip/internal/storage/pg/generated/services"
+	"go.temporal.io/sdk/temporal"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// resourceLabel marks the objects making up an in-cluster resource with
+// the resource's ID.
+const resourceLabel = "dp.ml.ink/resource"
+
+// In-cluster resources are a Secret, Service, NetworkPolicy and StatefulSet
+// sharing one name in the project's namespace. The helpers below are what
+// postgres and redis have in common.
+
+// checkResourceName refuses a resource whose name is already taken in the
+// namespace by a service or another resource.
+func (a *Activities) checkResourceName(ctx context.Context, namespace, name, resourceID, resourceName string) error {
+	existing, err := a.k8s.CoreV1().Services(namespace).Get(ctx, name, metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+	case err != nil:
+		return fmt.Errorf("get service %s: %w", name, err)
+	case existing.Labels[resourceLabel] != resourceID:
+		return temporal.NewNonRetryableApplicationError(
+			fmt.Sprintf("resource name %q clashes with service %q in this project", resourceName, name),
+			"resource_name_taken",
+			nil,
+		)
+	}
+	return nil
+}
+
+// resourcePassword returns the password already stored under key in the
+// resource's Secret, or a new one the first time. Keeping it there means it
+// never passes through workflow history and a retry reuses it.
+func (a *Activities) resourcePassword(ctx context.Context, namespace, name, key string) (string, error) {
+	existing, err := a.k8s.CoreV1().Secrets(namespace).Get(ctx, name+"-env", metav1.GetOptions{})
+	if err == nil {
+		if pw := string(existing.Data[key]); pw != "" {
+			return pw, nil
+		}
+	} else if !apierrors.IsNotFound(err) {
+		return "", fmt.Errorf("get secret: %w", err)
+	}
+	return rand.Text(), nil
+}
+
+// applyResourceObjects applies everything but the StatefulSet: the Secret
+// holding env, the Service in front of port and the NetworkPolicy keeping
+// other namespaces out.
+func (a *Activities) applyResourceObjects(ctx context.Context, namespace, name, resourceID string, env map[string]string, portName string, port int32) error {
+	if err := a.applyResourceSecret(ctx, namespace, name, resourceID, env); err != nil {
+		return err
+	}
+
+	svcData, err := json.Marshal(buildResourceService(namespace, name, resourceID, portName, port))
+	if err != nil {
+		return fmt.Errorf("marshal service: %w", err)
+	}
+	if _, err := a.k8s.CoreV1().Services(namespace).Patch(ctx, name,
+		types.ApplyPatchType, svcData,
+		metav1.PatchOptions{FieldManager: "temporal-worker"}); err != nil {
+		return fmt.Errorf("apply service: %w", err)
+	}
+
+	npData, err := json.Marshal(buildResourceNetworkPolicy(namespace, name, resourceID, port))
+	if err != nil {
+		return fmt.Errorf("marshal network policy: %w", err)
+	}
+	if _, err := a.k8s.NetworkingV1().NetworkPolicies(namespace).Patch(ctx, name,
+		types.ApplyPatchType, npData,
+		metav1.PatchOptions{FieldManager: "temporal-worker"}); err != nil {
+		return fmt.Errorf("apply network policy: %w", err)
+	}
+	return nil
+}
+
+func (a *Activities) applyResourceSecret(ctx context.Context, namespace, name, resourceID string, env map[string]string) error {
+	secret := buildSecret(namespace, name, env)
+	secret.Labels = map[string]string{resourceLabel: resourceID}
+	data, err := json.Marshal(secret)
+	if err != nil {
+		return fmt.Errorf("marshal secret: %w", err)
+	}
+	if _, err := a.k8s.CoreV1().Secrets(namespace).Patch(ctx, secret.Name,
+		types.ApplyPatchType, data,
+		metav1.PatchOptions{FieldManager: "temporal-worker"}); err != nil {
+		return fmt.Errorf("apply secret: %w", err)
+	}
+	return nil
+}
+
+func (a *Activities) applyResourceStatefulSet(ctx context.Context, sts *appsv1.StatefulSet) error {
+	data, err := json.Marshal(sts)
+	if err != nil {
+		return fmt.Errorf("marshal statefulset: %w", err)
+	}
+	if _, err := a.k8s.AppsV1().StatefulSets(sts.Namespace).Patch(ctx, sts.Name,
+		types.ApplyPatchType, data,
+		metav1.PatchOptions{FieldManager: "temporal-worker"}); err != nil {
+		return fmt.Errorf("apply statefulset: %w", err)
+	}
+	return nil
+}
+
+// storeResourceCredentials encrypts the connection URL of a resource and
+// merges meta into its stored metadata.
+func (a *Activities) storeResourceCredentials(ctx context.Context, resourceID, url string, meta resources.Metadata) error {
+	encrypted, err := resources.EncryptCredentials(&resources.Credentials{URL: url}, a.config.CredentialsEncryptionKey)
+	if err != nil {
+		return fmt.Errorf("encrypt credentials: %w", err)
+	}
+	metadata, err := json.Marshal(meta)
+	if err != nil {
+		return fmt.Errorf("marshal metadata: %w", err)
+	}
+	if err := a.servicesQ.SetResourceCredentials(ctx, services.SetResourceCredentialsParams{
+		ID:          resourceID,
+		Credentials: encrypted,
+		Metadata:    metadata,
+	}); err != nil {
+		return fmt.Errorf("store credentials: %w", err)
+	}
+	return nil
+}
+
+// waitForStatefulSet waits for the resource's single instance to be ready.
+func (a *Activities) waitForStatefulSet(ctx context.Context, namespace, name string) error {
+	for {
+		recordHeartbeat(ctx)
+		sts, err := a.k8s.AppsV1().StatefulSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("get statefulset: %w", err)
+		}
+		if err == nil && sts.Status.ReadyReplicas > 0 {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("wait for %s/%s: %w", namespace, name, ctx.Err())
+		case <-time.After(waitForRolloutPollInterval):
+		}
+	}
+}
+
+func (a *Activities) MarkResourceStatus(ctx context.Context, input MarkResourceStatusInput) error {
+	return a.servicesQ.SetResourceStatus(ctx, services.SetResourceStatusParams{
+		ID:     input.ResourceID,
+		Status: input.Status,
+	})
+}
+
+// deleteResourceObjects removes a resource's objects, its volumes included.
+func (a *Activities) deleteResourceObjects(ctx context.Context, namespace, name, resourceID string) error {
+	if err := a.k8s.AppsV1().StatefulSets(namespace).Delete(ctx, name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("delete statefulset: %w", err)
+	}
+	if err := a.k8s.CoreV1().Services(namespace).Delete(ctx, name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("delete service: %w", err)
+	}
+	if err := a.k8s.NetworkingV1().NetworkPolicies(namespace).Delete(ctx, name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("delete network policy: %w", err)
+	}
+	if err := a.k8s.CoreV1().Secrets(namespace).Delete(ctx, name+"-env", metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("delete secret: %w", err)
+	}
+
+	claims, err := a.k8s.CoreV1().PersistentVolumeClaims(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: resourceLabel + "=" + resourceID,
+	})
+	if err != nil {
+		return fmt.Errorf("list volume claims: %w", err)
+	}
+	for _, pvc := range claims.Items {
+		if err := a.k8s.CoreV1().PersistentVolumeClaims(namespace).Delete(ctx, pvc.Name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("delete volume claim %s: %w", pvc.Name, err)
+		}
+	}
+	return nil
+}
+
+func buildResourceService(namespace, name, resourceID, portName string, port int32) *corev1.Service {
+	return &corev1.Service{
+		TypeMeta: metav1.TypeMeta{Kind: "Service", APIVersion: "v1"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    map[string]string{"app": name, resourceLabel: resourceID},
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{"app": name},
+			Ports: []corev1.ServicePort{{
+				Name:       portName,
+				Port:       port,
+				TargetPort: intstr.FromInt32(port),
+			}},
+		},
+	}
+}
+
+// buildResourceNetworkPolicy admits only pods of the resource's own
+// namespace, which is its project's. The namespace-wide ingress-isolation
+// policy skips resource pods, since it also lets the ingress controller in.
+func buildResourceNetworkPolicy(namespace, name, resourceID string, port int32) *networkingv1.NetworkPolicy {
+	protoTCP := corev1.ProtocolTCP
+	resourcePort := intstr.FromInt32(port)
+
+	return &networkingv1.NetworkPolicy{
+		TypeMeta: metav1.TypeMeta{Kind: "NetworkPolicy", APIVersion: "networking.k8s.io/v1"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    map[string]string{resourceLabel: resourceID},
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: map[string]string{resourceLabel: resourceID},
+			},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+			Ingress: []networkingv1.NetworkPolicyIngressRule{{
+				From:  []networkingv1.NetworkPolicyPeer{{PodSelector: &metav1.LabelSelector{}}},
+				Ports: []networkingv1.NetworkPolicyPort{{Protocol: &protoTCP, Port: &resourcePort}},
+			}},
+		},
+	}
+}
@@ -15,43 +15,74 @@ import (
 
 var waitForRolloutPollInterval = 2 * time.Second
 
+// WaitForRollout waits for a service's Deployment to become available. A
+// compose stack's other components are waited on first, since the public
+// one usually can't come up without them.
 func (a *Activities) WaitForRollout(ctx context.Context, input WaitForRolloutInput) (*WaitForRolloutResult, error) {
+	names := append(append([]string(nil), input.Components...), input.DeploymentName)
+	for _, name := range names {
+		if err := a.waitForDeployment(ctx, input.Namespace, name); err != nil {
+			return nil, err
+		}
+	}
+	return &WaitForRolloutResult{Status: StatusRunning}, nil
+}
+
+func (a *Activities) waitForDeployment(ctx context.Context, namespace, name string) error {
 	for {
 		recordHeartbeat(ctx)
-		dep, err := a.k8s.AppsV1().Deployments(input.Namespace).Get(ctx, input.DeploymentName, metav1.GetOptions{})
+		dep, err := a.k8s.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
 		if err != nil {
 			if apierrors.IsNotFound(err) {
 				select {
 				case <-ctx.Done():
-					return nil, fmt.Errorf("wait for rollout timed out waiting for deployment %s/%s to exist: %w", input.Namespace, input.DeploymentName, ctx.Err())
+					return fmt.Errorf("wait for rollout timed out waiting for deployment %s/%s to exist: %w", namespace, name, ctx.Err())
 				case <-time.After(waitForRolloutPollInterval):
 					continue
 				}
 			}
-			return nil, fmt.Errorf("get deployment: %w", err)
+			return fmt.Errorf("get deployment: %w", err)
 		}
 
 		if err := deploymentRolloutFailure(dep); err != nil {
-			return nil, temporal.NewNonRetryableApplicationError(
-				fmt.Sprintf("rollout failed for %s/%s: %v", input.Namespace, input.DeploymentName, err),
+			return temporal.NewNonRetryableApplicationError(
+				fmt.Sprintf("rollout failed for %s/%s: %v", namespace, name, err),
 				"deployment_rollout_failed",
 				err,
 			)
 		}
 
+		// A pod restarted by its startup or liveness probe won't come up on
+		// its own; say which check failed rather than waiting out the timeout.
+		if a.podsCrashLooping(ctx, dep) {
+			if failure := a.lastProbeFailure(ctx, dep); failure != "" {
+				return temporal.NewNonRetryableApplicationError(
+					fmt.Sprintf("rollout failed for %s/%s: %s", namespace, name, failure),
+					"deployment_health_check_failed",
+					nil,
+				)
+			}
+		}
+
 		desired := int32(1)
 		if dep.Spec.Replicas != nil {
 			desired = *dep.Spec.Replicas
 		}
 
 		if dep.Status.UpdatedReplicas == desired && dep.Status.AvailableReplicas == desired {
-			return &WaitForRolloutResult{Status: StatusRunning}, nil
+			return nil
 		}
 
 		select {
 		case <-ctx.Done():
-			return nil, fmt.Errorf("wait for rollout timed out for %s/%s: %s: %w",
-				input.Namespace, input.DeploymentName, deploymentRolloutSummary(dep), ctx.Err())
+			summary := deploymentRolloutSummary(dep)
+			diagCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+			if failure := a.lastProbeFailure(diagCtx, dep); failure != "" {
+				summary = failure + "; " + summary
+			}
+			cancel()
+			return fmt.Errorf("wait for rollout timed out for %s/%s: %s: %w",
+				namespace, name, summary, ctx.Err())
 		case <-time.After(waitForRolloutPollInterval):
 		}
 	}
@@ -93,3 +124,89 @@ func deploymentRolloutSummary(dep *appsv1.Deployment) string {
 	}
 	return summary + ", conditions=[" + strings.Join(parts, ", ") + "]"
 }
+
+func (a *Activities) listDeploymentPods(ctx context.Context, dep *appsv1.Deployment) ([]corev1.Pod, error) {
+	pods, err := a.k8s.CoreV1().Pods(dep.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: "app=" + dep.Name,
+	})
+	if err != nil {
+		// Without pods a failed rollout is reported without its cause, so
+		// say why (usually the worker's RBAC) rather than fail quietly.
+		a.logger.Warn("failed to list deployment pods", "namespace", dep.Namespace, "deployment", dep.Name, "error", err)
+		return nil, err
+	}
+	return pods.Items, nil
+}
+
+func (a *Activities) podsCrashLooping(ctx context.Context, dep *appsv1.Deployment) bool {
+	pods, err := a.listDeploymentPods(ctx, dep)
+	if err != nil {
+		return false
+	}
+	for _, pod := range pods {
+		for _, cs := range pod.Status.ContainerStatuses {
+			if cs.State.Waiting != nil && cs.State.Waiting.Reason == "CrashLoopBackOff" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// lastProbeFailure describes the most recent failed probe on the
+// deployment's pods, or returns "" if none has failed.
+func (a *Activities) lastProbeFailure(ctx context.Context, dep *appsv1.Deployment) string {
+	pods, err := a.listDeploymentPods(ctx, dep)
+	if err != nil || len(pods) == 0 {
+		return ""
+	}
+	podNames := make(map[string]bool, len(pods))
+	for _, pod := range pods {
+		podNames[pod.Name] = true
+	}
+
+	events, err := a.k8s.CoreV1().Events(dep.Namespace).List(ctx, metav1.ListOptions{
+		FieldSelector: "involvedObject.kind=Pod,reason=Unhealthy",
+	})
+	if err != nil {
+		a.logger.Warn("failed to list probe events", "namespace", dep.Namespace, "deployment", dep.Name, "error", err)
+		return ""
+	}
+	var latest *corev1.Event
+	for i := range events.Items {
+		ev := &events.Items[i]
+		if ev.Reason != "Unhealthy" || !podNames[ev.InvolvedObject.Name] {
+			continue
+		}
+		if latest == nil || eventTime(ev).After(eventTime(latest)) {
+			latest = ev
+		}
+	}
+	if latest == nil {
+		return ""
+	}
+
+	var probe *corev1.Probe
+	if containers := dep.Spec.Template.Spec.Containers; len(containers) > 0 {
+		c := containers[0]
+		switch {
+		case strings.HasPrefix(latest.Message, "Startup probe"):
+			probe = c.StartupProbe
+		case strings.HasPrefix(latest.Message, "Liveness probe"):
+			probe = c.LivenessProbe
+		default:
+			probe = c.ReadinessProbe
+		}
+	}
+	return describeProbeFailure(probe, latest.Message)
+}
+
+func eventTime(ev *corev1.Event) time.Time {
+	switch {
+	case !ev.LastTimestamp.IsZero():
+		return ev.LastTimestamp.Time
+	case !ev.EventTime.IsZero():
+		return ev.EventTime.Time
+	}
+	return ev.CreationTimestamp.Time
+}
@@ -103,3 +103,62 @@ func TestWaitForRollout_FailsFastOnReplicaFailure(t *testing.T) {
 		t.Fatalf("WaitForRollout() error = %v, want replica failure details", err)
 	}
 }
+
+func TestWaitForRollout_ReportsFailingHealthCheck(t *testing.T) {
+	prev := waitForRolloutPollInterval
+	waitForRolloutPollInterval = 5 * time.Millisecond
+	t.Cleanup(func() { waitForRolloutPollInterval = prev })
+
+	client := fake.NewClientset()
+	a := &Activities{k8s: client}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	const namespace = "ns"
+	const name = "api"
+	dep := buildDeployment(namespace, name, "img:1", 3000, "256Mi", "0.5", 1, &HealthCheck{Path: "/healthz"})
+	if _, err := client.AppsV1().Deployments(namespace).Create(ctx, dep, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("create deployment: %v", err)
+	}
+	_, err := client.CoreV1().Pods(namespace).Create(ctx, &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "api-abc-123",
+			Namespace: namespace,
+			Labels:    map[string]string{"app": name},
+		},
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:         name,
+				RestartCount: 3,
+				State: corev1.ContainerState{
+					Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"},
+				},
+			}},
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		t.Fatalf("create pod: %v", err)
+	}
+	_, err = client.CoreV1().Events(namespace).Create(ctx, &corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Name: "api-abc-123.1", Namespace: namespace},
+		InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "api-abc-123", Namespace: namespace},
+		Reason:         "Unhealthy",
+		Message:        "Startup probe failed: HTTP probe failed with statuscode: 500",
+		LastTimestamp:  metav1.Now(),
+	}, metav1.CreateOptions{})
+	if err != nil {
+		t.Fatalf("create event: %v", err)
+	}
+
+	_, err = a.WaitForRollout(ctx, WaitForRolloutInput{
+		Namespace:      namespace,
+		DeploymentName: name,
+	})
+	if err == nil {
+		t.Fatalf("WaitForRollout() expected error, got nil")
+	}
+	if !strings.Contains(err.Error(), "health check /healthz returned 500") {
+		t.Fatalf("WaitForRollout() error = %v, want health check details", err)
+	}
+}
@@ -2,6 +2,7 @@ package k8sdeployments
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	deploymentsdb "github.com/augustdev/autoclip/internal/storage/pg/generated/deployments"
@@ -30,11 +31,21 @@ func (a *Activities) MarkDeploymentActive(ctx context.Context, input MarkDeploym
 		return fmt.Errorf("supersede active deployment: %w", err)
 	}
 
+	var stack []byte
+	if input.Stack != nil {
+		var err error
+		if stack, err = json.Marshal(input.Stack); err != nil {
+			return fmt.Errorf("marshal stack: %w", err)
+		}
+	}
+
 	// Mark this deployment as active
 	if err := a.deploymentsQ.MarkDeploymentActive(ctx, deploymentsdb.MarkDeploymentActiveParams{
-		ID:         input.DeploymentID,
-		CommitHash: &input.CommitSHA,
-		ImageRef:   &input.ImageRef,
+		ID:           input.DeploymentID,
+		CommitHash:   &input.CommitSHA,
+		ImageRef:     &input.ImageRef,
+		ResolvedPort: input.Port,
+		Stack:        stack,
 	}); err != nil {
 		return fmt.Errorf("mark deployment active: %w", err)
 	}
@@ -76,6 +87,41 @@ func (a *Activities) MarkDeploymentFailed(ctx context.Context, input MarkDeploym
 	return nil
 }
 
+func (a *Activities) MarkDeploymentCancelled(ctx context.Context, input MarkDeploymentCancelledInput) error {
+	affected, err := a.deploymentsQ.MarkDeploymentCancelled(ctx, input.DeploymentID)
+	if err != nil {
+		return fmt.Errorf("mark deployment cancelled: %w", err)
+	}
+	// Zero rows means the row already left the in-flight states, e.g. it was
+	// cancelled through the API before the workflow saw the cancellation.
+	a.logger.Info("Deployment status → cancelled",
+		"deploymentID", input.DeploymentID,
+		"updated", affected > 0)
+	return nil
+}
+
+func (a *Activities) GetActiveDeployment(ctx context.Context, serviceID string) (ActiveDeployment, error) {
+	svc, err := a.servicesQ.GetServiceByID(ctx, serviceID)
+	if err != nil {
+		return ActiveDeployment{}, fmt.Errorf("get service: %w", err)
+	}
+	if svc.CurrentDeploymentID == nil {
+		return ActiveDeployment{}, nil
+	}
+	dep, err := a.deploymentsQ.GetDeploymentByID(ctx, *svc.CurrentDeploymentID)
+	if err != nil {
+		return ActiveDeployment{}, fmt.Errorf("get deployment: %w", err)
+	}
+	active := ActiveDeployment{DeploymentID: dep.ID}
+	if dep.ImageRef != nil {
+		active.ImageRef = *dep.ImageRef
+	}
+	if dep.CommitHash != nil {
+		active.CommitSHA = *dep.CommitHash
+	}
+	return active, nil
+}
+
 func (a *Activities) UpdateDeploymentBuildProgress(ctx context.Context, input UpdateDeploymentBuildProgressInput) error {
 	if err := a.deploymentsQ.UpdateDeploymentBuildProgress(ctx, deploymentsdb.UpdateDeploymentBuildProgressParams{
 		ID:            input.DeploymentID,
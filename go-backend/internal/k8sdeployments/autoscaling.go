@@ -0,0 +1,71 @@
+package k8sdeployments
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// AutoscalingConfig enables a HorizontalPodAutoscaler for a service. At least
+// one utilization target must be set; targets are percentages of the
+// container's requests.
+type AutoscalingConfig struct {
+	MinReplicas             int32  `json:"min_replicas"`
+	MaxReplicas             int32  `json:"max_replicas"`
+	TargetCPUUtilization    *int32 `json:"target_cpu_utilization,omitempty"`
+	TargetMemoryUtilization *int32 `json:"target_memory_utilization,omitempty"`
+}
+
+// ParseAutoscaling returns nil for an empty column or a malformed value, which
+// leaves the service on fixed replicas.
+func ParseAutoscaling(raw []byte) *AutoscalingConfig {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil
+	}
+	var cfg AutoscalingConfig
+	if err := json.Unmarshal(raw, &cfg); err != nil {
+		return nil
+	}
+	return &cfg
+}
+
+// ValidateAutoscaling checks an autoscaling config against the owner's
+// replica quota.
+func ValidateAutoscaling(cfg *AutoscalingConfig, maxReplicas int32) error {
+	if cfg == nil {
+		return nil
+	}
+	if cfg.MinReplicas < 1 {
+		return fmt.Errorf("invalid autoscaling min_replicas %d: must be at least 1", cfg.MinReplicas)
+	}
+	if cfg.MaxReplicas < cfg.MinReplicas {
+		return fmt.Errorf("invalid autoscaling max_replicas %d: must be at least min_replicas (%d)", cfg.MaxReplicas, cfg.MinReplicas)
+	}
+	if cfg.MaxReplicas > maxReplicas {
+		return fmt.Errorf("invalid autoscaling max_replicas %d: exceeds your limit of %d", cfg.MaxReplicas, maxReplicas)
+	}
+	if cfg.TargetCPUUtilization == nil && cfg.TargetMemoryUtilization == nil {
+		return fmt.Errorf("autoscaling needs target_cpu_utilization, target_memory_utilization or both")
+	}
+	for _, t := range []struct {
+		name   string
+		target *int32
+	}{
+		{"target_cpu_utilization", cfg.TargetCPUUtilization},
+		{"target_memory_utilization", cfg.TargetMemoryUtilization},
+	} {
+		if t.target != nil && (*t.target < 1 || *t.target > 100) {
+			return fmt.Errorf("invalid autoscaling %s %d: must be between 1 and 100", t.name, *t.target)
+		}
+	}
+	return nil
+}
+
+// autoscaledReplicas is the replica count to apply to an autoscaled
+// deployment: what it runs now, held to the autoscaler's bounds, or the
+// minimum for a new one.
+func autoscaledReplicas(running *int32, cfg AutoscalingConfig) int32 {
+	if running == nil {
+		return cfg.MinReplicas
+	}
+	return min(max(*running, cfg.MinReplicas), cfg.MaxReplicas)
+}
@@ -0,0 +1,82 @@
+package k8sdeployments
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestValidateAutoscaling(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     *AutoscalingConfig
+		wantErr bool
+	}{
+		{"disabled", nil, false},
+		{"cpu target", &AutoscalingConfig{MinReplicas: 1, MaxReplicas: 3, TargetCPUUtilization: ptr.To(int32(70))}, false},
+		{"both targets", &AutoscalingConfig{MinReplicas: 2, MaxReplicas: 3, TargetCPUUtilization: ptr.To(int32(70)), TargetMemoryUtilization: ptr.To(int32(80))}, false},
+		{"no target", &AutoscalingConfig{MinReplicas: 1, MaxReplicas: 3}, true},
+		{"max below min", &AutoscalingConfig{MinReplicas: 3, MaxReplicas: 2, TargetCPUUtilization: ptr.To(int32(70))}, true},
+		{"max over quota", &AutoscalingConfig{MinReplicas: 1, MaxReplicas: 4, TargetCPUUtilization: ptr.To(int32(70))}, true},
+		{"zero min", &AutoscalingConfig{MinReplicas: 0, MaxReplicas: 3, TargetCPUUtilization: ptr.To(int32(70))}, true},
+		{"target over 100", &AutoscalingConfig{MinReplicas: 1, MaxReplicas: 3, TargetMemoryUtilization: ptr.To(int32(150))}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateAutoscaling(tt.cfg, 3)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateAutoscaling() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBuildHorizontalPodAutoscaler_OnlyConfiguredTargets(t *testing.T) {
+	hpa := buildHorizontalPodAutoscaler("ns", "app", AutoscalingConfig{
+		MinReplicas:          2,
+		MaxReplicas:          5,
+		TargetCPUUtilization: ptr.To(int32(60)),
+	})
+
+	if hpa.Spec.ScaleTargetRef.Name != "app" || hpa.Spec.ScaleTargetRef.Kind != "Deployment" {
+		t.Fatalf("unexpected scale target: %+v", hpa.Spec.ScaleTargetRef)
+	}
+	if *hpa.Spec.MinReplicas != 2 || hpa.Spec.MaxReplicas != 5 {
+		t.Fatalf("replicas = %d..%d, want 2..5", *hpa.Spec.MinReplicas, hpa.Spec.MaxReplicas)
+	}
+	if len(hpa.Spec.Metrics) != 1 {
+		t.Fatalf("expected 1 metric, got %d", len(hpa.Spec.Metrics))
+	}
+	m := hpa.Spec.Metrics[0].Resource
+	if m.Name != corev1.ResourceCPU || *m.Target.AverageUtilization != 60 {
+		t.Fatalf("unexpected metric: %s at %d", m.Name, *m.Target.AverageUtilization)
+	}
+}
+
+func TestParseAutoscaling_EmptyMeansFixedReplicas(t *testing.T) {
+	for _, raw := range [][]byte{nil, []byte("null"), []byte("{bad")} {
+		if cfg := ParseAutoscaling(raw); cfg != nil {
+			t.Fatalf("ParseAutoscaling(%q) = %+v, want nil", raw, cfg)
+		}
+	}
+}
+
+func TestAutoscaledReplicas_KeepsRunningCount(t *testing.T) {
+	cfg := AutoscalingConfig{MinReplicas: 2, MaxReplicas: 6}
+	tests := []struct {
+		name    string
+		running *int32
+		want    int32
+	}{
+		{"new deployment", nil, 2},
+		{"within bounds", ptr.To(int32(4)), 4},
+		{"below min", ptr.To(int32(1)), 2},
+		{"above max", ptr.To(int32(9)), 6},
+	}
+	for _, tt := range tests {
+		if got := autoscaledReplicas(tt.running, cfg); got != tt.want {
+			t.Errorf("%s: autoscaledReplicas() = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
@@ -3,11 +3,12 @@ package k8sdeployments
 import "encoding/json"
 
 type BuildConfig struct {
-	RootDirectory    string `json:"root_directory,omitempty"`
-	DockerfilePath   string `json:"dockerfile_path,omitempty"`
-	PublishDirectory string `json:"publish_directory,omitempty"`
-	BuildCommand     string `json:"build_command,omitempty"`
-	StartCommand     string `json:"start_command,omitempty"`
+	RootDirectory    string       `json:"root_directory,omitempty"`
+	DockerfilePath   string       `json:"dockerfile_path,omitempty"`
+	PublishDirectory string       `json:"publish_directory,omitempty"`
+	BuildCommand     string       `json:"build_command,omitempty"`
+	StartCommand     string       `json:"start_command,omitempty"`
+	HealthCheck      *HealthCheck `json:"health_check,omitempty"`
 }
 
 func parseBuildConfig(raw []byte) BuildConfig {
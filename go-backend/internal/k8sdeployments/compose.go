@@ -0,0 +1,343 @@
+package k8sdeployments
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/yaml"
+)
+
+// composeFileNames are looked up in order, as docker compose does.
+var composeFileNames = []string{"compose.yaml", "compose.yml", "docker-compose.yaml", "docker-compose.yml"}
+
+// ComposeStack is a compose file resolved for deploying: one component per
+// compose service, each with the image it runs. Exactly one component is
+// public and is served through the service's own Deployment and Ingress.
+type ComposeStack struct {
+	Components []ComposeComponent `json:"components"`
+}
+
+// ComposeComponent is one compose service. Ports are the container ports
+// other components reach it on; for the public component the first one is
+// also the one exposed through the Ingress.
+type ComposeComponent struct {
+	Name        string            `json:"name"`
+	Image       string            `json:"image"`
+	Build       *ComposeBuild     `json:"build,omitempty"`
+	Public      bool              `json:"public,omitempty"`
+	Ports       []int32           `json:"ports,omitempty"`
+	Environment map[string]string `json:"environment,omitempty"`
+	Entrypoint  []string          `json:"entrypoint,omitempty"`
+	Command     []string          `json:"command,omitempty"`
+}
+
+// ComposeBuild locates a component's build. Context is relative to the
+// directory holding the compose file, Dockerfile to the context.
+type ComposeBuild struct {
+	Context    string `json:"context"`
+	Dockerfile string `json:"dockerfile,omitempty"`
+}
+
+// Public returns the component served through the Ingress.
+func (s *ComposeStack) Public() *ComposeComponent {
+	for i := range s.Components {
+		if s.Components[i].Public {
+			return &s.Components[i]
+		}
+	}
+	return nil
+}
+
+func parseComposeStack(raw []byte) *ComposeStack {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil
+	}
+	var stack ComposeStack
+	if err := json.Unmarshal(raw, &stack); err != nil {
+		return nil
+	}
+	return &stack
+}
+
+// findComposeFile returns the path of the compose file in dir.
+func findComposeFile(dir string) (string, error) {
+	for _, name := range composeFileNames {
+		path := filepath.Join(dir, name)
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("build pack is 'dockercompose' but no compose file found (looked for %s)", strings.Join(composeFileNames, ", "))
+}
+
+// resolveComposeStack turns a compose file into a stack. imageRef names the
+// image a component built from source is pushed as. wantPort, when set,
+// picks the public component if more than one publishes ports.
+//
+// Only the parts of the compose spec that map onto a Deployment are read:
+// image, build, ports, expose, environment, entrypoint and command. Volumes,
+// networks and depends_on are ignored; components restart until what they
+// depend on is reachable.
+func resolveComposeStack(data []byte, wantPort string, imageRef func(component string) string) (*ComposeStack, error) {
+	var file composeFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("parse compose file: %w", err)
+	}
+	if len(file.Services) == 0 {
+		return nil, fmt.Errorf("compose file has no services")
+	}
+
+	names := make([]string, 0, len(file.Services))
+	for name := range file.Services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	stack := &ComposeStack{}
+	var published []int
+	for _, name := range names {
+		svc := file.Services[name]
+		if errs := validation.IsDNS1035Label(name); len(errs) > 0 {
+			return nil, fmt.Errorf("compose service %q can't be used as a DNS name: %s", name, strings.Join(errs, "; "))
+		}
+
+		c := ComposeComponent{
+			Name:        name,
+			Environment: map[string]string(svc.Environment),
+			Entrypoint:  []string(svc.Entrypoint),
+			Command:     []string(svc.Command),
+		}
+		switch {
+		case svc.Build != nil:
+			ctxDir := filepath.Clean(svc.Build.Context)
+			if filepath.IsAbs(ctxDir) || ctxDir == ".." || strings.HasPrefix(ctxDir, "../") {
+				return nil, fmt.Errorf("compose service %q: build context %q must stay inside the repo", name, svc.Build.Context)
+			}
+			c.Build = &ComposeBuild{Context: ctxDir, Dockerfile: svc.Build.Dockerfile}
+			c.Image = imageRef(name)
+		case svc.Image != "":
+			c.Image = svc.Image
+		default:
+			return nil, fmt.Errorf("compose service %q needs either image or build", name)
+		}
+
+		for _, p := range svc.Ports {
+			c.Ports = appendPort(c.Ports, int32(p))
+		}
+		if len(svc.Ports) > 0 {
+			published = append(published, len(stack.Components))
+		}
+		for _, p := range svc.Expose {
+			c.Ports = appendPort(c.Ports, int32(p))
+		}
+		stack.Components = append(stack.Components, c)
+	}
+
+	public, err := pickPublicComponent(stack, published, wantPort)
+	if err != nil {
+		return nil, err
+	}
+	stack.Components[public].Public = true
+	return stack, nil
+}
+
+func pickPublicComponent(stack *ComposeStack, published []int, wantPort string) (int, error) {
+	switch len(published) {
+	case 0:
+		return 0, fmt.Errorf("no compose service publishes ports; add ports: to the one that should be public")
+	case 1:
+		return published[0], nil
+	}
+	if wantPort != "" {
+		port := ParsePortString(wantPort)
+		for _, i := range published {
+			if slices.Contains(stack.Components[i].Ports, port) {
+				return i, nil
+			}
+		}
+	}
+	candidates := make([]string, len(published))
+	for i, idx := range published {
+		candidates[i] = stack.Components[idx].Name
+	}
+	return 0, fmt.Errorf("compose services %s all publish ports; set port to the one that should be public", strings.Join(candidates, ", "))
+}
+
+func appendPort(ports []int32, p int32) []int32 {
+	for _, existing := range ports {
+		if existing == p {
+			return ports
+		}
+	}
+	return append(ports, p)
+}
+
+type composeFile struct {
+	Services map[string]composeService `json:"services"`
+}
+
+type composeService struct {
+	Image       string         `json:"image"`
+	Build       *composeBuild  `json:"build"`
+	Ports       []composePort  `json:"ports"`
+	Expose      []composePort  `json:"expose"`
+	Environment composeEnv     `json:"environment"`
+	Entrypoint  composeCommand `json:"entrypoint"`
+	Command     composeCommand `json:"command"`
+}
+
+// composeBuild accepts both `build: ./dir` and `build: {context, dockerfile}`.
+type composeBuild struct {
+	Context    string `json:"context"`
+	Dockerfile string `json:"dockerfile"`
+}
+
+func (b *composeBuild) UnmarshalJSON(data []byte) error {
+	var ctx string
+	if err := json.Unmarshal(data, &ctx); err == nil {
+		b.Context = ctx
+		return nil
+	}
+	type plain composeBuild
+	if err := json.Unmarshal(data, (*plain)(b)); err != nil {
+		return err
+	}
+	if b.Context == "" {
+		b.Context = "."
+	}
+	return nil
+}
+
+// composePort is the container side of a port entry: 80, "80",
+// "8080:80", "127.0.0.1:8080:80/tcp" or {target: 80, published: 8080}.
+type composePort int32
+
+func (p *composePort) UnmarshalJSON(data []byte) error {
+	var n int32
+	if err := json.Unmarshal(data, &n); err == nil {
+		*p = composePort(n)
+		return nil
+	}
+	var long struct {
+		Target int32 `json:"target"`
+	}
+	if err := json.Unmarshal(data, &long); err == nil && long.Target > 0 {
+		*p = composePort(long.Target)
+		return nil
+	}
+	var short string
+	if err := json.Unmarshal(data, &short); err != nil {
+		return fmt.Errorf("invalid port %s", data)
+	}
+	target := short
+	if i := strings.LastIndex(target, ":"); i >= 0 {
+		target = target[i+1:]
+	}
+	target, _, _ = strings.Cut(target, "/")
+	port, err := strconv.ParseInt(target, 10, 32)
+	if err != nil || port < 1 || port > 65535 {
+		return fmt.Errorf("invalid port %q: port ranges aren't supported", short)
+	}
+	*p = composePort(port)
+	return nil
+}
+
+// composeEnv accepts both the list ("KEY=value") and map forms. Entries
+// without a value would be read from the host shell and are skipped.
+type composeEnv map[string]string
+
+func (e *composeEnv) UnmarshalJSON(data []byte) error {
+	env := make(composeEnv)
+	var list []string
+	if err := json.Unmarshal(data, &list); err == nil {
+		for _, entry := range list {
+			if k, v, ok := strings.Cut(entry, "="); ok {
+				env[k] = v
+			}
+		}
+		*e = env
+		return nil
+	}
+	var m map[string]any
+	if err := json.Unmarshal(data, &m); err != nil {
+		return fmt.Errorf("environment must be a list or a map")
+	}
+	for k, v := range m {
+		switch v := v.(type) {
+		case nil:
+		case string:
+			env[k] = v
+		default:
+			env[k] = fmt.Sprint(v)
+		}
+	}
+	*e = env
+	return nil
+}
+
+// composeCommand accepts both the list form and a string, which compose
+// splits like a shell would without running one.
+type composeCommand []string
+
+func (c *composeCommand) UnmarshalJSON(data []byte) error {
+	var list []string
+	if err := json.Unmarshal(data, &list); err == nil {
+		*c = list
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("command must be a string or a list")
+	}
+	words, err := splitShellWords(s)
+	if err != nil {
+		return err
+	}
+	*c = words
+	return nil
+}
+
+func splitShellWords(s string) ([]string, error) {
+	var (
+		words   []string
+		current strings.Builder
+		inWord  bool
+		quote   rune
+	)
+	for _, r := range s {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inWord = true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inWord {
+				words = append(words, current.String())
+				current.Reset()
+				inWord = false
+			}
+		default:
+			current.WriteRune(r)
+			inWord = true
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated quote in command %q", s)
+	}
+	if inWord {
+		words = append(words, current.String())
+	}
+	return words, nil
+}
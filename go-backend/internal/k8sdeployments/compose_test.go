@@ -0,0 +1,213 @@
+package k8sdeployments
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func testImageRef(component string) string {
+	return "registry/ns/app-" + component + ":abc"
+}
+
+func TestResolveComposeStack(t *testing.T) {
+	data := []byte(`
+services:
+  web:
+    build: ./web
+    ports:
+      - "8080:3000"
+    environment:
+      - DATABASE_HOST=db
+    command: node server.js --port "3000"
+  worker:
+    build:
+      context: .
+      dockerfile: worker.Dockerfile
+    environment:
+      QUEUE: jobs
+      CONCURRENCY: 4
+  db:
+    image: postgres:16
+    expose:
+      - 5432
+    volumes:
+      - data:/var/lib/postgresql/data
+volumes:
+  data:
+`)
+	stack, err := resolveComposeStack(data, "", testImageRef)
+	if err != nil {
+		t.Fatalf("resolveComposeStack() error = %v", err)
+	}
+
+	want := []ComposeComponent{
+		{Name: "db", Image: "postgres:16", Ports: []int32{5432}},
+		{
+			Name:        "web",
+			Image:       "registry/ns/app-web:abc",
+			Build:       &ComposeBuild{Context: "web"},
+			Public:      true,
+			Ports:       []int32{3000},
+			Environment: map[string]string{"DATABASE_HOST": "db"},
+			Command:     []string{"node", "server.js", "--port", "3000"},
+		},
+		{
+			Name:        "worker",
+			Image:       "registry/ns/app-worker:abc",
+			Build:       &ComposeBuild{Context: ".", Dockerfile: "worker.Dockerfile"},
+			Environment: map[string]string{"QUEUE": "jobs", "CONCURRENCY": "4"},
+		},
+	}
+	if !reflect.DeepEqual(stack.Components, want) {
+		t.Fatalf("resolveComposeStack() =\n%+v\nwant\n%+v", stack.Components, want)
+	}
+	if got := stack.Public().Name; got != "web" {
+		t.Fatalf("Public() = %q, want web", got)
+	}
+}
+
+func TestResolveComposeStack_PublicComponent(t *testing.T) {
+	twoPublic := `
+services:
+  api:
+    image: api
+    ports: ["8000"]
+  admin:
+    image: admin
+    ports: [{target: 9000, published: 80}]
+`
+	tests := []struct {
+		name     string
+		data     string
+		wantPort string
+		want     string
+		wantErr  string
+	}{
+		{name: "port picks between published", data: twoPublic, wantPort: "9000", want: "admin"},
+		{name: "ambiguous without port", data: twoPublic, wantErr: "admin, api all publish ports"},
+		{name: "unmatched port", data: twoPublic, wantPort: "3000", wantErr: "set port"},
+		{
+			name:    "nothing published",
+			data:    "services:\n  worker:\n    image: worker\n",
+			wantErr: "no compose service publishes ports",
+		},
+		{
+			name:    "name not usable in DNS",
+			data:    "services:\n  my_app:\n    image: app\n    ports: [80]\n",
+			wantErr: "can't be used as a DNS name",
+		},
+		{
+			name:    "build context outside repo",
+			data:    "services:\n  app:\n    build: ../other\n    ports: [80]\n",
+			wantErr: "must stay inside the repo",
+		},
+		{
+			name:    "neither image nor build",
+			data:    "services:\n  app:\n    ports: [80]\n",
+			wantErr: "needs either image or build",
+		},
+		{
+			name:    "port range",
+			data:    "services:\n  app:\n    image: app\n    ports: [\"3000-3005:3000-3005\"]\n",
+			wantErr: "port ranges aren't supported",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stack, err := resolveComposeStack([]byte(tt.data), tt.wantPort, testImageRef)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("resolveComposeStack() error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolveComposeStack() error = %v", err)
+			}
+			if got := stack.Public().Name; got != tt.want {
+				t.Fatalf("Public() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyComposeStack(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewClientset(&appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "app-cache",
+			Namespace: "ns",
+			Labels:    map[string]string{stackLabel: "app"},
+		},
+	})
+	a := &Activities{k8s: client}
+
+	stack := &ComposeStack{Components: []ComposeComponent{
+		{Name: "db", Image: "postgres:16", Ports: []int32{5432}},
+		{Name: "web", Image: "registry/ns/app-web:abc", Public: true, Ports: []int32{3000}},
+		{Name: "worker", Image: "registry/ns/app-worker:abc", Command: []string{"worker"}},
+	}}
+	components, err := a.applyComposeStack(ctx, "ns", "app", stack, "256Mi", "0.5")
+	if err != nil {
+		t.Fatalf("applyComposeStack() error = %v", err)
+	}
+	if want := []string{"app-db", "app-worker"}; !reflect.DeepEqual(components, want) {
+		t.Fatalf("applyComposeStack() = %v, want %v", components, want)
+	}
+
+	if _, err := client.AppsV1().Deployments("ns").Get(ctx, "app-cache", metav1.GetOptions{}); err == nil {
+		t.Fatal("component dropped from the stack was not pruned")
+	}
+	worker, err := client.AppsV1().Deployments("ns").Get(ctx, "app-worker", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("get worker deployment: %v", err)
+	}
+	if c := worker.Spec.Template.Spec.Containers[0]; c.ReadinessProbe != nil || !reflect.DeepEqual(c.Args, []string{"worker"}) {
+		t.Fatalf("worker container = probe %v args %v, want no probe and args [worker]", c.ReadinessProbe, c.Args)
+	}
+
+	for name, app := range map[string]string{"db": "app-db", "web": "app"} {
+		svc, err := client.CoreV1().Services("ns").Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("get service %s: %v", name, err)
+		}
+		if got := svc.Spec.Selector["app"]; got != app {
+			t.Fatalf("service %s selects app=%s, want %s", name, got, app)
+		}
+	}
+	if _, err := client.CoreV1().Services("ns").Get(ctx, "worker", metav1.GetOptions{}); err == nil {
+		t.Fatal("component without ports got a Service")
+	}
+
+	if _, err := a.applyComposeStack(ctx, "ns", "app", nil, "256Mi", "0.5"); err != nil {
+		t.Fatalf("applyComposeStack(nil) error = %v", err)
+	}
+	deployments, _ := client.AppsV1().Deployments("ns").List(ctx, metav1.ListOptions{})
+	services, _ := client.CoreV1().Services("ns").List(ctx, metav1.ListOptions{})
+	if len(deployments.Items) != 0 || len(services.Items) != 0 {
+		t.Fatalf("nil stack left %d deployments and %d services", len(deployments.Items), len(services.Items))
+	}
+}
+
+func TestApplyComposeStack_RefusesTakenName(t *testing.T) {
+	client := fake.NewClientset(&corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "ns"},
+	})
+	a := &Activities{k8s: client}
+
+	stack := &ComposeStack{Components: []ComposeComponent{
+		{Name: "db", Image: "postgres:16", Ports: []int32{5432}},
+		{Name: "web", Image: "web", Public: true, Ports: []int32{3000}},
+	}}
+	_, err := a.applyComposeStack(context.Background(), "ns", "app", stack, "256Mi", "0.5")
+	if err == nil || !strings.Contains(err.Error(), "clashes with service") {
+		t.Fatalf("applyComposeStack() error = %v, want a name clash", err)
+	}
+}
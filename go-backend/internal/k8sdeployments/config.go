@@ -8,4 +8,14 @@ type Config struct {
 	LokiQueryURL        string
 	GitServerAdminToken string
 	GitServerCloneHost  string
+
+	// ActivatorHost and ActivatorPort locate the deployer-server endpoint
+	// that holds requests for sleeping services while they wake.
+	ActivatorHost string
+	ActivatorPort int32
+
+	// CredentialsEncryptionKey decrypts the credentials of resources bound
+	// to a service and a prebuilt image's registry login; it is the API's
+	// auth.apikeyencryptionkey.
+	CredentialsEncryptionKey string
 }
@@ -0,0 +1,282 @@
+package k8sdeployments
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+// Health statuses.
+const (
+	HealthHealthy   = "healthy"
+	HealthStarting  = "starting"
+	HealthUnhealthy = "unhealthy"
+	HealthStopped   = "stopped"
+)
+
+// Reasons a service is unhealthy, most specific first.
+const (
+	HealthReasonImagePullFailed   = "image_pull_failed"
+	HealthReasonUnschedulable     = "unschedulable"
+	HealthReasonOOMKilled         = "oom_killed"
+	HealthReasonCrashLoop         = "crash_loop"
+	HealthReasonPortMismatch      = "port_mismatch"
+	HealthReasonHealthCheckFailed = "health_check_failed"
+)
+
+const (
+	// diagnoseLogLines is how much of a crashed container's previous log is
+	// searched for its last error.
+	diagnoseLogLines = 50
+
+	// probeGracePeriod is how long a running but unready container gets
+	// before its failing probes count against it, so a slow boot reads as
+	// starting rather than a port mismatch.
+	probeGracePeriod = time.Minute
+)
+
+// ServiceHealth is a classified view of a service's pods. Reason and
+// Suggestion are only set when Status is unhealthy.
+type ServiceHealth struct {
+	Status        string
+	Reason        string
+	Message       string
+	Suggestion    string
+	RestartCount  int32
+	ExitCode      *int32
+	LastErrorLine string
+}
+
+func (a *Activities) DiagnoseService(ctx context.Context, input DiagnoseServiceInput) (*ServiceHealth, error) {
+	id, err := a.resolveServiceIdentity(ctx, input.ServiceID)
+	if err != nil {
+		return nil, err
+	}
+
+	pods, err := a.k8s.CoreV1().Pods(id.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: "app=" + id.Name,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list pods: %w", err)
+	}
+
+	// Events are listed pod by pod: the namespace can hold other services'
+	// pods, and field selectors can't match a set of names.
+	var events []corev1.Event
+	for _, pod := range pods.Items {
+		list, err := a.k8s.CoreV1().Events(id.Namespace).List(ctx, metav1.ListOptions{
+			FieldSelector: "involvedObject.kind=Pod,involvedObject.name=" + pod.Name,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("list events: %w", err)
+		}
+		events = append(events, list.Items...)
+	}
+
+	health := classifyHealth(pods.Items, events, time.Now())
+	if health.Reason == HealthReasonCrashLoop || health.Reason == HealthReasonOOMKilled {
+		health.LastErrorLine = a.lastErrorLine(ctx, id.Namespace, pods.Items)
+	}
+	return &health, nil
+}
+
+// lastErrorLine reads the previous run of the first restarted container and
+// returns the last line that looks like an error, or failing that the last
+// line it printed.
+func (a *Activities) lastErrorLine(ctx context.Context, namespace string, pods []corev1.Pod) string {
+	for _, pod := range pods {
+		for _, cs := range pod.Status.ContainerStatuses {
+			if cs.RestartCount == 0 {
+				continue
+			}
+			raw, err := a.k8s.CoreV1().Pods(namespace).GetLogs(pod.Name, &corev1.PodLogOptions{
+				Container: cs.Name,
+				Previous:  true,
+				TailLines: ptr.To(int64(diagnoseLogLines)),
+			}).DoRaw(ctx)
+			if err != nil {
+				continue
+			}
+			return pickErrorLine(string(raw))
+		}
+	}
+	return ""
+}
+
+func pickErrorLine(logs string) string {
+	var last string
+	lines := strings.Split(logs, "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		line := strings.TrimSpace(lines[i])
+		if line == "" {
+			continue
+		}
+		if last == "" {
+			last = line
+		}
+		lower := strings.ToLower(line)
+		if strings.Contains(lower, "error") || strings.Contains(lower, "exception") || strings.Contains(lower, "panic") || strings.Contains(lower, "fatal") {
+			return line
+		}
+	}
+	return last
+}
+
+// classifyHealth picks the most specific problem across a service's pods.
+// A bad image or unschedulable pod explains any crash that follows, so
+// those win over crash loops, which win over failing probes.
+func classifyHealth(pods []corev1.Pod, events []corev1.Event, now time.Time) ServiceHealth {
+	if len(pods) == 0 {
+		return ServiceHealth{Status: HealthStopped, Message: "no instances are running"}
+	}
+
+	podNames := make(map[string]bool, len(pods))
+	for _, pod := range pods {
+		podNames[pod.Name] = true
+	}
+	latestEvent := make(map[string]*corev1.Event)
+	for i := range events {
+		ev := &events[i]
+		if !podNames[ev.InvolvedObject.Name] {
+			continue
+		}
+		if prev, ok := latestEvent[ev.Reason]; !ok || eventTime(ev).After(eventTime(prev)) {
+			latestEvent[ev.Reason] = ev
+		}
+	}
+
+	var (
+		restarts  int32
+		ready     int
+		imagePull *corev1.ContainerStateWaiting
+		oom       *corev1.ContainerStateTerminated
+		crashed   *corev1.ContainerStateTerminated
+		looping   bool
+		stuck     bool
+		pending   *corev1.PodCondition
+		probe     *corev1.Probe
+		memory    string
+	)
+	for _, pod := range pods {
+		if len(pod.Spec.Containers) > 0 && probe == nil {
+			c := pod.Spec.Containers[0]
+			probe = c.ReadinessProbe
+			memory = c.Resources.Limits.Memory().String()
+		}
+		for _, cond := range pod.Status.Conditions {
+			if cond.Type == corev1.PodScheduled && cond.Status == corev1.ConditionFalse && cond.Reason == corev1.PodReasonUnschedulable {
+				pending = &cond
+			}
+		}
+		podReady := len(pod.Status.ContainerStatuses) > 0
+		for _, cs := range pod.Status.ContainerStatuses {
+			restarts += cs.RestartCount
+			if !cs.Ready {
+				podReady = false
+				if r := cs.State.Running; r != nil && now.Sub(r.StartedAt.Time) > probeGracePeriod {
+					stuck = true
+				}
+			}
+			if w := cs.State.Waiting; w != nil {
+				switch w.Reason {
+				case "ErrImagePull", "ImagePullBackOff", "InvalidImageName":
+					imagePull = w
+				case "CrashLoopBackOff":
+					looping = true
+				}
+			}
+			for _, t := range []*corev1.ContainerStateTerminated{cs.State.Terminated, cs.LastTerminationState.Terminated} {
+				if t == nil {
+					continue
+				}
+				if t.Reason == "OOMKilled" {
+					oom = t
+				} else if t.ExitCode != 0 && crashed == nil {
+					crashed = t
+				}
+			}
+		}
+		if podReady {
+			ready++
+		}
+	}
+
+	health := ServiceHealth{Status: HealthUnhealthy, RestartCount: restarts}
+	switch {
+	case imagePull != nil:
+		health.Reason = HealthReasonImagePullFailed
+		health.Message = strings.TrimSpace(imagePull.Reason + ": " + imagePull.Message)
+		health.Suggestion = "The built image can't be pulled. Redeploy with redeploy_service to rebuild it."
+		return health
+
+	case pending != nil:
+		health.Reason = HealthReasonUnschedulable
+		health.Message = strings.TrimSpace(pending.Message)
+		if ev := latestEvent["FailedScheduling"]; ev != nil && health.Message == "" {
+			health.Message = ev.Message
+		}
+		health.Suggestion = "No node has room for an instance. Lower memory, vcpus or replicas with update_service."
+		return health
+
+	case oom != nil:
+		health.Reason = HealthReasonOOMKilled
+		health.ExitCode = ptr.To(oom.ExitCode)
+		health.Message = fmt.Sprintf("killed for exceeding its %s memory limit", memory)
+		health.Suggestion = "Raise memory with update_service or reduce the app's memory use."
+		return health
+
+	case looping || (crashed != nil && restarts > 0):
+		health.Reason = HealthReasonCrashLoop
+		health.Message = "the app keeps exiting after start"
+		if crashed != nil {
+			health.ExitCode = ptr.To(crashed.ExitCode)
+			health.Message = fmt.Sprintf("the app keeps exiting with code %d", crashed.ExitCode)
+		}
+		health.Suggestion = "Check last_error_line and the runtime logs; fix the error or the start_command and redeploy."
+		// Startup and liveness probes restart an app that answers with an
+		// error status, which looks like a crash from the container state.
+		if ev := latestEvent["Unhealthy"]; ev != nil && probe != nil && probe.HTTPGet != nil && strings.Contains(ev.Message, "statuscode") {
+			health.Reason = HealthReasonHealthCheckFailed
+			health.Message = describeProbeFailure(probe, ev.Message)
+			health.Suggestion = healthCheckSuggestion(probe)
+		}
+		return health
+	}
+
+	if ready == len(pods) {
+		return ServiceHealth{Status: HealthHealthy, RestartCount: restarts}
+	}
+
+	if ev := latestEvent["Unhealthy"]; ev != nil && stuck && probe != nil {
+		if strings.Contains(ev.Message, "connection refused") {
+			port := ""
+			switch {
+			case probe.TCPSocket != nil:
+				port = probe.TCPSocket.Port.String()
+			case probe.HTTPGet != nil:
+				port = probe.HTTPGet.Port.String()
+			}
+			health.Reason = HealthReasonPortMismatch
+			health.Message = fmt.Sprintf("nothing is listening on port %s", port)
+			health.Suggestion = fmt.Sprintf("Make the app listen on $PORT (%s) on 0.0.0.0, or set port with update_service to the port it uses.", port)
+			return health
+		}
+		if probe.HTTPGet != nil {
+			health.Reason = HealthReasonHealthCheckFailed
+			health.Message = describeProbeFailure(probe, ev.Message)
+			health.Suggestion = healthCheckSuggestion(probe)
+			return health
+		}
+	}
+
+	return ServiceHealth{Status: HealthStarting, RestartCount: restarts}
+}
+
+func healthCheckSuggestion(probe *corev1.Probe) string {
+	return fmt.Sprintf("Make %s respond with a 2xx status once the app is up, or adjust health_check with update_service.", probe.HTTPGet.Path)
+}
@@ -0,0 +1,135 @@
+package k8sdeployments
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestClassifyHealth(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	tcpProbe, _, _ := buildProbes(3000, nil)
+	httpProbe, _, _ := buildProbes(3000, &HealthCheck{Path: "/healthz"})
+
+	pod := func(probe *corev1.Probe, conditions []corev1.PodCondition, statuses ...corev1.ContainerStatus) corev1.Pod {
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "app-abc-123"},
+			Spec: corev1.PodSpec{Containers: []corev1.Container{{
+				Name:           "app",
+				ReadinessProbe: probe,
+			}}},
+			Status: corev1.PodStatus{Conditions: conditions, ContainerStatuses: statuses},
+		}
+	}
+	unhealthy := func(message string) []corev1.Event {
+		return []corev1.Event{{
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "app-abc-123"},
+			Reason:         "Unhealthy",
+			Message:        message,
+			LastTimestamp:  metav1.NewTime(now),
+		}}
+	}
+	runningSince := func(d time.Duration) corev1.ContainerState {
+		return corev1.ContainerState{Running: &corev1.ContainerStateRunning{StartedAt: metav1.NewTime(now.Add(-d))}}
+	}
+
+	tests := []struct {
+		name       string
+		pods       []corev1.Pod
+		events     []corev1.Event
+		wantStatus string
+		wantReason string
+	}{
+		{
+			name:       "no pods",
+			wantStatus: HealthStopped,
+		},
+		{
+			name:       "ready",
+			pods:       []corev1.Pod{pod(tcpProbe, nil, corev1.ContainerStatus{Ready: true, State: runningSince(time.Hour)})},
+			wantStatus: HealthHealthy,
+		},
+		{
+			name: "crash loop",
+			pods: []corev1.Pod{pod(tcpProbe, nil, corev1.ContainerStatus{
+				RestartCount:         4,
+				State:                corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+				LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Reason: "Error", ExitCode: 1}},
+			})},
+			wantStatus: HealthUnhealthy,
+			wantReason: HealthReasonCrashLoop,
+		},
+		{
+			name: "oom killed",
+			pods: []corev1.Pod{pod(tcpProbe, nil, corev1.ContainerStatus{
+				RestartCount:         2,
+				State:                corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+				LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Reason: "OOMKilled", ExitCode: 137}},
+			})},
+			wantStatus: HealthUnhealthy,
+			wantReason: HealthReasonOOMKilled,
+		},
+		{
+			name: "image pull",
+			pods: []corev1.Pod{pod(tcpProbe, nil, corev1.ContainerStatus{
+				State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ImagePullBackOff"}},
+			})},
+			wantStatus: HealthUnhealthy,
+			wantReason: HealthReasonImagePullFailed,
+		},
+		{
+			name: "unschedulable",
+			pods: []corev1.Pod{pod(tcpProbe, []corev1.PodCondition{{
+				Type:    corev1.PodScheduled,
+				Status:  corev1.ConditionFalse,
+				Reason:  corev1.PodReasonUnschedulable,
+				Message: "0/3 nodes are available: 3 Insufficient memory.",
+			}})},
+			wantStatus: HealthUnhealthy,
+			wantReason: HealthReasonUnschedulable,
+		},
+		{
+			name:       "port mismatch",
+			pods:       []corev1.Pod{pod(tcpProbe, nil, corev1.ContainerStatus{State: runningSince(5 * time.Minute)})},
+			events:     unhealthy("Readiness probe failed: dial tcp 10.0.0.5:3000: connect: connection refused"),
+			wantStatus: HealthUnhealthy,
+			wantReason: HealthReasonPortMismatch,
+		},
+		{
+			name:       "still booting",
+			pods:       []corev1.Pod{pod(tcpProbe, nil, corev1.ContainerStatus{State: runningSince(10 * time.Second)})},
+			events:     unhealthy("Readiness probe failed: dial tcp 10.0.0.5:3000: connect: connection refused"),
+			wantStatus: HealthStarting,
+		},
+		{
+			name:       "health check failing",
+			pods:       []corev1.Pod{pod(httpProbe, nil, corev1.ContainerStatus{State: runningSince(5 * time.Minute)})},
+			events:     unhealthy("Readiness probe failed: HTTP probe failed with statuscode: 503"),
+			wantStatus: HealthUnhealthy,
+			wantReason: HealthReasonHealthCheckFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := classifyHealth(tt.pods, tt.events, now)
+			if got.Status != tt.wantStatus || got.Reason != tt.wantReason {
+				t.Fatalf("classifyHealth() = %s/%s, want %s/%s (%s)", got.Status, got.Reason, tt.wantStatus, tt.wantReason, got.Message)
+			}
+			if got.Reason != "" && got.Suggestion == "" {
+				t.Fatalf("classifyHealth() reason %s has no suggestion", got.Reason)
+			}
+		})
+	}
+}
+
+func TestPickErrorLine(t *testing.T) {
+	logs := "Starting server\nError: Cannot find module 'express'\n    at Module._resolveFilename (node:internal)\n\n"
+	if got, want := pickErrorLine(logs), "Error: Cannot find module 'express'"; got != want {
+		t.Fatalf("pickErrorLine() = %q, want %q", got, want)
+	}
+	if got, want := pickErrorLine("booting\nlistening on 3000\n"), "listening on 3000"; got != want {
+		t.Fatalf("pickErrorLine() = %q, want %q", got, want)
+	}
+}
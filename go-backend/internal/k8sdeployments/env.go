@@ -0,0 +1,48 @@
+package k8sdeployments
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Env var scopes. Build vars are passed to the build as secrets and runtime
+// vars land in the service's -env Secret. A var stored without a scope is
+// both, as every var was before scopes existed.
+const (
+	EnvScopeBuild   = "build"
+	EnvScopeRuntime = "runtime"
+	EnvScopeBoth    = "both"
+)
+
+// ValidateEnvScope checks an env var's scope. Empty means EnvScopeBoth.
+func ValidateEnvScope(scope string) error {
+	switch scope {
+	case "", EnvScopeBuild, EnvScopeRuntime, EnvScopeBoth:
+		return nil
+	}
+	return fmt.Errorf("invalid env var scope %q: must be build, runtime or both", scope)
+}
+
+// parseEnvVars decodes a service's stored env vars, keeping those visible
+// in scope (EnvScopeBuild or EnvScopeRuntime).
+func parseEnvVars(raw json.RawMessage, scope string) map[string]string {
+	envVars := make(map[string]string)
+	if len(raw) == 0 {
+		return envVars
+	}
+	if err := json.Unmarshal(raw, &envVars); err != nil {
+		var envArr []struct {
+			Key   string `json:"key"`
+			Value string `json:"value"`
+			Scope string `json:"scope"`
+		}
+		if err := json.Unmarshal(raw, &envArr); err == nil {
+			for _, e := range envArr {
+				if e.Scope == "" || e.Scope == EnvScopeBoth || e.Scope == scope {
+					envVars[e.Key] = e.Value
+				}
+			}
+		}
+	}
+	return envVars
+}
@@ -0,0 +1,98 @@
+package k8sdeployments
+
+import (
+	"context"
+	"maps"
+	"testing"
+
+	"github.com/augustdev/autoclip/internal/resources"
+	"github.com/augustdev/autoclip/internal/storage/pg/generated/services"
+	"github.com/jackc/pgx/v5"
+)
+
+func TestParseEnvVars_Scopes(t *testing.T) {
+	raw := []byte(`[
+		{"key":"NPM_TOKEN","value":"npm","scope":"build"},
+		{"key":"API_KEY","value":"secret","scope":"runtime"},
+		{"key":"NODE_ENV","value":"production","scope":"both"},
+		{"key":"LEGACY","value":"1"}
+	]`)
+
+	tests := map[string]map[string]string{
+		EnvScopeBuild:   {"NPM_TOKEN": "npm", "NODE_ENV": "production", "LEGACY": "1"},
+		EnvScopeRuntime: {"API_KEY": "secret", "NODE_ENV": "production", "LEGACY": "1"},
+	}
+	for scope, want := range tests {
+		if got := parseEnvVars(raw, scope); !maps.Equal(got, want) {
+			t.Errorf("parseEnvVars(%s) = %v, want %v", scope, got, want)
+		}
+	}
+}
+
+func TestParseEnvVars_LegacyMap(t *testing.T) {
+	got := parseEnvVars([]byte(`{"A":"1"}`), EnvScopeBuild)
+	if !maps.Equal(got, map[string]string{"A": "1"}) {
+		t.Fatalf("parseEnvVars() = %v", got)
+	}
+}
+
+func TestValidateEnvScope(t *testing.T) {
+	for _, scope := range []string{"", EnvScopeBuild, EnvScopeRuntime, EnvScopeBoth} {
+		if err := ValidateEnvScope(scope); err != nil {
+			t.Errorf("ValidateEnvScope(%q) = %v", scope, err)
+		}
+	}
+	if err := ValidateEnvScope("deploy"); err == nil {
+		t.Fatal("expected an unknown scope to be rejected")
+	}
+}
+
+// projectResources serves the resources of one project by name.
+type projectResources struct {
+	services.Querier
+	projectID string
+	byName    map[string]services.GetReferencedResourceRow
+}
+
+func (p *projectResources) GetReferencedResource(ctx context.Context, arg services.GetReferencedResourceParams) (services.GetReferencedResourceRow, error) {
+	r, ok := p.byName[arg.Name]
+	if arg.ProjectID != p.projectID || !ok {
+		return services.GetReferencedResourceRow{}, pgx.ErrNoRows
+	}
+	return r, nil
+}
+
+func TestRuntimeEnvVars_ResolvesRefsInServiceProject(t *testing.T) {
+	creds, err := resources.EncryptCredentials(&resources.Credentials{URL: "libsql://main", AuthToken: "secret"}, "key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := &Activities{
+		servicesQ: &projectResources{
+			projectID: "proj-1",
+			byName: map[string]services.GetReferencedResourceRow{
+				"main-db": {Name: "main-db", Status: resources.StatusActive, Credentials: creds},
+			},
+		},
+		config: Config{CredentialsEncryptionKey: "key"},
+	}
+	svc := services.Service{ID: "svc-1", UserID: "user-1", ProjectID: "proj-1"}
+	raw := []byte(`[
+		{"key":"DATABASE_URL","value":"${{resources.main-db.url}}"},
+		{"key":"AUTH","value":"Bearer ${{ resources.main-db.auth_token }}","scope":"runtime"}
+	]`)
+
+	got, err := a.runtimeEnvVars(context.Background(), svc, raw)
+	if err != nil {
+		t.Fatalf("runtimeEnvVars() error = %v", err)
+	}
+	want := map[string]string{"DATABASE_URL": "libsql://main", "AUTH": "Bearer secret"}
+	if !maps.Equal(got, want) {
+		t.Fatalf("runtimeEnvVars() = %v, want %v", got, want)
+	}
+
+	svc.ProjectID = "proj-2"
+	if _, err := a.runtimeEnvVars(context.Background(), svc, raw); err == nil {
+		t.Fatal("expected a reference to another project's resource to fail")
+	}
+}
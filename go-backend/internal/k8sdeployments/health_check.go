@@ -0,0 +1,134 @@
+package k8sdeployments
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	defaultHealthCheckTimeout          = 3
+	defaultHealthCheckFailureThreshold = 3
+
+	// startupProbePeriod and startupProbeFailures give an app about two
+	// minutes to come up before it is restarted, inside the rollout wait.
+	startupProbePeriod   = 2
+	startupProbeFailures = 60
+)
+
+// HealthCheck replaces the default TCP readiness check with HTTP probes
+// against Path. The kubelet counts any 2xx or 3xx answer as healthy.
+type HealthCheck struct {
+	Path                string `json:"path"`
+	InitialDelaySeconds int32  `json:"initial_delay_seconds,omitempty"`
+	TimeoutSeconds      int32  `json:"timeout_seconds,omitempty"`
+	FailureThreshold    int32  `json:"failure_threshold,omitempty"`
+}
+
+// ValidateHealthCheck checks a health check and fills in its defaults.
+func ValidateHealthCheck(hc *HealthCheck) error {
+	if hc == nil {
+		return nil
+	}
+	hc.Path = strings.TrimSpace(hc.Path)
+	if !strings.HasPrefix(hc.Path, "/") {
+		return fmt.Errorf("invalid health_check path %q: must start with /", hc.Path)
+	}
+	if hc.InitialDelaySeconds < 0 || hc.InitialDelaySeconds > 60 {
+		return fmt.Errorf("invalid health_check initial_delay_seconds %d: must be between 0 and 60", hc.InitialDelaySeconds)
+	}
+	if hc.TimeoutSeconds == 0 {
+		hc.TimeoutSeconds = defaultHealthCheckTimeout
+	}
+	if hc.TimeoutSeconds < 1 || hc.TimeoutSeconds > 60 {
+		return fmt.Errorf("invalid health_check timeout_seconds %d: must be between 1 and 60", hc.TimeoutSeconds)
+	}
+	if hc.FailureThreshold == 0 {
+		hc.FailureThreshold = defaultHealthCheckFailureThreshold
+	}
+	if hc.FailureThreshold < 1 || hc.FailureThreshold > 10 {
+		return fmt.Errorf("invalid health_check failure_threshold %d: must be between 1 and 10", hc.FailureThreshold)
+	}
+	return nil
+}
+
+// buildProbes returns the readiness, liveness and startup probes for a
+// container. Without a health check only the TCP readiness probe is set.
+func buildProbes(port int32, hc *HealthCheck) (readiness, liveness, startup *corev1.Probe) {
+	if hc == nil {
+		return &corev1.Probe{
+			ProbeHandler: corev1.ProbeHandler{
+				TCPSocket: &corev1.TCPSocketAction{
+					Port: intstr.FromInt32(port),
+				},
+			},
+			InitialDelaySeconds: 1,
+			PeriodSeconds:       2,
+			TimeoutSeconds:      3,
+			FailureThreshold:    3,
+		}, nil, nil
+	}
+
+	handler := corev1.ProbeHandler{
+		HTTPGet: &corev1.HTTPGetAction{
+			Path: hc.Path,
+			Port: intstr.FromInt32(port),
+		},
+	}
+	timeout := hc.TimeoutSeconds
+	if timeout == 0 {
+		timeout = defaultHealthCheckTimeout
+	}
+	failures := hc.FailureThreshold
+	if failures == 0 {
+		failures = defaultHealthCheckFailureThreshold
+	}
+
+	// The startup probe holds off the other two until the app first
+	// answers, so a slow boot isn't killed by liveness.
+	startup = &corev1.Probe{
+		ProbeHandler:        handler,
+		InitialDelaySeconds: hc.InitialDelaySeconds,
+		PeriodSeconds:       startupProbePeriod,
+		TimeoutSeconds:      timeout,
+		FailureThreshold:    startupProbeFailures,
+	}
+	readiness = &corev1.Probe{
+		ProbeHandler:     handler,
+		PeriodSeconds:    5,
+		TimeoutSeconds:   timeout,
+		FailureThreshold: failures,
+	}
+	liveness = &corev1.Probe{
+		ProbeHandler:     handler,
+		PeriodSeconds:    10,
+		TimeoutSeconds:   timeout,
+		FailureThreshold: failures,
+	}
+	return readiness, liveness, startup
+}
+
+// describeProbeFailure turns a kubelet Unhealthy event message into
+// something a user can act on, e.g. "health check /healthz returned 500".
+func describeProbeFailure(probe *corev1.Probe, message string) string {
+	_, detail, ok := strings.Cut(message, "probe failed: ")
+	if !ok {
+		detail = message
+	}
+	detail = strings.TrimSpace(detail)
+
+	subject := "health check"
+	switch {
+	case probe != nil && probe.HTTPGet != nil:
+		subject = "health check " + probe.HTTPGet.Path
+	case probe != nil && probe.TCPSocket != nil:
+		subject = "readiness check on port " + probe.TCPSocket.Port.String()
+	}
+
+	if code, ok := strings.CutPrefix(detail, "HTTP probe failed with statuscode: "); ok {
+		return fmt.Sprintf("%s returned %s", subject, code)
+	}
+	return fmt.Sprintf("%s failed: %s", subject, detail)
+}
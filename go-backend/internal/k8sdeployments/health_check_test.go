@@ -0,0 +1,83 @@
+package k8sdeployments
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestValidateHealthCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		hc      *HealthCheck
+		wantErr bool
+	}{
+		{"unset", nil, false},
+		{"path only", &HealthCheck{Path: "/healthz"}, false},
+		{"relative path", &HealthCheck{Path: "healthz"}, true},
+		{"long initial delay", &HealthCheck{Path: "/healthz", InitialDelaySeconds: 600}, true},
+		{"threshold over 10", &HealthCheck{Path: "/healthz", FailureThreshold: 11}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateHealthCheck(tt.hc)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateHealthCheck() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBuildProbes_DefaultIsTCPReadinessOnly(t *testing.T) {
+	readiness, liveness, startup := buildProbes(3000, nil)
+	if readiness == nil || readiness.TCPSocket == nil {
+		t.Fatalf("expected a TCP readiness probe, got %+v", readiness)
+	}
+	if liveness != nil || startup != nil {
+		t.Fatal("expected no liveness or startup probe without a health check")
+	}
+}
+
+func TestBuildProbes_HealthCheck(t *testing.T) {
+	hc := &HealthCheck{Path: "/healthz", InitialDelaySeconds: 10, TimeoutSeconds: 5, FailureThreshold: 4}
+	readiness, liveness, startup := buildProbes(8080, hc)
+
+	for name, p := range map[string]*corev1.Probe{"readiness": readiness, "liveness": liveness, "startup": startup} {
+		if p == nil || p.HTTPGet == nil {
+			t.Fatalf("%s probe is not an HTTP probe", name)
+		}
+		if p.HTTPGet.Path != "/healthz" || p.HTTPGet.Port != intstr.FromInt32(8080) {
+			t.Fatalf("%s probe targets %s on %s", name, p.HTTPGet.Path, p.HTTPGet.Port.String())
+		}
+		if p.TimeoutSeconds != 5 {
+			t.Fatalf("%s probe timeout = %d, want 5", name, p.TimeoutSeconds)
+		}
+	}
+	if startup.InitialDelaySeconds != 10 {
+		t.Fatalf("startup initial delay = %d, want 10", startup.InitialDelaySeconds)
+	}
+	if readiness.FailureThreshold != 4 || liveness.FailureThreshold != 4 {
+		t.Fatalf("failure thresholds = %d/%d, want 4", readiness.FailureThreshold, liveness.FailureThreshold)
+	}
+}
+
+func TestDescribeProbeFailure(t *testing.T) {
+	httpProbe, _, _ := buildProbes(3000, &HealthCheck{Path: "/healthz"})
+	tcpProbe, _, _ := buildProbes(3000, nil)
+
+	tests := []struct {
+		probe   *corev1.Probe
+		message string
+		want    string
+	}{
+		{httpProbe, "Readiness probe failed: HTTP probe failed with statuscode: 500", "health check /healthz returned 500"},
+		{httpProbe, `Liveness probe failed: Get "http://10.0.0.5:3000/healthz": context deadline exceeded`, `health check /healthz failed: Get "http://10.0.0.5:3000/healthz": context deadline exceeded`},
+		{tcpProbe, "Readiness probe failed: dial tcp 10.0.0.5:3000: connect: connection refused", "readiness check on port 3000 failed: dial tcp 10.0.0.5:3000: connect: connection refused"},
+	}
+	for _, tt := range tests {
+		if got := describeProbeFailure(tt.probe, tt.message); got != tt.want {
+			t.Errorf("describeProbeFailure(%q) = %q, want %q", tt.message, got, tt.want)
+		}
+	}
+}
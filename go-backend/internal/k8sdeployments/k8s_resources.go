@@ -5,8 +5,10 @@ import (
 	"strconv"
 
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -30,14 +32,25 @@ var allowedVCPUs = map[string]bool{
 	"0.5": true, "1": true, "2": true, "4": true,
 }
 
-func validateResourceLimits(memory, vcpus string) error {
+// ValidateReplicas checks a replica count against the owner's quota.
+func ValidateReplicas(replicas, maxReplicas int32) error {
+	if replicas < 1 {
+		return fmt.Errorf("invalid replicas %d: must be at least 1", replicas)
+	}
+	if replicas > maxReplicas {
+		return fmt.Errorf("invalid replicas %d: exceeds your limit of %d", replicas, maxReplicas)
+	}
+	return nil
+}
+
+func validateResourceLimits(memory, vcpus string, replicas, maxReplicas int32) error {
 	if !allowedMemory[memory] {
 		return fmt.Errorf("invalid memory limit %q: must be one of 256Mi, 512Mi, 1024Mi, 2048Mi, 4096Mi", memory)
 	}
 	if !allowedVCPUs[vcpus] {
 		return fmt.Errorf("invalid vcpus limit %q: must be one of 0.5, 1, 2, 4", vcpus)
 	}
-	return nil
+	return ValidateReplicas(replicas, maxReplicas)
 }
 
 func buildNamespace(namespace, tenant, project string) *corev1.Namespace {
@@ -63,7 +76,14 @@ func buildIngressNetworkPolicy(namespace string) *networkingv1.NetworkPolicy {
 			Namespace: namespace,
 		},
 		Spec: networkingv1.NetworkPolicySpec{
-			PodSelector: metav1.LabelSelector{},
+			// Resources get a policy of their own that keeps the ingress
+			// controller out.
+			PodSelector: metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{{
+					Key:      resourceLabel,
+					Operator: metav1.LabelSelectorOpDoesNotExist,
+				}},
+			},
 			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
 			Ingress: []networkingv1.NetworkPolicyIngressRule{
 				{From: []networkingv1.NetworkPolicyPeer{{PodSelector: &metav1.LabelSelector{}}}},
@@ -90,6 +110,18 @@ func buildIngressNetworkPolicy(namespace string) *networkingv1.NetworkPolicy {
 						},
 					},
 				}},
+				// The activator proxies requests it held while waking a
+				// sleeping service.
+				{From: []networkingv1.NetworkPolicyPeer{
+					{
+						NamespaceSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{"kubernetes.io/metadata.name": "dp-system"},
+						},
+						PodSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{"app": "deployer-server"},
+						},
+					},
+				}},
 			},
 		},
 	}
@@ -148,11 +180,30 @@ func buildSecret(namespace, name string, envVars map[string]string) *corev1.Secr
 	}
 }
 
-func buildDeployment(namespace, name, imageRef string, port int32, memory, vcpus string) *appsv1.Deployment {
+// registryPullSecretName is the image pull secret of a service deployed
+// from a private registry.
+func registryPullSecretName(name string) string {
+	return name + "-registry"
+}
+
+func buildRegistryPullSecret(namespace, name string, dockerConfig []byte) *corev1.Secret {
+	return &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{Kind: "Secret", APIVersion: "v1"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      registryPullSecretName(name),
+			Namespace: namespace,
+		},
+		Type: corev1.SecretTypeDockerConfigJson,
+		Data: map[string][]byte{corev1.DockerConfigJsonKey: dockerConfig},
+	}
+}
+
+func buildDeployment(namespace, name, imageRef string, port int32, memory, vcpus string, replicas int32, healthCheck *HealthCheck) *appsv1.Deployment {
 	memLimit := resource.MustParse(memory)
 	cpuLimit := resource.MustParse(vcpus)
+	readiness, liveness, startup := buildProbes(port, healthCheck)
 
-	return &appsv1.Deployment{
+	deployment := &appsv1.Deployment{
 		TypeMeta: metav1.TypeMeta{Kind: "Deployment", APIVersion: "apps/v1"},
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
@@ -160,7 +211,7 @@ func buildDeployment(namespace, name, imageRef string, port int32, memory, vcpus
 			Labels:    map[string]string{"app": name},
 		},
 		Spec: appsv1.DeploymentSpec{
-			Replicas: ptr.To(int32(1)),
+			Replicas: ptr.To(replicas),
 			Selector: &metav1.LabelSelector{
 				MatchLabels: map[string]string{"app": name},
 			},
@@ -201,23 +252,100 @@ func buildDeployment(namespace, name, imageRef string, port int32, memory, vcpus
 								AllowPrivilegeEscalation: ptr.To(false),
 								ReadOnlyRootFilesystem:   ptr.To(false),
 							},
-							ReadinessProbe: &corev1.Probe{
-								ProbeHandler: corev1.ProbeHandler{
-									TCPSocket: &corev1.TCPSocketAction{
-										Port: intstr.FromInt32(port),
-									},
-								},
-								InitialDelaySeconds: 1,
-								PeriodSeconds:       2,
-								TimeoutSeconds:      3,
-								FailureThreshold:    3,
-							},
+							ReadinessProbe: readiness,
+							LivenessProbe:  liveness,
+							StartupProbe:   startup,
 						},
 					},
 				},
 			},
 		},
 	}
+
+	// Spread replicas across nodes (and zones where the cluster has them) so
+	// losing one node doesn't take the whole service down. ScheduleAnyway
+	// keeps small clusters schedulable.
+	if replicas > 1 {
+		selector := &metav1.LabelSelector{MatchLabels: map[string]string{"app": name}}
+		deployment.Spec.Template.Spec.TopologySpreadConstraints = []corev1.TopologySpreadConstraint{
+			{
+				MaxSkew:           1,
+				TopologyKey:       "kubernetes.io/hostname",
+				WhenUnsatisfiable: corev1.ScheduleAnyway,
+				LabelSelector:     selector,
+			},
+			{
+				MaxSkew:           1,
+				TopologyKey:       "topology.kubernetes.io/zone",
+				WhenUnsatisfiable: corev1.ScheduleAnyway,
+				LabelSelector:     selector,
+			},
+		}
+	}
+
+	return deployment
+}
+
+// buildPodDisruptionBudget keeps all but one replica up during voluntary
+// disruptions such as node drains. Only used when replicas > 1.
+func buildPodDisruptionBudget(namespace, name string) *policyv1.PodDisruptionBudget {
+	maxUnavailable := intstr.FromInt32(1)
+	return &policyv1.PodDisruptionBudget{
+		TypeMeta: metav1.TypeMeta{Kind: "PodDisruptionBudget", APIVersion: "policy/v1"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			MaxUnavailable: &maxUnavailable,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"app": name},
+			},
+		},
+	}
+}
+
+func buildHorizontalPodAutoscaler(namespace, name string, cfg AutoscalingConfig) *autoscalingv2.HorizontalPodAutoscaler {
+	var metrics []autoscalingv2.MetricSpec
+	for _, t := range []struct {
+		resource corev1.ResourceName
+		target   *int32
+	}{
+		{corev1.ResourceCPU, cfg.TargetCPUUtilization},
+		{corev1.ResourceMemory, cfg.TargetMemoryUtilization},
+	} {
+		if t.target == nil {
+			continue
+		}
+		metrics = append(metrics, autoscalingv2.MetricSpec{
+			Type: autoscalingv2.ResourceMetricSourceType,
+			Resource: &autoscalingv2.ResourceMetricSource{
+				Name: t.resource,
+				Target: autoscalingv2.MetricTarget{
+					Type:               autoscalingv2.UtilizationMetricType,
+					AverageUtilization: ptr.To(*t.target),
+				},
+			},
+		})
+	}
+
+	return &autoscalingv2.HorizontalPodAutoscaler{
+		TypeMeta: metav1.TypeMeta{Kind: "HorizontalPodAutoscaler", APIVersion: "autoscaling/v2"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+				APIVersion: "apps/v1",
+				Kind:       "Deployment",
+				Name:       name,
+			},
+			MinReplicas: ptr.To(cfg.MinReplicas),
+			MaxReplicas: cfg.MaxReplicas,
+			Metrics:     metrics,
+		},
+	}
 }
 
 func buildService(namespace, name string, port int32) *corev1.Service {
@@ -239,6 +367,27 @@ func buildService(namespace, name string, port int32) *corev1.Service {
 	}
 }
 
+// buildWakeService points at the activator while a service sleeps. Its routes
+// are retargeted here so the first request reaches something that can wake
+// the Deployment.
+func buildWakeService(namespace, name, activatorHost string, activatorPort int32) *corev1.Service {
+	return &corev1.Service{
+		TypeMeta: metav1.TypeMeta{Kind: "Service", APIVersion: "v1"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      wakeServiceName(name),
+			Namespace: namespace,
+			Labels:    map[string]string{"app": name},
+		},
+		Spec: corev1.ServiceSpec{
+			Type:         corev1.ServiceTypeExternalName,
+			ExternalName: activatorHost,
+			Ports: []corev1.ServicePort{
+				{Port: activatorPort},
+			},
+		},
+	}
+}
+
 func buildIngress(namespace, name, host string, port int32) *networkingv1.Ingress {
 	pathType := networkingv1.PathTypePrefix
 	ingressClassName := "traefik"
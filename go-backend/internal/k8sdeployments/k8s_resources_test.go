@@ -0,0 +1,53 @@
+package k8sdeployments
+
+import "testing"
+
+func TestBuildDeployment_SingleReplicaHasNoSpreadConstraints(t *testing.T) {
+	d := buildDeployment("ns", "app", "img:1", 3000, "256Mi", "0.5", 1, nil)
+
+	if got := *d.Spec.Replicas; got != 1 {
+		t.Fatalf("replicas = %d, want 1", got)
+	}
+	if n := len(d.Spec.Template.Spec.TopologySpreadConstraints); n != 0 {
+		t.Fatalf("expected no topology spread constraints, got %d", n)
+	}
+}
+
+func TestBuildDeployment_MultipleReplicasSpreadAcrossNodes(t *testing.T) {
+	d := buildDeployment("ns", "app", "img:1", 3000, "256Mi", "0.5", 3, nil)
+
+	if got := *d.Spec.Replicas; got != 3 {
+		t.Fatalf("replicas = %d, want 3", got)
+	}
+	constraints := d.Spec.Template.Spec.TopologySpreadConstraints
+	if len(constraints) != 2 {
+		t.Fatalf("expected 2 topology spread constraints, got %d", len(constraints))
+	}
+	for _, c := range constraints {
+		if c.LabelSelector == nil || c.LabelSelector.MatchLabels["app"] != "app" {
+			t.Fatalf("constraint %s does not select the app's pods", c.TopologyKey)
+		}
+	}
+}
+
+func TestValidateResourceLimits_Replicas(t *testing.T) {
+	tests := []struct {
+		name        string
+		replicas    int32
+		maxReplicas int32
+		wantErr     bool
+	}{
+		{"single", 1, 3, false},
+		{"at quota", 3, 3, false},
+		{"over quota", 4, 3, true},
+		{"zero", 0, 3, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateResourceLimits("256Mi", "0.5", tt.replicas, tt.maxReplicas)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validateResourceLimits() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
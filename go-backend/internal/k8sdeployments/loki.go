@@ -138,8 +138,10 @@ func QueryLoki(ctx context.Context, queryURL, username, password, logQL string,
 
 func queryLogs(ctx context.Context, lokiQueryURL, username, password, logQL string, since time.Duration, limit int) ([]string, error) {
 	end := time.Now()
-	start := end.Add(-since)
+	return queryLogsRange(ctx, lokiQueryURL, username, password, logQL, end.Add(-since), end, limit)
+}
 
+func queryLogsRange(ctx context.Context, lokiQueryURL, username, password, logQL string, start, end time.Time, limit int) ([]string, error) {
 	result, err := QueryLoki(ctx, lokiQueryURL, username, password, logQL, start, end, limit)
 	if err != nil {
 		return nil, err
@@ -164,6 +166,13 @@ func QueryBuildLogs(ctx context.Context, lokiQueryURL, username, password, names
 	return queryLogs(ctx, lokiQueryURL, username, password, fmt.Sprintf(`{job="build", namespace=%q, service=%q}`, namespace, service), since, limit)
 }
 
+// QueryBuildLogsRange fetches build logs emitted between start and end. Build
+// streams are labelled per service, not per deployment, so a single
+// deployment's log is selected by its time window.
+func QueryBuildLogsRange(ctx context.Context, lokiQueryURL, username, password, namespace, service string, start, end time.Time, limit int) ([]string, error) {
+	return queryLogsRange(ctx, lokiQueryURL, username, password, fmt.Sprintf(`{job="build", namespace=%q, service=%q}`, namespace, service), start, end, limit)
+}
+
 func QueryRunLogs(ctx context.Context, lokiQueryURL, username, password, namespace, service string, since time.Duration, limit int) ([]string, error) {
 	return queryLogs(ctx, lokiQueryURL, username, password, fmt.Sprintf(`{namespace=%q, container=%q}`, namespace, service), since, limit)
 }
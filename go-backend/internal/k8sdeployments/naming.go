@@ -27,3 +27,54 @@ func NamespaceName(userID, projectRef string) string {
 func ServiceName(appName string) string {
 	return sanitizeDNS(appName)
 }
+
+// BuildWorkflowID is the child workflow ID deployService uses for a build, so
+// concurrent deploys of the same commit share one build.
+func BuildWorkflowID(serviceID, commitSHA string) string {
+	return fmt.Sprintf("build-%s-%s", serviceID, commitSHA)
+}
+
+// SleepIdleServicesWorkflowID is the cron workflow that puts a region's idle
+// services to sleep.
+func SleepIdleServicesWorkflowID(region string) string {
+	return "sleep-idle-services-" + region
+}
+
+// WakeServiceWorkflowID is shared by every request that arrives while a
+// service sleeps, so they all wait on one wake.
+func WakeServiceWorkflowID(serviceID string) string {
+	return "wake-" + serviceID
+}
+
+// DiagnoseServiceWorkflowID lets concurrent get_service calls for one service
+// share a diagnosis.
+func DiagnoseServiceWorkflowID(serviceID string) string {
+	return "diagnose-" + serviceID
+}
+
+// ComposeComponentName names the image built for a compose component and,
+// for all but the public one, which runs under the service's own name, its
+// Deployment and Secret.
+func ComposeComponentName(serviceName, component string) string {
+	return serviceName + "-" + component
+}
+
+// PostgresName names the StatefulSet, Service and Secret of a postgres
+// resource. StatefulSet names are kept short enough for the
+// controller-revision-hash label their pods get.
+func PostgresName(resourceName string) string {
+	name := sanitizeDNS(resourceName)
+	if len(name) > 40 {
+		name = strings.TrimRight(name[:40], "-")
+	}
+	return "pg-" + name
+}
+
+// RedisName names the objects of a redis resource, like PostgresName.
+func RedisName(resourceName string) string {
+	name := sanitizeDNS(resourceName)
+	if len(name) > 40 {
+		name = strings.TrimRight(name[:40], "-")
+	}
+	return "redis-" + name
+}
@@ -0,0 +1,51 @@
+package k8sdeployments
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+// podExecutor runs cmd in a container of a pod, feeding it stdin. The
+// fake clientset can't exec, so tests substitute their own.
+type podExecutor func(ctx context.Context, namespace, pod, container string, cmd []string, stdin io.Reader) error
+
+// newPodExecutor execs through the API server the way kubectl exec does.
+func newPodExecutor(k8s kubernetes.Interface, restConfig *rest.Config) podExecutor {
+	return func(ctx context.Context, namespace, pod, container string, cmd []string, stdin io.Reader) error {
+		req := k8s.CoreV1().RESTClient().Post().
+			Resource("pods").
+			Namespace(namespace).
+			Name(pod).
+			SubResource("exec").
+			VersionedParams(&corev1.PodExecOptions{
+				Container: container,
+				Command:   cmd,
+				Stdin:     stdin != nil,
+				Stdout:    true,
+				Stderr:    true,
+			}, scheme.ParameterCodec)
+
+		executor, err := remotecommand.NewSPDYExecutor(restConfig, "POST", req.URL())
+		if err != nil {
+			return fmt.Errorf("exec in pod %s: %w", pod, err)
+		}
+		var stderr bytes.Buffer
+		if err := executor.StreamWithContext(ctx, remotecommand.StreamOptions{
+			Stdin:  stdin,
+			Stdout: io.Discard,
+			Stderr: &stderr,
+		}); err != nil {
+			return fmt.Errorf("exec in pod %s: %w: %s", pod, err, strings.TrimSpace(stderr.String()))
+		}
+		return nil
+	}
+}
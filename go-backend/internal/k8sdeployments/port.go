@@ -13,6 +13,26 @@ func EffectivePort(buildPack, port string, buildConfig []byte) int32 {
 	return ParsePortString(effectiveAppPort(buildPack, port, bc.PublishDirectory))
 }
 
+// DefaultServicePort is the port a service built with buildPack listens on
+// when none is given. An empty port is left for the build to detect: the
+// Dockerfile's EXPOSE or the public compose service's published port.
+func DefaultServicePort(buildPack, publishDir string) string {
+	switch buildPack {
+	case "railpack":
+		if publishDir != "" {
+			// Railpack static serving always binds nginx on 8080.
+			return "8080"
+		}
+		return "3000"
+	case "static":
+		return "80"
+	case "dockerfile", "dockercompose":
+		return ""
+	default:
+		return "3000"
+	}
+}
+
 func effectiveAppPort(buildPack, appPort, publishDir string) string {
 	port := strings.TrimSpace(appPort)
 	if port == "" {
@@ -1,17 +1,32 @@
 package k8sdeployments
 
-import "go.temporal.io/sdk/worker"
+import (
+	"github.com/augustdev/autoclip/internal/resources"
+	"go.temporal.io/sdk/worker"
+	"go.temporal.io/sdk/workflow"
+)
 
 func RegisterWorkflowsAndActivities(w worker.Worker, activities *Activities) {
 	w.RegisterWorkflow(CreateServiceWorkflow)
 	w.RegisterWorkflow(RedeployServiceWorkflow)
+	w.RegisterWorkflow(RollbackServiceWorkflow)
 	w.RegisterWorkflow(DeleteServiceWorkflow)
+	w.RegisterWorkflow(DeleteNamespaceWorkflow)
 	w.RegisterWorkflow(BuildServiceWorkflow)
+	w.RegisterWorkflow(SleepIdleServicesWorkflow)
+	w.RegisterWorkflow(WakeServiceWorkflow)
+	w.RegisterWorkflow(DiagnoseServiceWorkflow)
+	w.RegisterWorkflowWithOptions(ProvisionPostgresWorkflow, workflow.RegisterOptions{Name: resources.ProvisionPostgresWorkflowName})
+	w.RegisterWorkflowWithOptions(DeletePostgresWorkflow, workflow.RegisterOptions{Name: resources.DeletePostgresWorkflowName})
+	w.RegisterWorkflowWithOptions(RotatePostgresPasswordWorkflow, workflow.RegisterOptions{Name: resources.RotatePostgresPasswordWorkflowName})
+	w.RegisterWorkflowWithOptions(ProvisionRedisWorkflow, workflow.RegisterOptions{Name: resources.ProvisionRedisWorkflowName})
+	w.RegisterWorkflowWithOptions(DeleteRedisWorkflow, workflow.RegisterOptions{Name: resources.DeleteRedisWorkflowName})
 
 	w.RegisterActivity(activities.CloneRepo)
 	w.RegisterActivity(activities.ResolveImageRef)
 	w.RegisterActivity(activities.ResolveBuildContext)
 	w.RegisterActivity(activities.ImageExists)
+	w.RegisterActivity(activities.ResolveImage)
 	w.RegisterActivity(activities.RailpackBuild)
 	w.RegisterActivity(activities.RailpackStaticBuild)
 	w.RegisterActivity(activities.DockerfileBuild)
@@ -20,10 +35,26 @@ func RegisterWorkflowsAndActivities(w worker.Worker, activities *Activities) {
 	w.RegisterActivity(activities.Deploy)
 	w.RegisterActivity(activities.WaitForRollout)
 	w.RegisterActivity(activities.DeleteService)
+	w.RegisterActivity(activities.DeleteNamespace)
 	w.RegisterActivity(activities.UpdateDeploymentBuilding)
 	w.RegisterActivity(activities.UpdateDeploymentDeploying)
 	w.RegisterActivity(activities.MarkDeploymentActive)
 	w.RegisterActivity(activities.MarkDeploymentFailed)
+	w.RegisterActivity(activities.MarkDeploymentCancelled)
+	w.RegisterActivity(activities.GetActiveDeployment)
 	w.RegisterActivity(activities.UpdateDeploymentBuildProgress)
 	w.RegisterActivity(activities.SoftDeleteService)
+	w.RegisterActivity(activities.ListSleepCandidates)
+	w.RegisterActivity(activities.SleepService)
+	w.RegisterActivity(activities.ScaleUpService)
+	w.RegisterActivity(activities.RestoreServiceRouting)
+	w.RegisterActivity(activities.DiagnoseService)
+	w.RegisterActivity(activities.ApplyPostgres)
+	w.RegisterActivity(activities.WaitForPostgres)
+	w.RegisterActivity(activities.MarkResourceStatus)
+	w.RegisterActivity(activities.DeletePostgres)
+	w.RegisterActivity(activities.RotatePostgresPassword)
+	w.RegisterActivity(activities.ApplyRedis)
+	w.RegisterActivity(activities.WaitForRedis)
+	w.RegisterActivity(activities.DeleteRedis)
 }
@@ -0,0 +1,390 @@
+package k8sdeployments
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/distribution/reference"
+)
+
+var registryManifestAccept = strings.Join([]string{
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+}, ", ")
+
+// errRegistryDenied means the registry refused the manifest request even
+// after authenticating, usually because the credentials are wrong or
+// missing for a private image.
+var errRegistryDenied = errors.New("registry denied access")
+
+// errInternalRegistry means an image reference, or an address a registry
+// sent the lookup to, points at the platform's own registry or another
+// host inside the cluster.
+var errInternalRegistry = errors.New("image registry is internal")
+
+// maxManifestSize bounds the manifest body read when a registry doesn't
+// send Docker-Content-Digest and the digest has to be computed.
+const maxManifestSize = 4 << 20
+
+// manifestLookup is the outcome of a manifest request. Digest is empty when
+// the manifest wasn't found.
+type manifestLookup struct {
+	Found  bool
+	Digest string
+}
+
+// lookupManifest checks a manifest URL (<base>/v2/<repo>/manifests/<ref>)
+// and returns its digest. It answers the registry's auth challenge, either
+// a Bearer token from the challenge's realm or Basic auth, using creds
+// when they're given and anonymously otherwise.
+func lookupManifest(ctx context.Context, client *http.Client, manifestURL string, creds *RegistryCredentials) (manifestLookup, error) {
+	resp, err := manifestRequest(ctx, client, http.MethodHead, manifestURL, "")
+	if err != nil {
+		return manifestLookup{}, err
+	}
+	resp.Body.Close()
+
+	authorization := ""
+	if resp.StatusCode == http.StatusUnauthorized {
+		authorization, err = registryAuthorization(ctx, client, resp.Header.Get("WWW-Authenticate"), creds)
+		if err != nil {
+			return manifestLookup{}, err
+		}
+		resp, err = manifestRequest(ctx, client, http.MethodHead, manifestURL, authorization)
+		if err != nil {
+			return manifestLookup{}, err
+		}
+		resp.Body.Close()
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		if digest := resp.Header.Get("Docker-Content-Digest"); digest != "" {
+			return manifestLookup{Found: true, Digest: digest}, nil
+		}
+		// No digest header; fetch the manifest and hash it.
+	case http.StatusNotFound:
+		return manifestLookup{}, nil
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return manifestLookup{}, errRegistryDenied
+	case http.StatusMethodNotAllowed:
+		// Fallback for registries that don't support HEAD.
+	default:
+		return manifestLookup{}, fmt.Errorf("registry HEAD manifest unexpected status: %d", resp.StatusCode)
+	}
+
+	getResp, err := manifestRequest(ctx, client, http.MethodGet, manifestURL, authorization)
+	if err != nil {
+		return manifestLookup{}, err
+	}
+	defer getResp.Body.Close()
+
+	switch getResp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return manifestLookup{}, nil
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return manifestLookup{}, errRegistryDenied
+	default:
+		return manifestLookup{}, fmt.Errorf("registry GET manifest unexpected status: %d", getResp.StatusCode)
+	}
+
+	if digest := getResp.Header.Get("Docker-Content-Digest"); digest != "" {
+		_, _ = io.Copy(io.Discard, getResp.Body)
+		return manifestLookup{Found: true, Digest: digest}, nil
+	}
+	body, err := io.ReadAll(io.LimitReader(getResp.Body, maxManifestSize))
+	if err != nil {
+		return manifestLookup{}, fmt.Errorf("read manifest: %w", err)
+	}
+	return manifestLookup{Found: true, Digest: fmt.Sprintf("sha256:%x", sha256.Sum256(body))}, nil
+}
+
+func manifestRequest(ctx context.Context, client *http.Client, method, manifestURL, authorization string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, manifestURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create %s request: %w", method, err)
+	}
+	req.Header.Set("Accept", registryManifestAccept)
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("registry %s manifest: %w", method, err)
+	}
+	return resp, nil
+}
+
+// registryAuthorization answers a WWW-Authenticate challenge with the
+// Authorization header to retry with.
+func registryAuthorization(ctx context.Context, client *http.Client, challenge string, creds *RegistryCredentials) (string, error) {
+	scheme, params := parseChallenge(challenge)
+	switch strings.ToLower(scheme) {
+	case "basic":
+		if creds == nil {
+			return "", errRegistryDenied
+		}
+		return "Basic " + basicAuth(creds.Username, creds.Password), nil
+	case "bearer":
+		token, err := fetchRegistryToken(ctx, client, params, creds)
+		if err != nil {
+			return "", err
+		}
+		return "Bearer " + token, nil
+	default:
+		return "", fmt.Errorf("unsupported registry auth challenge %q", challenge)
+	}
+}
+
+// fetchRegistryToken gets a pull token from a Bearer challenge's realm, as
+// Docker Hub, GHCR and most hosted registries require even for public
+// images.
+func fetchRegistryToken(ctx context.Context, client *http.Client, params map[string]string, creds *RegistryCredentials) (string, error) {
+	realm := params["realm"]
+	if realm == "" {
+		return "", fmt.Errorf("registry auth challenge has no realm")
+	}
+	tokenURL, err := url.Parse(realm)
+	if err != nil {
+		return "", fmt.Errorf("parse token realm %q: %w", realm, err)
+	}
+	query := tokenURL.Query()
+	for _, key := range []string{"service", "scope"} {
+		if v := params[key]; v != "" {
+			query.Set(key, v)
+		}
+	}
+	tokenURL.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, tokenURL.String(), nil)
+	if err != nil {
+		return "", fmt.Errorf("create token request: %w", err)
+	}
+	if creds != nil {
+		req.SetBasicAuth(creds.Username, creds.Password)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("registry token request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return "", errRegistryDenied
+	default:
+		return "", fmt.Errorf("registry token request unexpected status: %d", resp.StatusCode)
+	}
+
+	var body struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("decode registry token: %w", err)
+	}
+	if body.Token != "" {
+		return body.Token, nil
+	}
+	if body.AccessToken != "" {
+		return body.AccessToken, nil
+	}
+	return "", fmt.Errorf("registry token response has no token")
+}
+
+// parseChallenge splits a WWW-Authenticate header such as
+// `Bearer realm="https://auth.docker.io/token",service="registry.docker.io"`
+// into its scheme and parameters. Quoted values may contain commas.
+func parseChallenge(header string) (string, map[string]string) {
+	header = strings.TrimSpace(header)
+	scheme, rest, _ := strings.Cut(header, " ")
+	params := map[string]string{}
+	for rest = strings.TrimSpace(rest); rest != ""; rest = strings.TrimLeft(rest, ", ") {
+		key, after, ok := strings.Cut(rest, "=")
+		if !ok {
+			break
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		var value string
+		if strings.HasPrefix(after, `"`) {
+			end := strings.Index(after[1:], `"`)
+			if end < 0 {
+				value, rest = after[1:], ""
+			} else {
+				value, rest = after[1:end+1], after[end+2:]
+			}
+		} else {
+			value, rest, _ = strings.Cut(after, ",")
+			value = strings.TrimSpace(value)
+		}
+		params[key] = value
+	}
+	return scheme, params
+}
+
+func basicAuth(username, password string) string {
+	return base64.StdEncoding.EncodeToString([]byte(username + ":" + password))
+}
+
+// imageTarget is where an outside image reference's manifest lives.
+type imageTarget struct {
+	// Name is the fully qualified repository, e.g. docker.io/library/nginx.
+	Name string
+	// Domain is the registry as written in the reference, e.g. docker.io.
+	Domain string
+	// ManifestURL is the registry API URL of the referenced manifest.
+	ManifestURL string
+}
+
+// ValidateImageRef checks that an image-sourced service's image is a
+// reference a deploy can resolve. The API doesn't know the platform's
+// registry address, but that registry is on an internal host, which is
+// refused here; the worker checks the address itself when it resolves the
+// image.
+func ValidateImageRef(imageRef string) error {
+	if strings.TrimSpace(imageRef) == "" {
+		return fmt.Errorf("image is required")
+	}
+	_, err := resolveImageManifestTarget(imageRef, "")
+	return err
+}
+
+// resolveImageManifestTarget locates the manifest of an image reference as
+// a user gives it: Docker Hub short names are expanded, a missing tag means
+// latest, and a digest reference is looked up as is. References to the
+// platform's registry at registryAddress, or to any internal host, are
+// refused: they would pull another tenant's builds or reach into the
+// cluster.
+func resolveImageManifestTarget(imageRef, registryAddress string) (imageTarget, error) {
+	named, err := reference.ParseNormalizedNamed(strings.TrimSpace(imageRef))
+	if err != nil {
+		return imageTarget{}, fmt.Errorf("parse image reference %q: %w", imageRef, err)
+	}
+	if domain := reference.Domain(named); isInternalRegistry(domain, registryAddress) {
+		return imageTarget{}, fmt.Errorf("%w: %s can't be deployed from %s, use a public registry", errInternalRegistry, imageRef, domain)
+	}
+
+	var ref string
+	if digested, ok := named.(reference.Digested); ok {
+		ref = digested.Digest().String()
+	} else {
+		ref = reference.TagNameOnly(named).(reference.Tagged).Tag()
+	}
+
+	domain := reference.Domain(named)
+	host := domain
+	if host == "docker.io" {
+		host = "registry-1.docker.io"
+	}
+
+	return imageTarget{
+		Name:        named.Name(),
+		Domain:      domain,
+		ManifestURL: fmt.Sprintf("https://%s/v2/%s/manifests/%s", host, reference.Path(named), ref),
+	}, nil
+}
+
+// isInternalRegistry reports whether a reference's registry domain is the
+// platform's registry or a host only reachable from inside the cluster:
+// localhost, a private or loopback address, a name without a dot such as
+// registry:5000, or a .local, .internal or .svc name.
+func isInternalRegistry(domain, registryAddress string) bool {
+	host := registryHostname(domain)
+	if registryAddress != "" && host == registryHostname(registryAddress) {
+		return true
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		return !isPublicIP(ip)
+	}
+	if host == "localhost" || !strings.Contains(host, ".") {
+		return true
+	}
+	for _, suffix := range []string{".localhost", ".local", ".internal", ".svc"} {
+		if strings.HasSuffix(host, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// registryHostname is the lower-cased host of a registry domain, without
+// its port or IPv6 brackets.
+func registryHostname(domain string) string {
+	host := domain
+	if h, _, err := net.SplitHostPort(domain); err == nil {
+		host = h
+	}
+	return strings.ToLower(strings.Trim(host, "[]"))
+}
+
+func isPublicIP(ip net.IP) bool {
+	return !ip.IsLoopback() && !ip.IsPrivate() && !ip.IsUnspecified() &&
+		!ip.IsLinkLocalUnicast() && !ip.IsLinkLocalMulticast() && !ip.IsInterfaceLocalMulticast()
+}
+
+// newPublicRegistryClient is the HTTP client for outside registries. It
+// refuses to connect to a non-public address, so a registry name that
+// resolves into the cluster, or a token realm or redirect pointing there,
+// fails with errInternalRegistry.
+func newPublicRegistryClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: 10 * time.Second,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !isPublicIP(ip) {
+				return fmt.Errorf("%w: %s", errInternalRegistry, host)
+			}
+			return nil
+		},
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{Timeout: 15 * time.Second, Transport: transport}
+}
+
+// dockerConfigJSON is the .dockerconfigjson of an image pull secret that
+// logs in to the registry imageRef is in.
+func dockerConfigJSON(imageRef string, creds RegistryCredentials) ([]byte, error) {
+	named, err := reference.ParseNormalizedNamed(imageRef)
+	if err != nil {
+		return nil, fmt.Errorf("parse image reference %q: %w", imageRef, err)
+	}
+	server := reference.Domain(named)
+	if server == "docker.io" {
+		server = "https://index.docker.io/v1/"
+	}
+
+	type authEntry struct {
+		Username string `json:"username"`
+		Password string `json:"password"`
+		Auth     string `json:"auth"`
+	}
+	return json.Marshal(map[string]map[string]authEntry{
+		"auths": {
+			server: {
+				Username: creds.Username,
+				Password: creds.Password,
+				Auth:     basicAuth(creds.Username, creds.Password),
+			},
+		},
+	})
+}
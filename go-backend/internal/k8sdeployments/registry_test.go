@@ -0,0 +1,201 @@
+package k8sdeployments
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// newTokenRegistry serves one manifest behind a Bearer challenge, handing
+// out a token only to alice:secret.
+func newTokenRegistry(t *testing.T, sendDigest bool) (*httptest.Server, []byte) {
+	t.Helper()
+	manifest := []byte(`{"schemaVersion":2}`)
+	mux := http.NewServeMux()
+	var srv *httptest.Server
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if user, pass, ok := r.BasicAuth(); !ok || user != "alice" || pass != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Query().Get("scope") != "repository:acme/api:pull" {
+			t.Errorf("token scope = %q", r.URL.Query().Get("scope"))
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{"token": "tok"})
+	})
+	mux.HandleFunc("/v2/acme/api/manifests/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer tok" {
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="test",scope="repository:acme/api:pull"`, srv.URL))
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path != "/v2/acme/api/manifests/v1" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if sendDigest {
+			w.Header().Set("Docker-Content-Digest", "sha256:abc")
+		} else if r.Method == http.MethodHead {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		_, _ = w.Write(manifest)
+	})
+	srv = httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv, manifest
+}
+
+func TestLookupManifest_BearerChallenge(t *testing.T) {
+	srv, _ := newTokenRegistry(t, true)
+	creds := &RegistryCredentials{Username: "alice", Password: "secret"}
+
+	got, err := lookupManifest(context.Background(), srv.Client(), srv.URL+"/v2/acme/api/manifests/v1", creds)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.Found || got.Digest != "sha256:abc" {
+		t.Fatalf("lookupManifest() = %+v, want found with sha256:abc", got)
+	}
+
+	got, err = lookupManifest(context.Background(), srv.Client(), srv.URL+"/v2/acme/api/manifests/v2", creds)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Found {
+		t.Fatal("expected a missing tag not to be found")
+	}
+}
+
+func TestLookupManifest_WrongCredentialsDenied(t *testing.T) {
+	srv, _ := newTokenRegistry(t, true)
+
+	for _, creds := range []*RegistryCredentials{nil, {Username: "alice", Password: "wrong"}} {
+		_, err := lookupManifest(context.Background(), srv.Client(), srv.URL+"/v2/acme/api/manifests/v1", creds)
+		if !errors.Is(err, errRegistryDenied) {
+			t.Fatalf("lookupManifest(%+v) error = %v, want errRegistryDenied", creds, err)
+		}
+	}
+}
+
+func TestLookupManifest_HashesBodyWithoutDigestHeader(t *testing.T) {
+	srv, manifest := newTokenRegistry(t, false)
+	creds := &RegistryCredentials{Username: "alice", Password: "secret"}
+
+	got, err := lookupManifest(context.Background(), srv.Client(), srv.URL+"/v2/acme/api/manifests/v1", creds)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := fmt.Sprintf("sha256:%x", sha256.Sum256(manifest))
+	if !got.Found || got.Digest != want {
+		t.Fatalf("lookupManifest() = %+v, want found with %s", got, want)
+	}
+}
+
+func TestParseChallenge(t *testing.T) {
+	scheme, params := parseChallenge(`Bearer realm="https://auth.docker.io/token",service="registry.docker.io",scope="repository:library/nginx:pull,push"`)
+	if scheme != "Bearer" {
+		t.Fatalf("scheme = %q", scheme)
+	}
+	want := map[string]string{
+		"realm":   "https://auth.docker.io/token",
+		"service": "registry.docker.io",
+		"scope":   "repository:library/nginx:pull,push",
+	}
+	for k, v := range want {
+		if params[k] != v {
+			t.Errorf("params[%q] = %q, want %q", k, params[k], v)
+		}
+	}
+
+	scheme, params = parseChallenge(`Basic realm=registry`)
+	if scheme != "Basic" || params["realm"] != "registry" {
+		t.Fatalf("parseChallenge(Basic) = %q %v", scheme, params)
+	}
+}
+
+func TestResolveImageManifestTarget(t *testing.T) {
+	tests := []struct {
+		image, name, manifestURL string
+	}{
+		{"nginx", "docker.io/library/nginx", "https://registry-1.docker.io/v2/library/nginx/manifests/latest"},
+		{"acme/api:1.2", "docker.io/acme/api", "https://registry-1.docker.io/v2/acme/api/manifests/1.2"},
+		{"ghcr.io/acme/api:main", "ghcr.io/acme/api", "https://ghcr.io/v2/acme/api/manifests/main"},
+		{
+			"ghcr.io/acme/api@sha256:0000000000000000000000000000000000000000000000000000000000000000",
+			"ghcr.io/acme/api",
+			"https://ghcr.io/v2/acme/api/manifests/sha256:0000000000000000000000000000000000000000000000000000000000000000",
+		},
+	}
+	for _, tt := range tests {
+		got, err := resolveImageManifestTarget(tt.image, "registry.internal:5000")
+		if err != nil {
+			t.Fatalf("resolveImageManifestTarget(%q): %v", tt.image, err)
+		}
+		if got.Name != tt.name || got.ManifestURL != tt.manifestURL {
+			t.Errorf("resolveImageManifestTarget(%q) = %+v, want %s at %s", tt.image, got, tt.name, tt.manifestURL)
+		}
+	}
+
+	if err := ValidateImageRef("Not An Image"); err == nil {
+		t.Fatal("expected an invalid reference to be rejected")
+	}
+}
+
+func TestResolveImageManifestTarget_InternalRegistry(t *testing.T) {
+	for _, image := range []string{
+		"registry.example.com:5000/dp-user1-default/api:v1",
+		"REGISTRY.example.com/dp-user1-default/api",
+		"registry.internal:5000/dp-user1-default/api:v1",
+		"registry:5000/api",
+		"localhost:5000/api",
+		"127.0.0.1:5000/api",
+		"10.0.0.12/api",
+		"[::1]:5000/api",
+		"169.254.169.254/latest",
+		"registry.dp-system.svc/api",
+		"registry.dp-system.svc.cluster.local:5000/api",
+	} {
+		_, err := resolveImageManifestTarget(image, "registry.example.com:5000")
+		if !errors.Is(err, errInternalRegistry) {
+			t.Errorf("resolveImageManifestTarget(%q) error = %v, want errInternalRegistry", image, err)
+		}
+	}
+	if err := ValidateImageRef("registry.internal:5000/dp-user1-default/api:v1"); !errors.Is(err, errInternalRegistry) {
+		t.Errorf("ValidateImageRef(platform registry) error = %v, want errInternalRegistry", err)
+	}
+}
+
+func TestPublicRegistryClient_RefusesInternalAddress(t *testing.T) {
+	srv, _ := newTokenRegistry(t, true)
+
+	// The test registry listens on loopback, as a name resolving into the
+	// cluster would.
+	_, err := lookupManifest(context.Background(), newPublicRegistryClient(), srv.URL+"/v2/acme/api/manifests/v1", nil)
+	if !errors.Is(err, errInternalRegistry) {
+		t.Fatalf("lookupManifest() error = %v, want errInternalRegistry", err)
+	}
+}
+
+func TestDockerConfigJSON(t *testing.T) {
+	data, err := dockerConfigJSON("nginx:1.27", RegistryCredentials{Username: "alice", Password: "secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var cfg struct {
+		Auths map[string]struct {
+			Auth string `json:"auth"`
+		} `json:"auths"`
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		t.Fatal(err)
+	}
+	if got := cfg.Auths["https://index.docker.io/v1/"].Auth; got != "YWxpY2U6c2VjcmV0" {
+		t.Fatalf("docker hub auth = %q", got)
+	}
+}
@@ -0,0 +1,366 @@
+package k8sdeployments
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/augustdev/autoclip/internal/cron"
+	"github.com/augustdev/autoclip/internal/prometheus"
+	"go.temporal.io/sdk/client"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	// MinSleepAfter keeps the idle check longer than the 5m rate window it
+	// reads; MaxSleepAfter keeps the range query within Prometheus' limits.
+	MinSleepAfter = 5 * time.Minute
+	MaxSleepAfter = 7 * 24 * time.Hour
+
+	// idleReceiveBytesPerSec is the inbound rate a service has to stay under
+	// for its whole sleep_after window to count as idle. The TCP readiness
+	// probe alone accounts for roughly 150 B/s.
+	idleReceiveBytesPerSec = 512
+
+	// sleepFieldManager owns spec.replicas while a service sleeps or wakes;
+	// Deploy force-applies to take it back.
+	sleepFieldManager = "temporal-worker-sleep"
+
+	// sleepSchedule is how often idle services are checked.
+	sleepSchedule = "*/5 * * * *"
+)
+
+var ingressRouteGVR = schema.GroupVersionResource{
+	Group:    "traefik.io",
+	Version:  "v1alpha1",
+	Resource: "ingressroutes",
+}
+
+func wakeServiceName(name string) string {
+	return name + "-wake"
+}
+
+// ParseSleepAfter parses a sleep_after duration such as "30m" into seconds.
+// An empty or zero duration disables sleeping and returns nil.
+func ParseSleepAfter(s string) (*int32, error) {
+	s = strings.TrimSpace(s)
+	if s == "" || s == "0" {
+		return nil, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sleep_after %q: use a duration like 30m or 2h", s)
+	}
+	if d == 0 {
+		return nil, nil
+	}
+	if d < MinSleepAfter || d > MaxSleepAfter {
+		return nil, fmt.Errorf("invalid sleep_after %q: must be between 5m and 168h, or 0 to disable", s)
+	}
+	secs := int32(d / time.Second)
+	return &secs, nil
+}
+
+// ValidateSleepAfter checks a sleep_after given in seconds. Sleeping scales
+// the Deployment directly, which would fight an HPA, so the two are
+// exclusive.
+func ValidateSleepAfter(seconds *int32, autoscaled bool) error {
+	if seconds == nil {
+		return nil
+	}
+	d := time.Duration(*seconds) * time.Second
+	if d < MinSleepAfter || d > MaxSleepAfter {
+		return fmt.Errorf("invalid sleep_after %s: must be between 5m and 168h", d)
+	}
+	if autoscaled {
+		return fmt.Errorf("sleep_after can't be combined with autoscaling")
+	}
+	return nil
+}
+
+// sleepQueryStep keeps the idle query around 120 points whatever the window.
+func sleepQueryStep(window time.Duration) time.Duration {
+	step := (window / 120).Truncate(time.Second)
+	if step < time.Minute {
+		step = time.Minute
+	}
+	return step
+}
+
+// isIdle reports whether inbound traffic stayed under the idle threshold for
+// the whole window. A series that starts late, because the pods are younger
+// than the window or metrics went missing, doesn't count as idle.
+func isIdle(points []prometheus.DataPoint, windowStart time.Time, step time.Duration) bool {
+	if len(points) == 0 {
+		return false
+	}
+	if points[0].Timestamp.After(windowStart.Add(2 * step)) {
+		return false
+	}
+	for _, p := range points {
+		if p.Value >= idleReceiveBytesPerSec {
+			return false
+		}
+	}
+	return true
+}
+
+func (a *Activities) ListSleepCandidates(ctx context.Context, input SleepIdleServicesInput) ([]string, error) {
+	svcs, err := a.servicesQ.ListSleepCandidates(ctx, input.Region)
+	if err != nil {
+		return nil, fmt.Errorf("list sleep candidates: %w", err)
+	}
+	ids := make([]string, 0, len(svcs))
+	for _, svc := range svcs {
+		ids = append(ids, svc.ID)
+	}
+	return ids, nil
+}
+
+// SleepService scales a service to zero if it has been idle for its whole
+// sleep_after window, routing its hosts to the activator first so no request
+// lands on a Service without endpoints.
+func (a *Activities) SleepService(ctx context.Context, input SleepServiceInput) (*SleepServiceResult, error) {
+	id, err := a.resolveServiceIdentity(ctx, input.ServiceID)
+	if err != nil {
+		return nil, err
+	}
+	svc := id.Service
+	if svc.SleepAfter == nil || svc.SleptAt.Valid || svc.Autoscaling != nil {
+		return &SleepServiceResult{}, nil
+	}
+
+	window := time.Duration(*svc.SleepAfter) * time.Second
+	step := sleepQueryStep(window)
+	end := time.Now()
+	start := end.Add(-window)
+	points, err := a.prometheus.GetNetworkReceiveRate(ctx, id.Namespace, id.Name, start, end, fmt.Sprintf("%ds", int(step.Seconds())))
+	if err != nil {
+		return nil, fmt.Errorf("query network receive rate: %w", err)
+	}
+	if !isIdle(points, start, step) {
+		return &SleepServiceResult{}, nil
+	}
+
+	host, err := a.ingressHost(ctx, id.Namespace, id.Name)
+	if err != nil {
+		return nil, err
+	}
+	if err := a.applyWakeRouting(ctx, id.Namespace, id.Name, host); err != nil {
+		return nil, fmt.Errorf("route to activator: %w", err)
+	}
+	if err := a.scaleDeployment(ctx, id.Namespace, id.Name, 0); err != nil {
+		return nil, fmt.Errorf("scale to zero: %w", err)
+	}
+	if _, err := a.servicesQ.MarkServiceSleeping(ctx, svc.ID); err != nil {
+		return nil, fmt.Errorf("mark service sleeping: %w", err)
+	}
+
+	a.logger.Info("Service put to sleep",
+		"serviceID", svc.ID,
+		"namespace", id.Namespace,
+		"name", id.Name,
+		"idleFor", window)
+
+	return &SleepServiceResult{Slept: true}, nil
+}
+
+// ScaleUpService restores a sleeping service's replica count from its active
+// deployment. Routing stays on the activator until RestoreServiceRouting.
+func (a *Activities) ScaleUpService(ctx context.Context, input WakeServiceInput) (*WakeServiceResult, error) {
+	id, err := a.resolveServiceIdentity(ctx, input.ServiceID)
+	if err != nil {
+		return nil, err
+	}
+
+	replicas := id.Service.Replicas
+	if id.Service.CurrentDeploymentID != nil {
+		cfg, err := a.resolveDeployConfig(ctx, id.Service, *id.Service.CurrentDeploymentID)
+		if err != nil {
+			return nil, err
+		}
+		replicas = cfg.Replicas
+	}
+
+	k8sSvc, err := a.k8s.CoreV1().Services(id.Namespace).Get(ctx, id.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("get service: %w", err)
+	}
+	if len(k8sSvc.Spec.Ports) == 0 {
+		return nil, fmt.Errorf("service %s/%s has no ports", id.Namespace, id.Name)
+	}
+
+	if err := a.scaleDeployment(ctx, id.Namespace, id.Name, replicas); err != nil {
+		return nil, fmt.Errorf("scale up: %w", err)
+	}
+
+	a.logger.Info("Waking service",
+		"serviceID", input.ServiceID,
+		"namespace", id.Namespace,
+		"name", id.Name,
+		"replicas", replicas)
+
+	return &WakeServiceResult{
+		Namespace:      id.Namespace,
+		DeploymentName: id.Name,
+		Port:           k8sSvc.Spec.Ports[0].Port,
+	}, nil
+}
+
+// RestoreServiceRouting points a woken service's hosts back at its own
+// Service and clears its sleeping mark.
+func (a *Activities) RestoreServiceRouting(ctx context.Context, input RestoreServiceRoutingInput) error {
+	id, err := a.resolveServiceIdentity(ctx, input.ServiceID)
+	if err != nil {
+		return err
+	}
+	host, err := a.ingressHost(ctx, id.Namespace, id.Name)
+	if err != nil {
+		return err
+	}
+	if err := a.applyIngress(ctx, id.Namespace, id.Name, host, input.Port); err != nil {
+		return fmt.Errorf("apply ingress: %w", err)
+	}
+	return a.finishWake(ctx, id.Namespace, id.Name, input.ServiceID, input.Port)
+}
+
+// finishWake undoes the rest of applyWakeRouting once the service's own
+// Ingress is back. Deploy calls it too, since a deploy wakes a sleeping
+// service.
+func (a *Activities) finishWake(ctx context.Context, namespace, name, serviceID string, port int32) error {
+	if err := a.retargetSubdomainRoute(ctx, namespace, name, name, port); err != nil {
+		return err
+	}
+	err := a.k8s.CoreV1().Services(namespace).Delete(ctx, wakeServiceName(name), metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("delete wake service: %w", err)
+	}
+	if err := a.servicesQ.ClearServiceSleeping(ctx, serviceID); err != nil {
+		return fmt.Errorf("clear service sleeping: %w", err)
+	}
+	return nil
+}
+
+func (a *Activities) ingressHost(ctx context.Context, namespace, name string) (string, error) {
+	ingress, err := a.k8s.NetworkingV1().Ingresses(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("get ingress: %w", err)
+	}
+	if len(ingress.Spec.Rules) == 0 || ingress.Spec.Rules[0].Host == "" {
+		return "", fmt.Errorf("ingress %s/%s has no host", namespace, name)
+	}
+	return ingress.Spec.Rules[0].Host, nil
+}
+
+func (a *Activities) applyWakeRouting(ctx context.Context, namespace, name, host string) error {
+	wake := buildWakeService(namespace, name, a.config.ActivatorHost, a.config.ActivatorPort)
+	data, err := json.Marshal(wake)
+	if err != nil {
+		return fmt.Errorf("marshal wake service: %w", err)
+	}
+	_, err = a.k8s.CoreV1().Services(namespace).Patch(ctx, wake.Name,
+		types.ApplyPatchType, data,
+		metav1.PatchOptions{FieldManager: "temporal-worker"})
+	if err != nil {
+		return fmt.Errorf("apply wake service: %w", err)
+	}
+
+	ingress := buildIngress(namespace, name, host, a.config.ActivatorPort)
+	ingress.Spec.Rules[0].HTTP.Paths[0].Backend.Service.Name = wake.Name
+	data, err = json.Marshal(ingress)
+	if err != nil {
+		return fmt.Errorf("marshal ingress: %w", err)
+	}
+	_, err = a.k8s.NetworkingV1().Ingresses(namespace).Patch(ctx, name,
+		types.ApplyPatchType, data,
+		metav1.PatchOptions{FieldManager: "temporal-worker"})
+	if err != nil {
+		return fmt.Errorf("apply ingress: %w", err)
+	}
+
+	return a.retargetSubdomainRoute(ctx, namespace, name, wake.Name, a.config.ActivatorPort)
+}
+
+// retargetSubdomainRoute points the custom-domain IngressRoute, if the
+// service has one, at backend. The whole spec is re-applied under the field
+// manager the DNS worker uses so its later applies don't conflict.
+func (a *Activities) retargetSubdomainRoute(ctx context.Context, namespace, name, backend string, port int32) error {
+	routeName := name + "-dz"
+	existing, err := a.dynClient.Resource(ingressRouteGVR).Namespace(namespace).Get(ctx, routeName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("get ingressroute: %w", err)
+	}
+
+	spec, _ := existing.Object["spec"].(map[string]any)
+	routes, _ := spec["routes"].([]any)
+	for _, r := range routes {
+		if route, ok := r.(map[string]any); ok {
+			route["services"] = []any{
+				map[string]any{"name": backend, "port": int64(port)},
+			}
+		}
+	}
+
+	route := &unstructured.Unstructured{
+		Object: map[string]any{
+			"apiVersion": existing.GetAPIVersion(),
+			"kind":       existing.GetKind(),
+			"metadata": map[string]any{
+				"name":      routeName,
+				"namespace": namespace,
+			},
+			"spec": spec,
+		},
+	}
+	data, err := json.Marshal(route)
+	if err != nil {
+		return fmt.Errorf("marshal ingressroute: %w", err)
+	}
+	_, err = a.dynClient.Resource(ingressRouteGVR).Namespace(namespace).Patch(ctx, routeName,
+		types.ApplyPatchType, data,
+		metav1.PatchOptions{FieldManager: "temporal-worker"})
+	if err != nil {
+		return fmt.Errorf("apply ingressroute: %w", err)
+	}
+	return nil
+}
+
+// scaleDeployment applies only spec.replicas, under its own field manager so
+// the rest of the Deployment stays owned by Deploy.
+func (a *Activities) scaleDeployment(ctx context.Context, namespace, name string, replicas int32) error {
+	data, err := json.Marshal(map[string]any{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata": map[string]any{
+			"name":      name,
+			"namespace": namespace,
+		},
+		"spec": map[string]any{
+			"replicas": replicas,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("marshal scale: %w", err)
+	}
+	_, err = a.k8s.AppsV1().Deployments(namespace).Patch(ctx, name,
+		types.ApplyPatchType, data,
+		metav1.PatchOptions{FieldManager: sleepFieldManager, Force: ptr.To(true)})
+	return err
+}
+
+// StartSleepSchedule starts the region's SleepIdleServicesWorkflow cron on
+// the region's own queue.
+func StartSleepSchedule(ctx context.Context, c client.Client, region, taskQueue string) error {
+	return cron.Start(ctx, c, SleepIdleServicesWorkflowID(region), taskQueue, sleepSchedule,
+		SleepIdleServicesWorkflow, SleepIdleServicesInput{Region: region})
+}
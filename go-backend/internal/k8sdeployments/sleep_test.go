@@ -0,0 +1,80 @@
+package k8sdeployments
+
+import (
+	"testing"
+	"time"
+
+	"github.com/augustdev/autoclip/internal/prometheus"
+	"k8s.io/utils/ptr"
+)
+
+func TestParseSleepAfter(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    *int32
+		wantErr bool
+	}{
+		{"", nil, false},
+		{"0", nil, false},
+		{"0s", nil, false},
+		{"30m", ptr.To(int32(1800)), false},
+		{" 2h ", ptr.To(int32(7200)), false},
+		{"168h", ptr.To(int32(604800)), false},
+		{"4m", nil, true},
+		{"169h", nil, true},
+		{"soon", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseSleepAfter(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseSleepAfter(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+				t.Fatalf("ParseSleepAfter(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateSleepAfter_RejectsAutoscaling(t *testing.T) {
+	if err := ValidateSleepAfter(ptr.To(int32(1800)), true); err == nil {
+		t.Fatal("expected an error combining sleep_after with autoscaling")
+	}
+	if err := ValidateSleepAfter(nil, true); err != nil {
+		t.Fatalf("unset sleep_after should pass with autoscaling: %v", err)
+	}
+	if err := ValidateSleepAfter(ptr.To(int32(60)), false); err == nil {
+		t.Fatal("expected an error for sleep_after below the minimum")
+	}
+}
+
+func TestIsIdle(t *testing.T) {
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	step := time.Minute
+	series := func(offset time.Duration, values ...float64) []prometheus.DataPoint {
+		points := make([]prometheus.DataPoint, len(values))
+		for i, v := range values {
+			points[i] = prometheus.DataPoint{Timestamp: start.Add(offset + time.Duration(i)*step), Value: v}
+		}
+		return points
+	}
+
+	tests := []struct {
+		name   string
+		points []prometheus.DataPoint
+		want   bool
+	}{
+		{"no data", nil, false},
+		{"probe noise only", series(0, 150, 160, 140), true},
+		{"one busy point", series(0, 150, 4000, 140), false},
+		{"series starts late", series(5*time.Minute, 150, 150), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isIdle(tt.points, start, step); got != tt.want {
+				t.Fatalf("isIdle() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
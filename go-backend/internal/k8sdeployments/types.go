@@ -9,6 +9,10 @@ type DeployServiceInput struct {
 	InstallationID int64
 	CommitSHA      string
 	AppsDomain     string
+
+	// Image, when set, deploys this prebuilt image reference instead of
+	// building Repo. Its tag is resolved to a digest on every deploy.
+	Image string
 }
 
 type DeployServiceResult struct {
@@ -28,6 +32,19 @@ type (
 	RedeployServiceWorkflowResult = DeployServiceResult
 )
 
+type RollbackServiceWorkflowInput struct {
+	ServiceID          string
+	DeploymentID       string
+	SourceDeploymentID string
+	ImageRef           string
+	CommitSHA          string
+	AppsDomain         string
+
+	// Prebuilt marks an image-sourced service, whose image lives in an
+	// outside registry rather than the one builds push to.
+	Prebuilt bool
+}
+
 type DeleteServiceWorkflowInput struct {
 	ServiceID string
 	Namespace string
@@ -54,6 +71,7 @@ type BuildServiceWorkflowResult struct {
 	ImageRef  string
 	CommitSHA string
 	Port      string
+	Stack     *ComposeStack // dockercompose only; ImageRef is the public component's
 }
 
 type CloneRepoInput struct {
@@ -85,6 +103,33 @@ type ResolveImageRefResult struct {
 	ImageRef string
 }
 
+// Service source types. An upload service is built from source tarballs
+// sent to the git server; its git_provider is SourceUpload too, which is
+// what CloneRepo switches on.
+const (
+	SourceGit    = "git"
+	SourceImage  = "image"
+	SourceUpload = "upload"
+)
+
+// RegistryCredentials log in to the registry an image-sourced service
+// pulls from. They're stored encrypted on the service.
+type RegistryCredentials struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+type ResolveImageInput struct {
+	ServiceID string
+	Image     string
+}
+
+type ResolveImageResult struct {
+	// ImageRef is Image pinned to Digest (repository@sha256:...).
+	ImageRef string
+	Digest   string
+}
+
 type ResolveBuildContextResult struct {
 	BuildPack           string
 	ImageRef            string
@@ -97,6 +142,7 @@ type ResolveBuildContextResult struct {
 	DockerfilePath      string
 	BuildCommand        string
 	StartCommand        string
+	Stack               *ComposeStack // dockercompose only
 }
 
 type BuildImageInput struct {
@@ -110,6 +156,9 @@ type BuildImageInput struct {
 	DockerfilePath   string
 	BuildCommand     string
 	StartCommand     string
+	// CacheName keys the build cache when several images share Name's
+	// build log, as a compose stack's components do. Defaults to Name.
+	CacheName string
 }
 
 type BuildImageResult struct {
@@ -122,17 +171,29 @@ type DeployInput struct {
 	CommitSHA  string
 	AppsDomain string
 	Port       string // resolved port from build phase; empty = re-read from DB
+
+	// SnapshotDeploymentID, when set, deploys with that deployment's frozen
+	// config snapshot instead of the service's live config (rollbacks).
+	SnapshotDeploymentID string
+
+	// Stack is the freshly built compose stack; snapshots carry their own.
+	Stack *ComposeStack
 }
 
 type DeployResult struct {
 	Namespace      string
 	DeploymentName string
 	URL            string
+	Port           string
+	Stack          *ComposeStack
+	// Components are the Deployments of a stack's non-public components.
+	Components []string
 }
 
 type WaitForRolloutInput struct {
 	Namespace      string
 	DeploymentName string
+	Components     []string // further Deployments to wait for
 }
 
 type WaitForRolloutResult struct {
@@ -149,6 +210,10 @@ type DeleteServiceResult struct {
 	Status string
 }
 
+type DeleteNamespaceInput struct {
+	Namespace string
+}
+
 // Deployment-aware status activity inputs
 
 type UpdateDeploymentBuildingInput struct {
@@ -165,6 +230,8 @@ type MarkDeploymentActiveInput struct {
 	URL          string
 	CommitSHA    string
 	ImageRef     string
+	Port         string // resolved app port; backfills an empty snapshot port
+	Stack        *ComposeStack
 }
 
 type MarkDeploymentFailedInput struct {
@@ -172,8 +239,62 @@ type MarkDeploymentFailedInput struct {
 	ErrorMessage string
 }
 
+type MarkDeploymentCancelledInput struct {
+	DeploymentID string
+}
+
+// ActiveDeployment is the deployment a service currently serves. Empty when
+// the service has never gone live.
+type ActiveDeployment struct {
+	DeploymentID string
+	ImageRef     string
+	CommitSHA    string
+}
+
 type UpdateDeploymentBuildProgressInput struct {
 	DeploymentID  string
 	BuildProgress []byte
 }
 
+type SleepIdleServicesInput struct {
+	Region string
+}
+
+type SleepIdleServicesResult struct {
+	Checked int
+	Slept   int
+}
+
+type SleepServiceInput struct {
+	ServiceID string
+}
+
+type SleepServiceResult struct {
+	Slept bool
+}
+
+type MarkResourceStatusInput struct {
+	ResourceID string
+	Status     string
+}
+
+type WakeServiceInput struct {
+	ServiceID string
+}
+
+// WakeServiceResult locates the woken service inside the cluster so the
+// activator can forward the requests it held.
+type WakeServiceResult struct {
+	Namespace      string
+	DeploymentName string
+	Port           int32
+}
+
+type RestoreServiceRoutingInput struct {
+	ServiceID string
+	Port      int32
+}
+
+type DiagnoseServiceInput struct {
+	ServiceID string
+}
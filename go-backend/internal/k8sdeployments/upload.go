@@ -0,0 +1,148 @@
+package k8sdeployments
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"go.temporal.io/sdk/temporal"
+)
+
+// maxUnpackedUploadSize bounds what an uploaded source archive may expand
+// to, so a small gzip bomb can't fill the worker's disk.
+const maxUnpackedUploadSize = 2 << 30
+
+// fetchUpload downloads a service's uploaded source archive from the git
+// server and unpacks it into dir. The archive is named by its digest,
+// which the deploy carries as its commit SHA.
+func (a *Activities) fetchUpload(ctx context.Context, serviceID, digest, dir string) error {
+	if digest == "" {
+		return temporal.NewNonRetryableApplicationError("upload deploy has no archive digest", "upload_missing", nil)
+	}
+
+	url := fmt.Sprintf("http://%s/uploads/%s/%s.tar.gz", a.config.GitServerCloneHost, serviceID, digest)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("create upload request: %w", err)
+	}
+	req.SetBasicAuth("x-admin-token", a.config.GitServerAdminToken)
+
+	client := &http.Client{Timeout: 10 * time.Minute}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("download upload: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		msg := fmt.Sprintf("uploaded archive %s is no longer stored; upload the source again", digest)
+		return temporal.NewNonRetryableApplicationError(msg, "upload_missing", nil)
+	default:
+		return fmt.Errorf("download upload: unexpected status %d", resp.StatusCode)
+	}
+
+	if err := extractTarGz(resp.Body, dir, maxUnpackedUploadSize); err != nil {
+		return temporal.NewNonRetryableApplicationError(fmt.Sprintf("unpack upload: %v", err), "upload_invalid", err)
+	}
+	return nil
+}
+
+// extractTarGz unpacks a gzipped tarball into dir. Entries that would land
+// outside dir, links pointing out of it, and anything but regular files,
+// directories and symlinks are rejected. Everything is written through an
+// os.Root, so an entry can't escape through a symlink an earlier entry
+// left on disk either.
+func extractTarGz(r io.Reader, dir string, maxSize int64) error {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return fmt.Errorf("not a gzip archive: %w", err)
+	}
+	defer gz.Close()
+
+	root, err := os.OpenRoot(dir)
+	if err != nil {
+		return err
+	}
+	defer root.Close()
+
+	var total int64
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("read archive: %w", err)
+		}
+
+		name := filepath.Clean(filepath.FromSlash(strings.TrimPrefix(hdr.Name, "./")))
+		if name == "." {
+			continue
+		}
+		if !filepath.IsLocal(name) {
+			return fmt.Errorf("archive entry %q escapes the source directory", hdr.Name)
+		}
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := root.MkdirAll(name, 0o755); err != nil {
+				return archiveEntryError(hdr, err)
+			}
+		case tar.TypeReg:
+			total += hdr.Size
+			if total > maxSize {
+				return fmt.Errorf("archive expands to more than %d bytes", maxSize)
+			}
+			if err := writeArchiveFile(tr, root, name, hdr); err != nil {
+				return err
+			}
+		case tar.TypeSymlink:
+			linked := filepath.Join(filepath.Dir(name), hdr.Linkname)
+			if filepath.IsAbs(hdr.Linkname) || !filepath.IsLocal(linked) {
+				return fmt.Errorf("archive symlink %q points outside the source directory", hdr.Name)
+			}
+			if err := root.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+				return archiveEntryError(hdr, err)
+			}
+			if err := root.Symlink(hdr.Linkname, name); err != nil {
+				return archiveEntryError(hdr, err)
+			}
+		case tar.TypeXGlobalHeader:
+			// pax metadata, nothing to write
+		default:
+			return fmt.Errorf("archive entry %q has unsupported type %q", hdr.Name, hdr.Typeflag)
+		}
+	}
+}
+
+func writeArchiveFile(r io.Reader, root *os.Root, name string, hdr *tar.Header) error {
+	if err := root.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return archiveEntryError(hdr, err)
+	}
+	// Keep the executable bits (build scripts) but nothing beyond 0755.
+	f, err := root.OpenFile(name, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, os.FileMode(hdr.Mode)&0o755|0o644)
+	if err != nil {
+		return archiveEntryError(hdr, err)
+	}
+	if _, err := io.CopyN(f, r, hdr.Size); err != nil {
+		f.Close()
+		return fmt.Errorf("write %s: %w", hdr.Name, err)
+	}
+	return f.Close()
+}
+
+// archiveEntryError reports an entry the root refused, typically one whose
+// path leads out of the source directory through a symlink.
+func archiveEntryError(hdr *tar.Header, err error) error {
+	return fmt.Errorf("archive entry %q: %w", hdr.Name, err)
+}
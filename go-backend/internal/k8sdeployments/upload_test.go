@@ -0,0 +1,131 @@
+package k8sdeployments
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// tarGz builds a gzipped tarball from headers; regular files get body as
+// their content.
+func tarGz(t *testing.T, entries []*tar.Header, body string) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for _, hdr := range entries {
+		if hdr.Typeflag == tar.TypeReg {
+			hdr.Size = int64(len(body))
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatal(err)
+		}
+		if hdr.Typeflag == tar.TypeReg {
+			if _, err := tw.Write([]byte(body)); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return &buf
+}
+
+func TestExtractTarGz(t *testing.T) {
+	archive := tarGz(t, []*tar.Header{
+		{Name: "./", Typeflag: tar.TypeDir, Mode: 0o755},
+		{Name: "./package.json", Typeflag: tar.TypeReg, Mode: 0o644},
+		{Name: "./bin/start.sh", Typeflag: tar.TypeReg, Mode: 0o4777},
+		{Name: "./current", Typeflag: tar.TypeSymlink, Linkname: "bin/start.sh"},
+	}, "hello")
+
+	dir := t.TempDir()
+	if err := extractTarGz(archive, dir, 1<<20); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "package.json"))
+	if err != nil || string(data) != "hello" {
+		t.Fatalf("package.json = %q, %v", data, err)
+	}
+	info, err := os.Stat(filepath.Join(dir, "bin", "start.sh"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0o755 {
+		t.Errorf("start.sh mode = %v, want 0755", info.Mode().Perm())
+	}
+	if link, err := os.Readlink(filepath.Join(dir, "current")); err != nil || link != "bin/start.sh" {
+		t.Errorf("current -> %q, %v", link, err)
+	}
+}
+
+func TestExtractTarGz_RejectsEscapes(t *testing.T) {
+	tests := map[string]*tar.Header{
+		"parent path":      {Name: "../evil", Typeflag: tar.TypeReg, Mode: 0o644},
+		"absolute path":    {Name: "/etc/evil", Typeflag: tar.TypeReg, Mode: 0o644},
+		"escaping symlink": {Name: "link", Typeflag: tar.TypeSymlink, Linkname: "../../etc/passwd"},
+		"absolute symlink": {Name: "link", Typeflag: tar.TypeSymlink, Linkname: "/etc/passwd"},
+		"hard link":        {Name: "link", Typeflag: tar.TypeLink, Linkname: "package.json"},
+		"device":           {Name: "dev", Typeflag: tar.TypeChar},
+	}
+	for name, hdr := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := extractTarGz(tarGz(t, []*tar.Header{hdr}, "x"), t.TempDir(), 1<<20)
+			if err == nil {
+				t.Fatal("expected the entry to be rejected")
+			}
+		})
+	}
+}
+
+func TestExtractTarGz_RejectsEscapeThroughExtractedSymlinks(t *testing.T) {
+	// Each link looks local on its own; chained they lead out of dir.
+	archive := tarGz(t, []*tar.Header{
+		{Name: "d/e/a", Typeflag: tar.TypeSymlink, Linkname: "../.."},
+		{Name: "d/e/a/l", Typeflag: tar.TypeSymlink, Linkname: "../outside"},
+		{Name: "d/e/a/l/evil", Typeflag: tar.TypeReg, Mode: 0o644},
+	}, "x")
+
+	base := t.TempDir()
+	dir := filepath.Join(base, "src")
+	outside := filepath.Join(base, "outside")
+	for _, d := range []string{dir, outside} {
+		if err := os.Mkdir(d, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := extractTarGz(archive, dir, 1<<20); err == nil {
+		t.Fatal("expected the archive to be rejected")
+	}
+	if _, err := os.Lstat(filepath.Join(outside, "evil")); !os.IsNotExist(err) {
+		t.Fatalf("file written outside the source directory: %v", err)
+	}
+}
+
+func TestExtractTarGz_SizeLimit(t *testing.T) {
+	archive := tarGz(t, []*tar.Header{
+		{Name: "a", Typeflag: tar.TypeReg, Mode: 0o644},
+		{Name: "b", Typeflag: tar.TypeReg, Mode: 0o644},
+	}, strings.Repeat("x", 600))
+
+	err := extractTarGz(archive, t.TempDir(), 1000)
+	if err == nil || !strings.Contains(err.Error(), "more than 1000 bytes") {
+		t.Fatalf("extractTarGz() error = %v, want size limit error", err)
+	}
+}
+
+func TestExtractTarGz_NotGzip(t *testing.T) {
+	if err := extractTarGz(strings.NewReader("plain text"), t.TempDir(), 1<<20); err == nil {
+		t.Fatal("expected a non-gzip body to be rejected")
+	}
+}
@@ -3,16 +3,19 @@ package k8sdeployments
 import (
 	"errors"
 	"fmt"
+	"path"
 	"time"
 
+	"github.com/augustdev/autoclip/internal/resources"
 	"go.temporal.io/sdk/temporal"
 	"go.temporal.io/sdk/workflow"
 )
 
 const (
-	StatusRunning = "running"
-	StatusFailed  = "failed"
-	StatusDeleted = "deleted"
+	StatusRunning   = "running"
+	StatusFailed    = "failed"
+	StatusDeleted   = "deleted"
+	StatusCancelled = "cancelled"
 )
 
 func CreateServiceWorkflow(ctx workflow.Context, input CreateServiceWorkflowInput) (CreateServiceWorkflowResult, error) {
@@ -41,7 +44,20 @@ func deployService(ctx workflow.Context, input DeployServiceInput) (DeployServic
 		}).Get(ctx, nil)
 	}
 
+	rolloutStarted := false
+	cancelled := func(err error) (DeployServiceResult, error) {
+		compensateCancelledDeploy(ctx, input.ServiceID, input.DeploymentID, input.AppsDomain, rolloutStarted)
+		return DeployServiceResult{
+			ServiceID:    input.ServiceID,
+			Status:       StatusCancelled,
+			ErrorMessage: err.Error(),
+		}, err
+	}
+
 	fail := func(err error) (DeployServiceResult, error) {
+		if isCancellation(ctx, err) {
+			return cancelled(err)
+		}
 		markFailed(err.Error())
 		return DeployServiceResult{
 			ServiceID:    input.ServiceID,
@@ -54,6 +70,9 @@ func deployService(ctx workflow.Context, input DeployServiceInput) (DeployServic
 	if err := workflow.ExecuteActivity(statusCtx, activities.UpdateDeploymentBuilding, UpdateDeploymentBuildingInput{
 		DeploymentID: input.DeploymentID,
 	}).Get(ctx, nil); err != nil {
+		if isCancellation(ctx, err) {
+			return cancelled(err)
+		}
 		return DeployServiceResult{
 			ServiceID:    input.ServiceID,
 			Status:       StatusFailed,
@@ -61,22 +80,235 @@ func deployService(ctx workflow.Context, input DeployServiceInput) (DeployServic
 		}, fmt.Errorf("update deployment building: %w", err)
 	}
 
-	childCtx := workflow.WithChildOptions(ctx, workflow.ChildWorkflowOptions{
-		WorkflowID: fmt.Sprintf("build-%s-%s", input.ServiceID, input.CommitSHA),
-	})
 	var buildResult BuildServiceWorkflowResult
-	if err := workflow.ExecuteChildWorkflow(childCtx, BuildServiceWorkflow, BuildServiceWorkflowInput{
-		ServiceID:      input.ServiceID,
-		DeploymentID:   input.DeploymentID,
-		Repo:           input.Repo,
-		Branch:         input.Branch,
-		GitProvider:    input.GitProvider,
-		InstallationID: input.InstallationID,
-		CommitSHA:      input.CommitSHA,
-	}).Get(ctx, &buildResult); err != nil {
+	if input.Image != "" {
+		// A prebuilt image skips the build; its tag is pinned to the digest
+		// it points at now.
+		resolveCtx := workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+			StartToCloseTimeout: time.Minute,
+			RetryPolicy:         &temporal.RetryPolicy{MaximumAttempts: 3},
+		})
+		var resolved ResolveImageResult
+		if err := workflow.ExecuteActivity(resolveCtx, activities.ResolveImage, ResolveImageInput{
+			ServiceID: input.ServiceID,
+			Image:     input.Image,
+		}).Get(ctx, &resolved); err != nil {
+			return fail(fmt.Errorf("resolve image %s: %w", input.Image, err))
+		}
+		buildResult.ImageRef = resolved.ImageRef
+	} else {
+		childCtx := workflow.WithChildOptions(ctx, workflow.ChildWorkflowOptions{
+			WorkflowID: BuildWorkflowID(input.ServiceID, input.CommitSHA),
+		})
+		if err := workflow.ExecuteChildWorkflow(childCtx, BuildServiceWorkflow, BuildServiceWorkflowInput{
+			ServiceID:      input.ServiceID,
+			DeploymentID:   input.DeploymentID,
+			Repo:           input.Repo,
+			Branch:         input.Branch,
+			GitProvider:    input.GitProvider,
+			InstallationID: input.InstallationID,
+			CommitSHA:      input.CommitSHA,
+		}).Get(ctx, &buildResult); err != nil {
+			return fail(err)
+		}
+	}
+
+	rolloutStarted = true
+	deployResult, waitResult, err := rolloutImage(ctx, input.DeploymentID, DeployInput{
+		ServiceID:  input.ServiceID,
+		ImageRef:   buildResult.ImageRef,
+		CommitSHA:  buildResult.CommitSHA,
+		AppsDomain: input.AppsDomain,
+		Port:       buildResult.Port,
+		Stack:      buildResult.Stack,
+	})
+	if err != nil {
+		return fail(err)
+	}
+
+	// Mark deployment as active, supersede old, set pointer
+	if err := workflow.ExecuteActivity(statusCtx, activities.MarkDeploymentActive, MarkDeploymentActiveInput{
+		ServiceID:    input.ServiceID,
+		DeploymentID: input.DeploymentID,
+		URL:          deployResult.URL,
+		CommitSHA:    buildResult.CommitSHA,
+		ImageRef:     buildResult.ImageRef,
+		Port:         deployResult.Port,
+		Stack:        deployResult.Stack,
+	}).Get(ctx, nil); err != nil {
+		if isCancellation(ctx, err) {
+			return cancelled(err)
+		}
+		return DeployServiceResult{
+			ServiceID:    input.ServiceID,
+			Status:       StatusFailed,
+			ErrorMessage: fmt.Sprintf("mark deployment active: %v", err),
+		}, fmt.Errorf("mark deployment active: %w", err)
+	}
+
+	return DeployServiceResult{
+		ServiceID: input.ServiceID,
+		Status:    waitResult.Status,
+		URL:       deployResult.URL,
+		CommitSHA: buildResult.CommitSHA,
+	}, nil
+}
+
+// RollbackServiceWorkflow redeploys an image that was already built for an
+// earlier deployment, using that deployment's config snapshot. No build runs.
+func RollbackServiceWorkflow(ctx workflow.Context, input RollbackServiceWorkflowInput) (DeployServiceResult, error) {
+	logger := workflow.GetLogger(ctx)
+	logger.Info("Starting rollback", "serviceID", input.ServiceID, "deploymentID", input.DeploymentID,
+		"sourceDeploymentID", input.SourceDeploymentID, "imageRef", input.ImageRef)
+
+	var activities *Activities
+
+	statusCtx := workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: 10 * time.Second,
+		RetryPolicy:         &temporal.RetryPolicy{MaximumAttempts: 3},
+	})
+
+	rolloutStarted := false
+	fail := func(err error) (DeployServiceResult, error) {
+		if isCancellation(ctx, err) {
+			compensateCancelledDeploy(ctx, input.ServiceID, input.DeploymentID, input.AppsDomain, rolloutStarted)
+			return DeployServiceResult{
+				ServiceID:    input.ServiceID,
+				Status:       StatusCancelled,
+				ErrorMessage: err.Error(),
+			}, err
+		}
+		_ = workflow.ExecuteActivity(statusCtx, activities.MarkDeploymentFailed, MarkDeploymentFailedInput{
+			DeploymentID: input.DeploymentID,
+			ErrorMessage: err.Error(),
+		}).Get(ctx, nil)
+		return DeployServiceResult{
+			ServiceID:    input.ServiceID,
+			Status:       StatusFailed,
+			ErrorMessage: err.Error(),
+		}, err
+	}
+
+	checkCtx := workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: time.Minute,
+		RetryPolicy:         &temporal.RetryPolicy{MaximumAttempts: 3},
+	})
+	if input.Prebuilt {
+		// The image is in an outside registry; ResolveImage fails when the
+		// pinned digest is gone or no longer readable.
+		if err := workflow.ExecuteActivity(checkCtx, activities.ResolveImage, ResolveImageInput{
+			ServiceID: input.ServiceID,
+			Image:     input.ImageRef,
+		}).Get(ctx, nil); err != nil {
+			return fail(fmt.Errorf("check image %s: %w", input.ImageRef, err))
+		}
+	} else {
+		var exists bool
+		if err := workflow.ExecuteActivity(checkCtx, activities.ImageExists, input.ImageRef).Get(ctx, &exists); err != nil {
+			return fail(fmt.Errorf("check image %s: %w", input.ImageRef, err))
+		}
+		if !exists {
+			return fail(fmt.Errorf("image %s is no longer in the registry; redeploy from source instead", input.ImageRef))
+		}
+	}
+
+	rolloutStarted = true
+	deployResult, waitResult, err := rolloutImage(ctx, input.DeploymentID, DeployInput{
+		ServiceID:            input.ServiceID,
+		ImageRef:             input.ImageRef,
+		CommitSHA:            input.CommitSHA,
+		AppsDomain:           input.AppsDomain,
+		SnapshotDeploymentID: input.SourceDeploymentID,
+	})
+	if err != nil {
 		return fail(err)
 	}
 
+	if err := workflow.ExecuteActivity(statusCtx, activities.MarkDeploymentActive, MarkDeploymentActiveInput{
+		ServiceID:    input.ServiceID,
+		DeploymentID: input.DeploymentID,
+		URL:          deployResult.URL,
+		CommitSHA:    input.CommitSHA,
+		ImageRef:     input.ImageRef,
+		Port:         deployResult.Port,
+		Stack:        deployResult.Stack,
+	}).Get(ctx, nil); err != nil {
+		return DeployServiceResult{
+			ServiceID:    input.ServiceID,
+			Status:       StatusFailed,
+			ErrorMessage: fmt.Sprintf("mark deployment active: %v", err),
+		}, fmt.Errorf("mark deployment active: %w", err)
+	}
+
+	return DeployServiceResult{
+		ServiceID: input.ServiceID,
+		Status:    waitResult.Status,
+		URL:       deployResult.URL,
+		CommitSHA: input.CommitSHA,
+	}, nil
+}
+
+// isCancellation reports whether err comes from the workflow, or the build
+// child it waits on, being cancelled rather than from a real failure.
+func isCancellation(ctx workflow.Context, err error) bool {
+	return temporal.IsCanceledError(err) || errors.Is(ctx.Err(), workflow.ErrCanceled)
+}
+
+// compensateCancelledDeploy marks a cancelled deployment and, when the
+// rollout had already started, re-applies the service's active deployment so
+// the k8s Deployment doesn't stay pointed at the abandoned image. It runs on a
+// disconnected context because ctx is already cancelled.
+func compensateCancelledDeploy(ctx workflow.Context, serviceID, deploymentID, appsDomain string, rolloutStarted bool) {
+	logger := workflow.GetLogger(ctx)
+	var activities *Activities
+
+	cleanupCtx, _ := workflow.NewDisconnectedContext(ctx)
+	statusCtx := workflow.WithActivityOptions(cleanupCtx, workflow.ActivityOptions{
+		StartToCloseTimeout: 10 * time.Second,
+		RetryPolicy:         &temporal.RetryPolicy{MaximumAttempts: 3},
+	})
+
+	if rolloutStarted {
+		var active ActiveDeployment
+		if err := workflow.ExecuteActivity(statusCtx, activities.GetActiveDeployment, serviceID).Get(cleanupCtx, &active); err != nil {
+			logger.Error("Failed to look up active deployment; rollout left as is", "serviceID", serviceID, "error", err)
+		} else if active.DeploymentID == "" || active.ImageRef == "" || active.DeploymentID == deploymentID {
+			logger.Warn("No previous deployment to restore after cancelled rollout", "serviceID", serviceID)
+		} else {
+			deployCtx := workflow.WithActivityOptions(cleanupCtx, workflow.ActivityOptions{
+				StartToCloseTimeout: 5 * time.Minute,
+				HeartbeatTimeout:    30 * time.Second,
+				RetryPolicy:         &temporal.RetryPolicy{MaximumAttempts: 3},
+			})
+			if err := workflow.ExecuteActivity(deployCtx, activities.Deploy, DeployInput{
+				ServiceID:            serviceID,
+				ImageRef:             active.ImageRef,
+				CommitSHA:            active.CommitSHA,
+				AppsDomain:           appsDomain,
+				SnapshotDeploymentID: active.DeploymentID,
+			}).Get(cleanupCtx, nil); err != nil {
+				logger.Error("Failed to restore active deployment after cancelled rollout",
+					"serviceID", serviceID, "activeDeploymentID", active.DeploymentID, "error", err)
+			} else {
+				logger.Info("Restored active deployment after cancelled rollout",
+					"serviceID", serviceID, "activeDeploymentID", active.DeploymentID, "imageRef", active.ImageRef)
+			}
+		}
+	}
+
+	if err := workflow.ExecuteActivity(statusCtx, activities.MarkDeploymentCancelled, MarkDeploymentCancelledInput{
+		DeploymentID: deploymentID,
+	}).Get(cleanupCtx, nil); err != nil {
+		logger.Error("Failed to mark deployment cancelled", "deploymentID", deploymentID, "error", err)
+	}
+}
+
+// rolloutImage applies the k8s resources for an already-built image and waits
+// for the rollout, moving the deployment row to deploying in between.
+func rolloutImage(ctx workflow.Context, deploymentID string, deployInput DeployInput) (DeployResult, WaitForRolloutResult, error) {
+	logger := workflow.GetLogger(ctx)
+	var activities *Activities
+
 	actCtx := workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
 		StartToCloseTimeout: 5 * time.Minute,
 		HeartbeatTimeout:    30 * time.Second,
@@ -89,19 +321,18 @@ func deployService(ctx workflow.Context, input DeployServiceInput) (DeployServic
 	})
 
 	var deployResult DeployResult
-	if err := workflow.ExecuteActivity(actCtx, activities.Deploy, DeployInput{
-		ServiceID:  input.ServiceID,
-		ImageRef:   buildResult.ImageRef,
-		CommitSHA:  buildResult.CommitSHA,
-		AppsDomain: input.AppsDomain,
-		Port:       buildResult.Port,
-	}).Get(ctx, &deployResult); err != nil {
-		return fail(err)
+	if err := workflow.ExecuteActivity(actCtx, activities.Deploy, deployInput).Get(ctx, &deployResult); err != nil {
+		return DeployResult{}, WaitForRolloutResult{}, err
 	}
 
+	statusCtx := workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: 10 * time.Second,
+		RetryPolicy:         &temporal.RetryPolicy{MaximumAttempts: 3},
+	})
+
 	// Mark deployment as deploying (waiting for rollout)
 	if err := workflow.ExecuteActivity(statusCtx, activities.UpdateDeploymentDeploying, UpdateDeploymentDeployingInput{
-		DeploymentID: input.DeploymentID,
+		DeploymentID: deploymentID,
 	}).Get(ctx, nil); err != nil {
 		logger.Warn("Failed to mark deployment deploying", "error", err)
 	}
@@ -118,31 +349,12 @@ func deployService(ctx workflow.Context, input DeployServiceInput) (DeployServic
 	if err := workflow.ExecuteActivity(rolloutCtx, activities.WaitForRollout, WaitForRolloutInput{
 		Namespace:      deployResult.Namespace,
 		DeploymentName: deployResult.DeploymentName,
+		Components:     deployResult.Components,
 	}).Get(ctx, &waitResult); err != nil {
-		return fail(err)
+		return DeployResult{}, WaitForRolloutResult{}, err
 	}
 
-	// Mark deployment as active, supersede old, set pointer
-	if err := workflow.ExecuteActivity(statusCtx, activities.MarkDeploymentActive, MarkDeploymentActiveInput{
-		ServiceID:    input.ServiceID,
-		DeploymentID: input.DeploymentID,
-		URL:          deployResult.URL,
-		CommitSHA:    buildResult.CommitSHA,
-		ImageRef:     buildResult.ImageRef,
-	}).Get(ctx, nil); err != nil {
-		return DeployServiceResult{
-			ServiceID:    input.ServiceID,
-			Status:       StatusFailed,
-			ErrorMessage: fmt.Sprintf("mark deployment active: %v", err),
-		}, fmt.Errorf("mark deployment active: %w", err)
-	}
-
-	return DeployServiceResult{
-		ServiceID: input.ServiceID,
-		Status:    waitResult.Status,
-		URL:       deployResult.URL,
-		CommitSHA: buildResult.CommitSHA,
-	}, nil
+	return deployResult, waitResult, nil
 }
 
 func BuildServiceWorkflow(ctx workflow.Context, input BuildServiceWorkflowInput) (BuildServiceWorkflowResult, error) {
@@ -161,11 +373,18 @@ func BuildServiceWorkflow(ctx workflow.Context, input BuildServiceWorkflowInput)
 	})
 	var activities *Activities
 
+	// Cleanup runs on a disconnected context so a cancelled build still
+	// removes its clone from the worker.
 	cleanupSource := func(path string) {
 		if path == "" {
 			return
 		}
-		if err := workflow.ExecuteActivity(actCtx, activities.CleanupSource, path).Get(ctx, nil); err != nil {
+		cleanupCtx, _ := workflow.NewDisconnectedContext(ctx)
+		cleanupCtx = workflow.WithActivityOptions(cleanupCtx, workflow.ActivityOptions{
+			StartToCloseTimeout: time.Minute,
+			RetryPolicy:         &temporal.RetryPolicy{MaximumAttempts: 3},
+		})
+		if err := workflow.ExecuteActivity(cleanupCtx, activities.CleanupSource, path).Get(cleanupCtx, nil); err != nil {
 			logger.Warn("CleanupSource failed", "sourcePath", path, "error", err)
 		}
 	}
@@ -240,6 +459,23 @@ func BuildServiceWorkflow(ctx workflow.Context, input BuildServiceWorkflowInput)
 			return BuildServiceWorkflowResult{}, fmt.Errorf("resolve failed: %w", err)
 		}
 
+		if resolveResult.BuildPack == "dockercompose" {
+			err = buildComposeStack(ctx, actCtx, resolveResult)
+			cleanupSource(cloneResult.SourcePath)
+			if err != nil {
+				if isSourcePathMissing(err) && attempt < 2 {
+					continue
+				}
+				return BuildServiceWorkflowResult{}, fmt.Errorf("build failed (dockercompose): %w", err)
+			}
+			return BuildServiceWorkflowResult{
+				ImageRef:  resolveResult.ImageRef,
+				CommitSHA: cloneResult.CommitSHA,
+				Port:      resolveResult.Port,
+				Stack:     resolveResult.Stack,
+			}, nil
+		}
+
 		var imageExists bool
 		err = workflow.ExecuteActivity(actCtx, activities.ImageExists, resolveResult.ImageRef).Get(ctx, &imageExists)
 		if err != nil {
@@ -302,6 +538,53 @@ func BuildServiceWorkflow(ctx workflow.Context, input BuildServiceWorkflowInput)
 	return BuildServiceWorkflowResult{}, fmt.Errorf("build failed: source path missing after re-clone")
 }
 
+// buildComposeStack builds every component of a stack that has a build
+// section, in parallel, skipping images already pushed for this commit.
+// Components that run a published image need no build.
+func buildComposeStack(ctx, actCtx workflow.Context, resolved ResolveBuildContextResult) error {
+	logger := workflow.GetLogger(ctx)
+	var activities *Activities
+
+	type pendingBuild struct {
+		component string
+		future    workflow.Future
+	}
+	var builds []pendingBuild
+	for _, c := range resolved.Stack.Components {
+		if c.Build == nil {
+			continue
+		}
+		var exists bool
+		if err := workflow.ExecuteActivity(actCtx, activities.ImageExists, c.Image).Get(ctx, &exists); err != nil {
+			logger.Warn("ImageExists check failed; building component", "component", c.Name, "imageRef", c.Image, "error", err)
+		} else if exists {
+			logger.Info("Skipping component build; image already exists", "component", c.Name, "imageRef", c.Image)
+			continue
+		}
+		builds = append(builds, pendingBuild{
+			component: c.Name,
+			future: workflow.ExecuteActivity(actCtx, activities.DockerfileBuild, BuildImageInput{
+				SourcePath:     path.Join(resolved.EffectiveSourcePath, c.Build.Context),
+				ImageRef:       c.Image,
+				BuildPack:      "dockerfile",
+				Name:           resolved.Name,
+				Namespace:      resolved.Namespace,
+				EnvVars:        resolved.EnvVars,
+				DockerfilePath: c.Build.Dockerfile,
+				CacheName:      ComposeComponentName(resolved.Name, c.Name),
+			}),
+		})
+	}
+
+	var firstErr error
+	for _, b := range builds {
+		if err := b.future.Get(ctx, nil); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("compose service %s: %w", b.component, err)
+		}
+	}
+	return firstErr
+}
+
 func isSourcePathMissing(err error) bool {
 	var appErr *temporal.ApplicationError
 	return errors.As(err, &appErr) && appErr.Type() == "source_path_missing"
@@ -345,3 +628,245 @@ func DeleteServiceWorkflow(ctx workflow.Context, input DeleteServiceWorkflowInpu
 		Status:    StatusDeleted,
 	}, nil
 }
+
+// DeleteNamespaceWorkflow removes a namespace from the cluster whose queue it
+// runs on. Deleting a namespace that doesn't exist there succeeds.
+func DeleteNamespaceWorkflow(ctx workflow.Context, input DeleteNamespaceInput) error {
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: 2 * time.Minute,
+		RetryPolicy: &temporal.RetryPolicy{
+			InitialInterval:    time.Second,
+			BackoffCoefficient: 2.0,
+			MaximumInterval:    30 * time.Second,
+			MaximumAttempts:    3,
+		},
+	})
+
+	var activities *Activities
+	return workflow.ExecuteActivity(ctx, activities.DeleteNamespace, input).Get(ctx, nil)
+}
+
+// SleepIdleServicesWorkflow runs on a cron schedule per region and scales
+// services with sleep_after set to zero once they've been idle that long. A
+// service that fails to sleep is skipped until the next run.
+func SleepIdleServicesWorkflow(ctx workflow.Context, input SleepIdleServicesInput) (SleepIdleServicesResult, error) {
+	logger := workflow.GetLogger(ctx)
+
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: time.Minute,
+		RetryPolicy:         &temporal.RetryPolicy{MaximumAttempts: 3},
+	})
+
+	var activities *Activities
+	var serviceIDs []string
+	if err := workflow.ExecuteActivity(ctx, activities.ListSleepCandidates, input).Get(ctx, &serviceIDs); err != nil {
+		return SleepIdleServicesResult{}, err
+	}
+
+	result := SleepIdleServicesResult{Checked: len(serviceIDs)}
+	for _, serviceID := range serviceIDs {
+		var sleepResult SleepServiceResult
+		if err := workflow.ExecuteActivity(ctx, activities.SleepService, SleepServiceInput{
+			ServiceID: serviceID,
+		}).Get(ctx, &sleepResult); err != nil {
+			logger.Warn("Failed to sleep service", "serviceID", serviceID, "error", err)
+			continue
+		}
+		if sleepResult.Slept {
+			result.Slept++
+		}
+	}
+
+	logger.Info("Checked idle services", "region", input.Region, "checked", result.Checked, "slept", result.Slept)
+	return result, nil
+}
+
+// WakeServiceWorkflow scales a sleeping service back up and routes its hosts
+// back to it once it is ready. The activator waits on the result before
+// forwarding the requests it held.
+func WakeServiceWorkflow(ctx workflow.Context, input WakeServiceInput) (WakeServiceResult, error) {
+	logger := workflow.GetLogger(ctx)
+	logger.Info("Starting wake", "serviceID", input.ServiceID)
+
+	var activities *Activities
+
+	actCtx := workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: 30 * time.Second,
+		RetryPolicy:         &temporal.RetryPolicy{MaximumAttempts: 3},
+	})
+
+	var result WakeServiceResult
+	if err := workflow.ExecuteActivity(actCtx, activities.ScaleUpService, input).Get(ctx, &result); err != nil {
+		return WakeServiceResult{}, err
+	}
+
+	rolloutCtx := workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: 3 * time.Minute,
+		HeartbeatTimeout:    30 * time.Second,
+		RetryPolicy: &temporal.RetryPolicy{
+			MaximumAttempts: 3,
+		},
+	})
+
+	if err := workflow.ExecuteActivity(rolloutCtx, activities.WaitForRollout, WaitForRolloutInput{
+		Namespace:      result.Namespace,
+		DeploymentName: result.DeploymentName,
+	}).Get(ctx, nil); err != nil {
+		return WakeServiceResult{}, err
+	}
+
+	if err := workflow.ExecuteActivity(actCtx, activities.RestoreServiceRouting, RestoreServiceRoutingInput{
+		ServiceID: input.ServiceID,
+		Port:      result.Port,
+	}).Get(ctx, nil); err != nil {
+		return WakeServiceResult{}, err
+	}
+
+	return result, nil
+}
+
+// DiagnoseServiceWorkflow reads the state of a service's pods from the
+// cluster so the API server, which has no cluster access, can report it.
+func DiagnoseServiceWorkflow(ctx workflow.Context, input DiagnoseServiceInput) (ServiceHealth, error) {
+	var activities *Activities
+
+	actCtx := workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: 15 * time.Second,
+		RetryPolicy:         &temporal.RetryPolicy{MaximumAttempts: 2},
+	})
+
+	var health ServiceHealth
+	if err := workflow.ExecuteActivity(actCtx, activities.DiagnoseService, input).Get(ctx, &health); err != nil {
+		return ServiceHealth{}, err
+	}
+	return health, nil
+}
+
+// ProvisionPostgresWorkflow brings up a postgres resource and marks it
+// active once it accepts connections, or failed if it doesn't.
+func ProvisionPostgresWorkflow(ctx workflow.Context, input resources.PostgresWorkflowInput) error {
+	logger := workflow.GetLogger(ctx)
+	logger.Info("Starting postgres provisioning", "resourceID", input.ResourceID, "name", input.Name)
+
+	var activities *Activities
+
+	applyCtx := workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: time.Minute,
+		RetryPolicy:         &temporal.RetryPolicy{MaximumAttempts: 3},
+	})
+	waitCtx := workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: 10 * time.Minute,
+		HeartbeatTimeout:    30 * time.Second,
+		RetryPolicy:         &temporal.RetryPolicy{MaximumAttempts: 1},
+	})
+	statusCtx := workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: 10 * time.Second,
+		RetryPolicy:         &temporal.RetryPolicy{MaximumAttempts: 3},
+	})
+
+	err := workflow.ExecuteActivity(applyCtx, activities.ApplyPostgres, input).Get(ctx, nil)
+	if err == nil {
+		err = workflow.ExecuteActivity(waitCtx, activities.WaitForPostgres, input).Get(ctx, nil)
+	}
+
+	status := resources.StatusActive
+	if err != nil {
+		logger.Error("Postgres provisioning failed", "resourceID", input.ResourceID, "error", err)
+		status = resources.StatusFailed
+	}
+	if statusErr := workflow.ExecuteActivity(statusCtx, activities.MarkResourceStatus, MarkResourceStatusInput{
+		ResourceID: input.ResourceID,
+		Status:     status,
+	}).Get(ctx, nil); statusErr != nil && err == nil {
+		err = statusErr
+	}
+	return err
+}
+
+// DeletePostgresWorkflow tears down a postgres resource whose record the
+// API server has already removed.
+func DeletePostgresWorkflow(ctx workflow.Context, input resources.PostgresWorkflowInput) error {
+	var activities *Activities
+
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: 5 * time.Minute,
+		RetryPolicy: &temporal.RetryPolicy{
+			InitialInterval:    time.Second,
+			BackoffCoefficient: 2.0,
+			MaximumInterval:    30 * time.Second,
+			MaximumAttempts:    5,
+		},
+	})
+	return workflow.ExecuteActivity(ctx, activities.DeletePostgres, input).Get(ctx, nil)
+}
+
+// RotatePostgresPasswordWorkflow replaces the password of a postgres
+// resource. The API server waits on it to hand back the new connection
+// string.
+func RotatePostgresPasswordWorkflow(ctx workflow.Context, input resources.PostgresWorkflowInput) error {
+	var activities *Activities
+
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: time.Minute,
+		RetryPolicy:         &temporal.RetryPolicy{MaximumAttempts: 3},
+	})
+	return workflow.ExecuteActivity(ctx, activities.RotatePostgresPassword, input).Get(ctx, nil)
+}
+
+// ProvisionRedisWorkflow brings up a redis resource and marks it active
+// once it answers, or failed if it doesn't.
+func ProvisionRedisWorkflow(ctx workflow.Context, input resources.RedisWorkflowInput) error {
+	logger := workflow.GetLogger(ctx)
+	logger.Info("Starting redis provisioning", "resourceID", input.ResourceID, "name", input.Name)
+
+	var activities *Activities
+
+	applyCtx := workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: time.Minute,
+		RetryPolicy:         &temporal.RetryPolicy{MaximumAttempts: 3},
+	})
+	waitCtx := workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: 10 * time.Minute,
+		HeartbeatTimeout:    30 * time.Second,
+		RetryPolicy:         &temporal.RetryPolicy{MaximumAttempts: 1},
+	})
+	statusCtx := workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: 10 * time.Second,
+		RetryPolicy:         &temporal.RetryPolicy{MaximumAttempts: 3},
+	})
+
+	err := workflow.ExecuteActivity(applyCtx, activities.ApplyRedis, input).Get(ctx, nil)
+	if err == nil {
+		err = workflow.ExecuteActivity(waitCtx, activities.WaitForRedis, input).Get(ctx, nil)
+	}
+
+	status := resources.StatusActive
+	if err != nil {
+		logger.Error("Redis provisioning failed", "resourceID", input.ResourceID, "error", err)
+		status = resources.StatusFailed
+	}
+	if statusErr := workflow.ExecuteActivity(statusCtx, activities.MarkResourceStatus, MarkResourceStatusInput{
+		ResourceID: input.ResourceID,
+		Status:     status,
+	}).Get(ctx, nil); statusErr != nil && err == nil {
+		err = statusErr
+	}
+	return err
+}
+
+// DeleteRedisWorkflow tears down a redis resource whose record the API
+// server has already removed.
+func DeleteRedisWorkflow(ctx workflow.Context, input resources.RedisWorkflowInput) error {
+	var activities *Activities
+
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: 5 * time.Minute,
+		RetryPolicy: &temporal.RetryPolicy{
+			InitialInterval:    time.Second,
+			BackoffCoefficient: 2.0,
+			MaximumInterval:    30 * time.Second,
+			MaximumAttempts:    5,
+		},
+	})
+	return workflow.ExecuteActivity(ctx, activities.DeleteRedis, input).Get(ctx, nil)
+}
@@ -0,0 +1,169 @@
+package k8sdeployments
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/testsuite"
+)
+
+const (
+	testServiceID    = "svc-1"
+	testDeploymentID = "dep-new"
+)
+
+func newDeployTestEnv(t *testing.T) (*testsuite.TestWorkflowEnvironment, *Activities) {
+	t.Helper()
+	var suite testsuite.WorkflowTestSuite
+	env := suite.NewTestWorkflowEnvironment()
+	a := &Activities{}
+	env.RegisterActivity(a)
+	env.RegisterWorkflow(BuildServiceWorkflow)
+
+	env.OnActivity(a.UpdateDeploymentBuilding, mock.Anything, mock.Anything).Return(nil).Maybe()
+	env.OnActivity(a.UpdateDeploymentDeploying, mock.Anything, mock.Anything).Return(nil).Maybe()
+	env.OnActivity(a.MarkDeploymentCancelled, mock.Anything, MarkDeploymentCancelledInput{DeploymentID: testDeploymentID}).Return(nil).Once()
+	t.Cleanup(func() { env.AssertExpectations(t) })
+	return env, a
+}
+
+func deployTestInput() DeployServiceInput {
+	return DeployServiceInput{
+		ServiceID:    testServiceID,
+		DeploymentID: testDeploymentID,
+		Repo:         "user/app",
+		Branch:       "main",
+		CommitSHA:    "abc123",
+		AppsDomain:   "apps.example.com",
+	}
+}
+
+func buildResult() BuildServiceWorkflowResult {
+	return BuildServiceWorkflowResult{ImageRef: "registry/app:abc123", CommitSHA: "abc123", Port: "3000"}
+}
+
+func newDeployResult() *DeployResult {
+	return &DeployResult{Namespace: "ns", DeploymentName: "app", URL: "https://app.apps.example.com", Port: "3000"}
+}
+
+func requireCancelled(t *testing.T, env *testsuite.TestWorkflowEnvironment) {
+	t.Helper()
+	if !env.IsWorkflowCompleted() {
+		t.Fatal("workflow did not complete")
+	}
+	err := env.GetWorkflowError()
+	if !temporal.IsCanceledError(err) {
+		t.Fatalf("expected cancelled error, got %v", err)
+	}
+}
+
+func TestDeployService_CancelDuringBuild(t *testing.T) {
+	env, a := newDeployTestEnv(t)
+
+	env.OnWorkflow(BuildServiceWorkflow, mock.Anything, mock.Anything).
+		After(10*time.Minute).Return(buildResult(), nil)
+	env.OnActivity(a.MarkDeploymentFailed, mock.Anything, mock.Anything).Return(nil).Never()
+	env.OnActivity(a.Deploy, mock.Anything, mock.Anything).Return(newDeployResult(), nil).Never()
+	env.OnActivity(a.GetActiveDeployment, mock.Anything, mock.Anything).Return(ActiveDeployment{}, nil).Never()
+
+	env.RegisterDelayedCallback(env.CancelWorkflow, time.Minute)
+	env.ExecuteWorkflow(RedeployServiceWorkflow, deployTestInput())
+
+	requireCancelled(t, env)
+}
+
+func TestDeployService_CancelDuringRolloutRestoresActive(t *testing.T) {
+	env, a := newDeployTestEnv(t)
+
+	env.OnWorkflow(BuildServiceWorkflow, mock.Anything, mock.Anything).Return(buildResult(), nil)
+	env.OnActivity(a.Deploy, mock.Anything, mock.MatchedBy(func(in DeployInput) bool {
+		return in.ImageRef == "registry/app:abc123"
+	})).Return(newDeployResult(), nil).Once()
+	env.OnActivity(a.WaitForRollout, mock.Anything, mock.Anything).
+		After(10*time.Minute).Return(&WaitForRolloutResult{Status: StatusRunning}, nil)
+	env.OnActivity(a.GetActiveDeployment, mock.Anything, testServiceID).Return(ActiveDeployment{
+		DeploymentID: "dep-old",
+		ImageRef:     "registry/app:old",
+		CommitSHA:    "old",
+	}, nil).Once()
+	env.OnActivity(a.Deploy, mock.Anything, DeployInput{
+		ServiceID:            testServiceID,
+		ImageRef:             "registry/app:old",
+		CommitSHA:            "old",
+		AppsDomain:           "apps.example.com",
+		SnapshotDeploymentID: "dep-old",
+	}).Return(newDeployResult(), nil).Once()
+	env.OnActivity(a.MarkDeploymentFailed, mock.Anything, mock.Anything).Return(nil).Never()
+	env.OnActivity(a.MarkDeploymentActive, mock.Anything, mock.Anything).Return(nil).Never()
+
+	env.RegisterDelayedCallback(env.CancelWorkflow, time.Minute)
+	env.ExecuteWorkflow(RedeployServiceWorkflow, deployTestInput())
+
+	requireCancelled(t, env)
+}
+
+func TestDeployService_CancelDuringFirstRolloutHasNothingToRestore(t *testing.T) {
+	env, a := newDeployTestEnv(t)
+
+	env.OnWorkflow(BuildServiceWorkflow, mock.Anything, mock.Anything).Return(buildResult(), nil)
+	env.OnActivity(a.Deploy, mock.Anything, mock.Anything).Return(newDeployResult(), nil).Once()
+	env.OnActivity(a.WaitForRollout, mock.Anything, mock.Anything).
+		After(10*time.Minute).Return(&WaitForRolloutResult{Status: StatusRunning}, nil)
+	env.OnActivity(a.GetActiveDeployment, mock.Anything, testServiceID).Return(ActiveDeployment{}, nil).Once()
+	env.OnActivity(a.MarkDeploymentFailed, mock.Anything, mock.Anything).Return(nil).Never()
+
+	env.RegisterDelayedCallback(env.CancelWorkflow, time.Minute)
+	env.ExecuteWorkflow(CreateServiceWorkflow, deployTestInput())
+
+	requireCancelled(t, env)
+}
+
+func TestDeployService_CancelBeforeBuildStarts(t *testing.T) {
+	env, a := newDeployTestEnv(t)
+
+	env.OnWorkflow(BuildServiceWorkflow, mock.Anything, mock.Anything).Return(buildResult(), nil).Never()
+	env.OnActivity(a.MarkDeploymentFailed, mock.Anything, mock.Anything).Return(nil).Never()
+
+	env.RegisterDelayedCallback(env.CancelWorkflow, 0)
+	env.ExecuteWorkflow(RedeployServiceWorkflow, deployTestInput())
+
+	requireCancelled(t, env)
+}
+
+func TestDeployService_ImageSkipsBuild(t *testing.T) {
+	var suite testsuite.WorkflowTestSuite
+	env := suite.NewTestWorkflowEnvironment()
+	a := &Activities{}
+	env.RegisterActivity(a)
+	env.RegisterWorkflow(BuildServiceWorkflow)
+	t.Cleanup(func() { env.AssertExpectations(t) })
+
+	const pinned = "docker.io/library/nginx@sha256:0123"
+	env.OnActivity(a.UpdateDeploymentBuilding, mock.Anything, mock.Anything).Return(nil).Once()
+	env.OnActivity(a.UpdateDeploymentDeploying, mock.Anything, mock.Anything).Return(nil).Once()
+	env.OnWorkflow(BuildServiceWorkflow, mock.Anything, mock.Anything).Return(buildResult(), nil).Never()
+	env.OnActivity(a.ResolveImage, mock.Anything, ResolveImageInput{ServiceID: testServiceID, Image: "nginx:1.27"}).
+		Return(&ResolveImageResult{ImageRef: pinned, Digest: "sha256:0123"}, nil).Once()
+	env.OnActivity(a.Deploy, mock.Anything, DeployInput{
+		ServiceID:  testServiceID,
+		ImageRef:   pinned,
+		AppsDomain: "apps.example.com",
+	}).Return(newDeployResult(), nil).Once()
+	env.OnActivity(a.WaitForRollout, mock.Anything, mock.Anything).Return(&WaitForRolloutResult{Status: StatusRunning}, nil).Once()
+	env.OnActivity(a.MarkDeploymentActive, mock.Anything, mock.MatchedBy(func(in MarkDeploymentActiveInput) bool {
+		return in.ImageRef == pinned
+	})).Return(nil).Once()
+
+	env.ExecuteWorkflow(RedeployServiceWorkflow, DeployServiceInput{
+		ServiceID:    testServiceID,
+		DeploymentID: testDeploymentID,
+		AppsDomain:   "apps.example.com",
+		Image:        "nginx:1.27",
+	})
+
+	if err := env.GetWorkflowError(); err != nil {
+		t.Fatalf("workflow failed: %v", err)
+	}
+}
@@ -83,7 +83,7 @@ func (s *Server) registerTools() {
 
 	mcp.AddTool(s.mcpServer, &mcp.Tool{
 		Name:        "create_service",
-		Description: "Create and deploy a service. Use host='ml.ink' (default) for private repos or host='github.com' for GitHub.",
+		Description: "Create and deploy a service. Use host='ml.ink' (default) for private repos or host='github.com' for GitHub. Set image instead of repo to deploy a prebuilt image without a build, or upload=true to deploy source tarballs uploaded with the returned command.",
 		InputSchema: schemaFor[CreateServiceInput](),
 	}, s.handleCreateService)
 
@@ -93,39 +93,123 @@ func (s *Server) registerTools() {
 		InputSchema: schemaFor[RedeployServiceInput](),
 	}, s.handleRedeployService)
 
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name:        "update_service",
+		Description: "Update an existing service's config (env vars, resources, port, branch, build settings) in place, keeping its URL and history. Redeploys by default.",
+		InputSchema: schemaFor[UpdateServiceInput](),
+	}, s.handleUpdateService)
+
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name:        "rollback_service",
+		Description: "Roll a service back to an earlier deployment's image and config without rebuilding. deployment_id defaults to 'previous'.",
+		InputSchema: schemaFor[RollbackServiceInput](),
+	}, s.handleRollbackService)
+
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name:        "cancel_deployment",
+		Description: "Cancel an in-flight (queued/building/deploying) deployment. deployment_id defaults to the latest deployment; the previously active deployment keeps serving.",
+		InputSchema: schemaFor[CancelDeploymentInput](),
+	}, s.handleCancelDeployment)
+
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name:        "list_deployments",
+		Description: "List a service's deployment history, newest first, with status, trigger, commit, image and duration.",
+		InputSchema: schemaFor[ListDeploymentsInput](),
+	}, s.handleListDeployments)
+
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name:        "get_deployment",
+		Description: "Get one deployment by ID, including its build log.",
+		InputSchema: schemaFor[GetDeploymentInput](),
+	}, s.handleGetDeployment)
+
 	mcp.AddTool(s.mcpServer, &mcp.Tool{
 		Name:        "list_services",
 		Description: "List all deployed services",
 		InputSchema: schemaFor[ListServicesInput](),
 	}, s.handleListServices)
 
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name:        "list_projects",
+		Description: "List your projects. Services, resources and repos each belong to one project, the default project unless another is given.",
+		InputSchema: schemaFor[ListProjectsInput](),
+	}, s.handleListProjects)
+
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name:        "create_project",
+		Description: "Create a project to keep a set of services, resources and repos apart from the others. Pass its name as project= to the other tools.",
+		InputSchema: schemaFor[CreateProjectInput](),
+	}, s.handleCreateProject)
+
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name:        "rename_project",
+		Description: "Rename a project. Only possible while it has no services or postgres/redis resources, since their URLs are derived from the project name. The default project can't be renamed.",
+		InputSchema: schemaFor[RenameProjectInput](),
+	}, s.handleRenameProject)
+
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name:        "delete_project",
+		Description: "Delete a project and everything in it: services, resources and their data, repos and custom domains. This is permanent. The default project can't be deleted.",
+		InputSchema: schemaFor[DeleteProjectInput](),
+	}, s.handleDeleteProject)
+
 	mcp.AddTool(s.mcpServer, &mcp.Tool{
 		Name:        "create_resource",
-		Description: "Create a new resource (e.g., sqlite database). Returns connection URL and auth token.",
+		Description: "Create a new resource: a sqlite (Turso) or postgres database, or a redis key-value store for caches and queues. Postgres and redis run in the project and start out provisioning, so fetch their URL with get_resource once active. Names are unique per project, and services only see the resources of their own project.",
 		InputSchema: schemaFor[CreateResourceInput](),
 	}, s.handleCreateResource)
 
 	mcp.AddTool(s.mcpServer, &mcp.Tool{
 		Name:        "list_resources",
-		Description: "List all resources (databases, etc.) for the authenticated user",
+		Description: "List resources (databases, etc.) across all projects or in one, with the storage and row usage of sqlite databases",
 		InputSchema: schemaFor[ListResourcesInput](),
 	}, s.handleListResources)
 
 	mcp.AddTool(s.mcpServer, &mcp.Tool{
 		Name:        "get_resource",
-		Description: "Get detailed information about a resource including connection URL and auth token",
+		Description: "Get detailed information about a resource including connection URL (database_url or redis_url) and auth token. For sqlite databases usage reports storage against the declared size; writes are blocked once a database outgrows it.",
 		InputSchema: schemaFor[GetResourceDetailsInput](),
 	}, s.handleGetResourceDetails)
 
 	mcp.AddTool(s.mcpServer, &mcp.Tool{
 		Name:        "delete_resource",
-		Description: "Delete a resource (e.g., sqlite database). This permanently removes the resource.",
+		Description: "Delete a resource (sqlite or postgres database or redis). This permanently removes the resource and its data.",
 		InputSchema: schemaFor[DeleteResourceInput](),
 	}, s.handleDeleteResource)
 
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name:        "fork_resource",
+		Description: "Copy a sqlite database into a new resource, as it is now or as it was at a past timestamp. Use it for a throwaway copy of production data for a preview service, or to keep the current state before restore_resource. Give a larger size to move a database that has outgrown its own.",
+		InputSchema: schemaFor[ForkResourceInput](),
+	}, s.handleForkResource)
+
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name:        "restore_resource",
+		Description: "Roll a sqlite database back to a past timestamp, e.g. after a bad migration. Changes made since are lost (fork_resource first to keep them). The database gets new credentials and services using it are redeployed. The database from before the restore is kept until finish_restore.",
+		InputSchema: schemaFor[RestoreResourceInput](),
+	}, s.handleRestoreResource)
+
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name:        "finish_restore",
+		Description: "Finish a restore_resource once the redeployed services work: deletes the database from before the restore. Set undo to switch back to that database instead and delete the restored one; services using it are redeployed.",
+		InputSchema: schemaFor[FinishRestoreInput](),
+	}, s.handleFinishRestore)
+
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name:        "rotate_resource_credentials",
+		Description: "Replace the credentials of a sqlite or postgres database and redeploy the services using it. A sqlite database gets a new auth token; set invalidate_old to revoke the previous tokens, e.g. after a leak. A postgres database gets a new password and the old one stops working at once. Redis credentials can't be rotated yet.",
+		InputSchema: schemaFor[RotateResourceCredentialsInput](),
+	}, s.handleRotateResourceCredentials)
+
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name:        "create_read_only_token",
+		Description: "Issue an expiring read-only auth token for a sqlite database, for tools that should only read the data. The token isn't stored; rotate_resource_credentials with invalidate_old revokes it. Only sqlite databases are supported: postgres and redis have no read-only tokens.",
+		InputSchema: schemaFor[CreateReadOnlyTokenInput](),
+	}, s.handleCreateReadOnlyToken)
+
 	mcp.AddTool(s.mcpServer, &mcp.Tool{
 		Name:        "get_service",
-		Description: "Get detailed information about a deployed service. Returns deployment status (queued/building/deploying/active/failed/cancelled) and runtime status (running/deploying/failed/not_deployed). Use deploy_log_lines and runtime_log_lines to fetch logs.",
+		Description: "Get detailed information about a deployed service. Returns deployment status (queued/building/deploying/active/failed/cancelled) and runtime status (running/deploying/failed/not_deployed). The health block classifies a failing service (crash_loop, oom_killed, image_pull_failed, port_mismatch, unschedulable, health_check_failed) with a suggested fix; pass diagnose=true to get it for a deployed service that misbehaves (it is included on its own when the latest deployment failed). Use deploy_log_lines and runtime_log_lines to fetch logs.",
 		InputSchema: schemaFor[GetServiceInput](),
 	}, s.handleGetService)
 
@@ -147,6 +231,24 @@ func (s *Server) registerTools() {
 		InputSchema: schemaFor[GetGitTokenInput](),
 	}, s.handleGetGitToken)
 
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name:        "set_repo_policy",
+		Description: "Set the push policy of an ml.ink repo: protected branches that refuse force pushes and deletes, and the largest pack and file a push may send. New repos protect main. Rejected pushes fail with the reason shown by git.",
+		InputSchema: schemaFor[SetRepoPolicyInput](),
+	}, s.handleSetRepoPolicy)
+
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name:        "list_repos",
+		Description: "List your ml.ink repos with their size on disk, and how much of the per-repo and per-user quotas is used. A push is refused once a repo or your repos together are over quota; sizes are updated after each push and by a nightly garbage collection.",
+		InputSchema: schemaFor[ListReposInput](),
+	}, s.handleListRepos)
+
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name:        "get_upload_url",
+		Description: "Get a fresh upload command for a service created with upload=true. The command tars the current directory and uploads it; each upload deploys. The token expires in an hour.",
+		InputSchema: schemaFor[GetUploadURLInput](),
+	}, s.handleGetUploadURL)
+
 	mcp.AddTool(s.mcpServer, &mcp.Tool{
 		Name:        "add_custom_domain",
 		Description: "Attach a custom domain to a service. Returns DNS records to configure.",
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -26,14 +27,7 @@ func resolveServicePort(buildPack, publishDir string, requestedPort *int) string
 	if requestedPort != nil && *requestedPort > 0 {
 		return strconv.Itoa(*requestedPort)
 	}
-	switch buildPack {
-	case "static":
-		return "80"
-	case "dockerfile":
-		return "" // defer to EXPOSE detection in ResolveBuildContext
-	default:
-		return strconv.Itoa(DefaultPort)
-	}
+	return k8sdeployments.DefaultServicePort(buildPack, publishDir)
 }
 
 func (s *Server) handleWhoami(ctx context.Context, req *mcp.CallToolRequest, input WhoamiInput) (*mcp.CallToolResult, WhoamiOutput, error) {
@@ -64,24 +58,42 @@ func (s *Server) handleCreateService(ctx context.Context, req *mcp.CallToolReque
 		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: "not authenticated"}}}, CreateServiceOutput{}, nil
 	}
 
-	if input.Repo == "" {
-		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: "repo is required"}}}, CreateServiceOutput{}, nil
+	input.Image = strings.TrimSpace(input.Image)
+	switch {
+	case input.Upload:
+		if input.Repo != "" || input.Image != "" || input.Host != "" || input.Branch != "" {
+			return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: "upload can't be used with repo, host, branch or image"}}}, CreateServiceOutput{}, nil
+		}
+	case input.Image != "":
+		if err := validateImageServiceInput(input); err != nil {
+			return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: err.Error()}}}, CreateServiceOutput{}, nil
+		}
+	case input.Repo == "":
+		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: "repo, image or upload is required"}}}, CreateServiceOutput{}, nil
 	}
-	if input.Branch == "" {
+	if input.Branch == "" && input.Image == "" && !input.Upload {
 		input.Branch = "main"
 	}
 	if input.Name == "" {
 		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: "name is required"}}}, CreateServiceOutput{}, nil
 	}
 
-	host, repo, err := s.normalizeServiceRepo(ctx, user, input)
-	if err != nil {
-		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: err.Error()}}}, CreateServiceOutput{}, nil
+	var host string
+	if input.Image == "" && !input.Upload {
+		var repo string
+		var err error
+		host, repo, err = s.normalizeServiceRepo(ctx, user, input)
+		if err != nil {
+			return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: err.Error()}}}, CreateServiceOutput{}, nil
+		}
+		input.Repo = repo
 	}
-	input.Repo = repo
 
 	buildPack := "railpack"
-	if input.BuildPack != "" {
+	if input.Image != "" {
+		// Nothing is built; the image brings its own start command.
+		buildPack = ""
+	} else if input.BuildPack != "" {
 		switch input.BuildPack {
 		case "railpack", "dockerfile", "static", "dockercompose":
 			buildPack = input.BuildPack
@@ -128,20 +140,54 @@ func (s *Server) handleCreateService(ctx context.Context, req *mcp.CallToolReque
 
 	port := resolveServicePort(buildPack, publishDir, input.Port)
 
+	if input.Replicas != nil && *input.Replicas < 1 {
+		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: "replicas must be at least 1"}}}, CreateServiceOutput{}, nil
+	}
+	if _, err := k8sdeployments.ParseSleepAfter(input.SleepAfter); err != nil {
+		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: err.Error()}}}, CreateServiceOutput{}, nil
+	}
+	if err := k8sdeployments.ValidateHealthCheck(healthCheckConfig(input.HealthCheck)); err != nil {
+		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: err.Error()}}}, CreateServiceOutput{}, nil
+	}
+
 	envVars := make([]deployments.EnvVar, len(input.EnvVars))
 	for i, ev := range input.EnvVars {
 		envVars[i] = deployments.EnvVar{
 			Key:   ev.Key,
 			Value: ev.Value,
+			Scope: ev.Scope,
 		}
 	}
 
+	if err := validateServiceResources(input.Resources); err != nil {
+		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: err.Error()}}}, CreateServiceOutput{}, nil
+	}
+	if err := s.validateEnvVars(ctx, user.ID, input.Project, input.EnvVars, input.Resources); err != nil {
+		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: err.Error()}}}, CreateServiceOutput{}, nil
+	}
+	// Check the name before provisioning anything; failures after this point
+	// release the resources created for the service.
+	if _, err := s.deployService.GetServiceByName(ctx, deployments.GetServiceByNameParams{
+		Name:    input.Name,
+		Project: input.Project,
+		UserID:  user.ID,
+	}); err == nil {
+		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("service %q already exists in this project", input.Name)}}}, CreateServiceOutput{}, nil
+	}
+	bindings, wired, err := s.provisionServiceResources(ctx, user.ID, input.Project, input.Region, input.Resources)
+	if err != nil {
+		s.logger.Error("failed to provision service resources", "error", err)
+		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: err.Error()}}}, CreateServiceOutput{}, nil
+	}
+
 	isInternalGit := host == "ml.ink"
 
 	s.logger.Info("starting deployment",
 		"user_id", user.ID,
 		"project", input.Project,
 		"repo", input.Repo,
+		"image", input.Image,
+		"upload", input.Upload,
 		"branch", input.Branch,
 		"build_pack", buildPack,
 		"port", port,
@@ -150,14 +196,20 @@ func (s *Server) handleCreateService(ctx context.Context, req *mcp.CallToolReque
 
 	var result *deployments.CreateServiceResult
 
-	if host == "ml.ink" {
-		result, err = s.createServiceFromInternalGit(ctx, user.ID, input, buildPack, port, envVars)
-	} else {
-		result, err = s.createServiceFromGitHub(ctx, user, input, buildPack, port, envVars)
+	switch {
+	case input.Upload:
+		result, err = s.createServiceFromUpload(ctx, user.ID, input, buildPack, port, envVars, bindings)
+	case input.Image != "":
+		result, err = s.createServiceFromImage(ctx, user.ID, input, port, envVars, bindings)
+	case host == "ml.ink":
+		result, err = s.createServiceFromInternalGit(ctx, user.ID, input, buildPack, port, envVars, bindings)
+	default:
+		result, err = s.createServiceFromGitHub(ctx, user, input, buildPack, port, envVars, bindings)
 	}
 
 	if err != nil {
 		s.logger.Error("failed to start deployment", "error", err)
+		s.releaseServiceResources(ctx, user.ID, wired)
 		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("failed to start deployment: %v", err)}}}, CreateServiceOutput{}, nil
 	}
 
@@ -166,9 +218,23 @@ func (s *Server) handleCreateService(ctx context.Context, req *mcp.CallToolReque
 		Name:      result.Name,
 		Status:    result.Status,
 		Repo:      result.Repo,
+		Image:     input.Image,
+		Resources: wired,
 		Message:   fmt.Sprintf("Deployment started (workflow_id: %s)", result.WorkflowID),
 	}
 
+	if input.Upload {
+		upload, err := s.internalGitSvc.CreateUploadToken(ctx, user.ID, result.ServiceID)
+		if err != nil {
+			s.logger.Error("failed to create upload token", "error", err, "service_id", result.ServiceID)
+			return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("service created but failed to issue an upload token: %v. Use get_upload_url", err)}}}, CreateServiceOutput{}, nil
+		}
+		output.UploadURL = upload.UploadURL
+		output.UploadCommand = upload.Command
+		output.ExpiresAt = upload.ExpiresAt
+		output.Message = "Service created. Run upload_command from the source directory to deploy"
+	}
+
 	return nil, output, nil
 }
 
@@ -227,7 +293,7 @@ func (s *Server) normalizeServiceRepo(ctx context.Context, user *users.User, inp
 	return host, fmt.Sprintf("%s/%s", username, repo), nil
 }
 
-func (s *Server) createServiceFromGitHub(ctx context.Context, user *users.User, input CreateServiceInput, buildPack, port string, envVars []deployments.EnvVar) (*deployments.CreateServiceResult, error) {
+func (s *Server) createServiceFromGitHub(ctx context.Context, user *users.User, input CreateServiceInput, buildPack, port string, envVars []deployments.EnvVar, bindings []deployments.ResourceBinding) (*deployments.CreateServiceResult, error) {
 	creds, err := s.authService.GetGitHubCredsByUserID(ctx, user.ID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get GitHub credentials")
@@ -251,6 +317,10 @@ func (s *Server) createServiceFromGitHub(ctx context.Context, user *users.User,
 		GitProvider:      "github",
 		Memory:           input.Memory,
 		VCPUs:            input.VCPUs,
+		Replicas:         int32(helpers.Deref(input.Replicas)),
+		Autoscaling:      autoscalingConfig(input.Autoscaling),
+		SleepAfter:       sleepAfterSeconds(input.SleepAfter),
+		HealthCheck:      healthCheckConfig(input.HealthCheck),
 		BuildCommand:     input.BuildCommand,
 		StartCommand:     input.StartCommand,
 		InstallationID:   *creds.GithubAppInstallationID,
@@ -258,10 +328,11 @@ func (s *Server) createServiceFromGitHub(ctx context.Context, user *users.User,
 		RootDirectory:    input.RootDirectory,
 		DockerfilePath:   input.DockerfilePath,
 		Region:           input.Region,
+		Resources:        bindings,
 	})
 }
 
-func (s *Server) createServiceFromInternalGit(ctx context.Context, userID string, input CreateServiceInput, buildPack, port string, envVars []deployments.EnvVar) (*deployments.CreateServiceResult, error) {
+func (s *Server) createServiceFromInternalGit(ctx context.Context, userID string, input CreateServiceInput, buildPack, port string, envVars []deployments.EnvVar, bindings []deployments.ResourceBinding) (*deployments.CreateServiceResult, error) {
 	fullName := strings.TrimPrefix(strings.TrimSpace(input.Repo), "ml.ink/")
 	parts := strings.Split(fullName, "/")
 	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
@@ -289,15 +360,241 @@ func (s *Server) createServiceFromInternalGit(ctx context.Context, userID string
 		GitProvider:      "internal",
 		Memory:           input.Memory,
 		VCPUs:            input.VCPUs,
+		Replicas:         int32(helpers.Deref(input.Replicas)),
+		Autoscaling:      autoscalingConfig(input.Autoscaling),
+		SleepAfter:       sleepAfterSeconds(input.SleepAfter),
+		HealthCheck:      healthCheckConfig(input.HealthCheck),
 		BuildCommand:     input.BuildCommand,
 		StartCommand:     input.StartCommand,
 		PublishDirectory: input.PublishDirectory,
 		RootDirectory:    input.RootDirectory,
 		DockerfilePath:   input.DockerfilePath,
 		Region:           input.Region,
+		Resources:        bindings,
+	})
+}
+
+// validateImageServiceInput rejects the repo and build options of an
+// image-sourced service, which has nothing to build.
+func validateImageServiceInput(input CreateServiceInput) error {
+	if input.Repo != "" {
+		return fmt.Errorf("set either repo or image, not both")
+	}
+	for _, option := range []struct{ name, value string }{
+		{"host", input.Host},
+		{"branch", input.Branch},
+		{"build_pack", input.BuildPack},
+		{"build_command", input.BuildCommand},
+		{"start_command", input.StartCommand},
+		{"publish_directory", input.PublishDirectory},
+		{"root_directory", input.RootDirectory},
+		{"dockerfile_path", input.DockerfilePath},
+	} {
+		if strings.TrimSpace(option.value) != "" {
+			return fmt.Errorf("%s can't be used with image", option.name)
+		}
+	}
+	if (input.RegistryUsername == "") != (input.RegistryPassword == "") {
+		return fmt.Errorf("registry_username and registry_password must be set together")
+	}
+	return k8sdeployments.ValidateImageRef(input.Image)
+}
+
+func (s *Server) createServiceFromImage(ctx context.Context, userID string, input CreateServiceInput, port string, envVars []deployments.EnvVar, bindings []deployments.ResourceBinding) (*deployments.CreateServiceResult, error) {
+	var registryCreds *k8sdeployments.RegistryCredentials
+	if input.RegistryUsername != "" {
+		registryCreds = &k8sdeployments.RegistryCredentials{
+			Username: input.RegistryUsername,
+			Password: input.RegistryPassword,
+		}
+	}
+
+	return s.deployService.CreateService(ctx, deployments.CreateServiceInput{
+		UserID:              userID,
+		ProjectRef:          input.Project,
+		Name:                input.Name,
+		Port:                port,
+		EnvVars:             envVars,
+		Memory:              input.Memory,
+		VCPUs:               input.VCPUs,
+		Replicas:            int32(helpers.Deref(input.Replicas)),
+		Autoscaling:         autoscalingConfig(input.Autoscaling),
+		SleepAfter:          sleepAfterSeconds(input.SleepAfter),
+		HealthCheck:         healthCheckConfig(input.HealthCheck),
+		Region:              input.Region,
+		Resources:           bindings,
+		Image:               input.Image,
+		RegistryCredentials: registryCreds,
 	})
 }
 
+func (s *Server) createServiceFromUpload(ctx context.Context, userID string, input CreateServiceInput, buildPack, port string, envVars []deployments.EnvVar, bindings []deployments.ResourceBinding) (*deployments.CreateServiceResult, error) {
+	return s.deployService.CreateService(ctx, deployments.CreateServiceInput{
+		UserID:           userID,
+		ProjectRef:       input.Project,
+		Name:             input.Name,
+		BuildPack:        buildPack,
+		Port:             port,
+		EnvVars:          envVars,
+		Memory:           input.Memory,
+		VCPUs:            input.VCPUs,
+		Replicas:         int32(helpers.Deref(input.Replicas)),
+		Autoscaling:      autoscalingConfig(input.Autoscaling),
+		SleepAfter:       sleepAfterSeconds(input.SleepAfter),
+		HealthCheck:      healthCheckConfig(input.HealthCheck),
+		BuildCommand:     input.BuildCommand,
+		StartCommand:     input.StartCommand,
+		PublishDirectory: input.PublishDirectory,
+		RootDirectory:    input.RootDirectory,
+		DockerfilePath:   input.DockerfilePath,
+		Region:           input.Region,
+		Resources:        bindings,
+		Upload:           true,
+	})
+}
+
+func validateServiceResources(inputs []ServiceResourceInput) error {
+	seen := make(map[string]bool, len(inputs))
+	for _, r := range inputs {
+		if r.Name == "" {
+			return fmt.Errorf("resources: name is required")
+		}
+		if r.Type != "" && r.Type != resources.TypeSQLite && r.Type != resources.TypePostgres && r.Type != resources.TypeRedis {
+			return fmt.Errorf("resources: invalid type %q for %s: must be 'sqlite', 'postgres' or 'redis'", r.Type, r.Name)
+		}
+		if seen[r.Name] {
+			return fmt.Errorf("resources: %s is listed twice", r.Name)
+		}
+		seen[r.Name] = true
+	}
+	return nil
+}
+
+// validateEnvVars checks env var scopes and that the resources env vars
+// reference exist in the service's project or are among those about to be
+// created, so a typo fails here rather than at deploy time.
+func (s *Server) validateEnvVars(ctx context.Context, userID, projectRef string, envVars []EnvVar, pending []ServiceResourceInput) error {
+	for _, ev := range envVars {
+		if err := k8sdeployments.ValidateEnvScope(ev.Scope); err != nil {
+			return fmt.Errorf("env var %s: %w", ev.Key, err)
+		}
+		refs, err := resources.ParseEnvRefs(ev.Value)
+		if err != nil {
+			return fmt.Errorf("env var %s: %w", ev.Key, err)
+		}
+		if len(refs) > 0 && ev.Scope == k8sdeployments.EnvScopeBuild {
+			return fmt.Errorf("env var %s: resource references are only resolved at runtime; use scope runtime or both", ev.Key)
+		}
+		for _, ref := range refs {
+			if slices.ContainsFunc(pending, func(r ServiceResourceInput) bool { return r.Name == ref.Resource }) {
+				continue
+			}
+			if s.resourcesService == nil {
+				return fmt.Errorf("resources service is not configured")
+			}
+			if _, err := s.resourcesService.GetResourceByName(ctx, userID, projectRef, ref.Resource); err != nil {
+				return fmt.Errorf("env var %s references unknown resource %q", ev.Key, ref.Resource)
+			}
+		}
+	}
+	return nil
+}
+
+// resourceDefaults fills in a new resource's type, size and region.
+// Postgres and redis run in their service's cluster region; sqlite lives
+// with Turso.
+func resourceDefaults(dbType, size, serviceRegion string) (string, string, string) {
+	if dbType == "" {
+		dbType = DefaultDBType
+	}
+	if dbType == resources.TypeSQLite {
+		if size == "" {
+			size = DefaultDBSize
+		}
+		return dbType, size, DefaultRegion
+	}
+	if size == "" {
+		size = resources.DefaultPostgresSize
+		if dbType == resources.TypeRedis {
+			size = resources.DefaultRedisSize
+		}
+	}
+	if serviceRegion == "" {
+		serviceRegion = resources.DefaultPostgresRegion
+	}
+	return dbType, size, serviceRegion
+}
+
+// provisionServiceResources creates the listed resources that don't exist
+// yet in the service's project and returns how each is bound. A single
+// resource gets the plain DATABASE_* names; several are prefixed by name.
+func (s *Server) provisionServiceResources(ctx context.Context, userID, projectRef, serviceRegion string, inputs []ServiceResourceInput) ([]deployments.ResourceBinding, []WiredResource, error) {
+	if len(inputs) == 0 {
+		return nil, nil, nil
+	}
+	if s.resourcesService == nil {
+		return nil, nil, fmt.Errorf("resources service is not configured")
+	}
+	project, err := s.deployService.ResolveProject(ctx, userID, projectRef)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	bindings := make([]deployments.ResourceBinding, 0, len(inputs))
+	wired := make([]WiredResource, 0, len(inputs))
+	for _, in := range inputs {
+		w := WiredResource{Name: in.Name, Type: DefaultDBType}
+		if existing, err := s.resourcesService.GetResourceByName(ctx, userID, project.Ref, in.Name); err == nil {
+			if in.Type != "" && existing.Type != in.Type {
+				s.releaseServiceResources(ctx, userID, wired)
+				return nil, nil, fmt.Errorf("resource %s already exists with type %s", in.Name, existing.Type)
+			}
+			w.ResourceID = existing.ID
+			w.Type = existing.Type
+		} else {
+			dbType, size, region := resourceDefaults(in.Type, in.Size, serviceRegion)
+			result, err := s.resourcesService.ProvisionDatabase(ctx, resources.ProvisionDatabaseInput{
+				UserID:    userID,
+				ProjectID: &project.ID,
+				Name:      in.Name,
+				Type:      dbType,
+				Size:      size,
+				Region:    region,
+			})
+			if err != nil {
+				s.releaseServiceResources(ctx, userID, wired)
+				return nil, nil, fmt.Errorf("failed to create resource %s: %w", in.Name, err)
+			}
+			w.ResourceID = result.ResourceID
+			w.Type = result.Type
+			w.Created = true
+		}
+
+		prefix := ""
+		if len(inputs) > 1 {
+			prefix = resources.EnvPrefix(in.Name)
+		}
+		w.EnvVars = resources.EnvVarNames(prefix, w.Type)
+		bindings = append(bindings, deployments.ResourceBinding{ResourceID: w.ResourceID, EnvPrefix: prefix})
+		wired = append(wired, w)
+	}
+	return bindings, wired, nil
+}
+
+// releaseServiceResources deletes the resources provisionServiceResources
+// created for a service that was never created, so a failed create_service
+// doesn't leave databases behind. Resources that already existed are kept.
+func (s *Server) releaseServiceResources(ctx context.Context, userID string, wired []WiredResource) {
+	for _, w := range wired {
+		if !w.Created {
+			continue
+		}
+		if err := s.resourcesService.DeleteResource(ctx, userID, w.ResourceID); err != nil {
+			s.logger.Error("failed to release resource", "error", err, "resource_id", w.ResourceID)
+		}
+	}
+}
+
 func (s *Server) handleListServices(ctx context.Context, req *mcp.CallToolRequest, input ListServicesInput) (*mcp.CallToolResult, ListServicesOutput, error) {
 	user := UserFromContext(ctx)
 	if user == nil {
@@ -386,52 +683,295 @@ func (s *Server) handleRedeployService(ctx context.Context, req *mcp.CallToolReq
 	return nil, output, nil
 }
 
-func (s *Server) handleCreateResource(ctx context.Context, req *mcp.CallToolRequest, input CreateResourceInput) (*mcp.CallToolResult, CreateResourceOutput, error) {
+func (s *Server) handleRollbackService(ctx context.Context, req *mcp.CallToolRequest, input RollbackServiceInput) (*mcp.CallToolResult, RollbackServiceOutput, error) {
 	user := UserFromContext(ctx)
 	if user == nil {
-		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: "not authenticated"}}}, CreateResourceOutput{}, nil
+		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: "not authenticated"}}}, RollbackServiceOutput{}, nil
 	}
 
 	if input.Name == "" {
-		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: "name is required"}}}, CreateResourceOutput{}, nil
+		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: "name is required"}}}, RollbackServiceOutput{}, nil
+	}
+
+	s.logger.Info("starting rollback",
+		"user_id", user.ID,
+		"project", input.Project,
+		"name", input.Name,
+		"deployment_id", input.DeploymentID,
+	)
+
+	result, err := s.deployService.RollbackService(ctx, deployments.RollbackServiceParams{
+		Name:         input.Name,
+		Project:      input.Project,
+		UserID:       user.ID,
+		DeploymentID: input.DeploymentID,
+	})
+	if err != nil {
+		s.logger.Error("failed to start rollback", "error", err)
+		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("failed to start rollback: %v", err)}}}, RollbackServiceOutput{}, nil
+	}
+
+	output := RollbackServiceOutput{
+		ServiceID:          result.ServiceID,
+		Name:               result.Name,
+		DeploymentID:       result.DeploymentID,
+		SourceDeploymentID: result.SourceDeploymentID,
+		CommitHash:         result.CommitHash,
+		ImageRef:           result.ImageRef,
+		Status:             "queued",
+		Message:            fmt.Sprintf("Rollback to deployment %s started (workflow_id: %s). Service config is unchanged; the next redeploy builds from it.", result.SourceDeploymentID, result.WorkflowID),
+	}
+
+	return nil, output, nil
+}
+
+func autoscalingConfig(in *AutoscalingInput) *k8sdeployments.AutoscalingConfig {
+	if in == nil {
+		return nil
+	}
+	minReplicas := in.MinReplicas
+	if minReplicas == 0 {
+		minReplicas = 1
 	}
+	cfg := &k8sdeployments.AutoscalingConfig{
+		MinReplicas: int32(minReplicas),
+		MaxReplicas: int32(in.MaxReplicas),
+	}
+	if in.TargetCPUUtilization != nil {
+		cfg.TargetCPUUtilization = helpers.Ptr(int32(*in.TargetCPUUtilization))
+	}
+	if in.TargetMemoryUtilization != nil {
+		cfg.TargetMemoryUtilization = helpers.Ptr(int32(*in.TargetMemoryUtilization))
+	}
+	return cfg
+}
+
+func healthCheckConfig(in *HealthCheckInput) *k8sdeployments.HealthCheck {
+	if in == nil {
+		return nil
+	}
+	return &k8sdeployments.HealthCheck{
+		Path:                strings.TrimSpace(in.Path),
+		InitialDelaySeconds: int32(in.InitialDelaySeconds),
+		TimeoutSeconds:      int32(in.TimeoutSeconds),
+		FailureThreshold:    int32(in.FailureThreshold),
+	}
+}
+
+// sleepAfterSeconds converts an already validated sleep_after duration.
+func sleepAfterSeconds(in string) *int32 {
+	secs, _ := k8sdeployments.ParseSleepAfter(in)
+	return secs
+}
+
+// formatSleepAfter renders seconds the way sleep_after is written, e.g. "2h"
+// rather than "2h0m0s".
+func formatSleepAfter(seconds int32) string {
+	out := (time.Duration(seconds) * time.Second).String()
+	if strings.HasSuffix(out, "m0s") {
+		out = strings.TrimSuffix(out, "0s")
+	}
+	if strings.HasSuffix(out, "h0m") {
+		out = strings.TrimSuffix(out, "0m")
+	}
+	return out
+}
 
-	dbType := DefaultDBType
-	if input.Type != "" {
-		if input.Type != "sqlite" {
-			return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: "invalid type: only 'sqlite' is supported"}}}, CreateResourceOutput{}, nil
+// sanitizeRelativePath trims surrounding slashes and rejects absolute paths
+// and parent traversal. An empty input stays empty so callers can clear it.
+func sanitizeRelativePath(field, raw string) (string, error) {
+	trimmed := strings.TrimSpace(raw)
+	if trimmed == "" {
+		return "", nil
+	}
+	cleaned := strings.Trim(trimmed, "/")
+	if cleaned == "" || strings.Contains(cleaned, "..") || filepath.IsAbs(trimmed) {
+		return "", fmt.Errorf("invalid %s: must be a relative path without '..'", field)
+	}
+	return cleaned, nil
+}
+
+func (s *Server) handleUpdateService(ctx context.Context, req *mcp.CallToolRequest, input UpdateServiceInput) (*mcp.CallToolResult, UpdateServiceOutput, error) {
+	user := UserFromContext(ctx)
+	if user == nil {
+		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: "not authenticated"}}}, UpdateServiceOutput{}, nil
+	}
+
+	if input.Name == "" {
+		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: "name is required"}}}, UpdateServiceOutput{}, nil
+	}
+
+	update := deployments.UpdateServiceInput{
+		UserID:        user.ID,
+		Project:       input.Project,
+		Name:          input.Name,
+		BuildCommand:  input.BuildCommand,
+		StartCommand:  input.StartCommand,
+		RemoveEnvVars: input.RemoveEnvVars,
+		Redeploy:      input.Redeploy == nil || *input.Redeploy,
+	}
+
+	if branch := strings.TrimSpace(input.Branch); branch != "" {
+		update.Branch = &branch
+	}
+
+	if input.BuildPack != "" {
+		switch input.BuildPack {
+		case "railpack", "dockerfile", "static", "dockercompose":
+			update.BuildPack = &input.BuildPack
+		default:
+			return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("invalid build_pack: %s. Valid options: railpack, dockerfile, static, dockercompose", input.BuildPack)}}}, UpdateServiceOutput{}, nil
 		}
-		dbType = input.Type
 	}
 
-	size := DefaultDBSize
-	if input.Size != "" {
-		size = input.Size
+	if input.Memory != "" {
+		update.Memory = &input.Memory
+	}
+	if input.VCPUs != "" {
+		update.VCPUs = &input.VCPUs
+	}
+	if input.Replicas != nil {
+		if *input.Replicas < 1 {
+			return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: "replicas must be at least 1"}}}, UpdateServiceOutput{}, nil
+		}
+		replicas := int32(*input.Replicas)
+		update.Replicas = &replicas
+	}
+	if input.Autoscaling != nil {
+		update.Autoscaling = autoscalingConfig(input.Autoscaling)
+	}
+	if input.SleepAfter != nil {
+		secs, err := k8sdeployments.ParseSleepAfter(*input.SleepAfter)
+		if err != nil {
+			return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: err.Error()}}}, UpdateServiceOutput{}, nil
+		}
+		if secs == nil {
+			secs = helpers.Ptr(int32(0))
+		}
+		update.SleepAfter = secs
+	}
+	if input.HealthCheck != nil {
+		update.HealthCheck = healthCheckConfig(input.HealthCheck)
+	}
+
+	for _, p := range []struct {
+		field string
+		in    *string
+		out   **string
+	}{
+		{"publish_directory", input.PublishDirectory, &update.PublishDirectory},
+		{"root_directory", input.RootDirectory, &update.RootDirectory},
+		{"dockerfile_path", input.DockerfilePath, &update.DockerfilePath},
+	} {
+		if p.in == nil {
+			continue
+		}
+		cleaned, err := sanitizeRelativePath(p.field, *p.in)
+		if err != nil {
+			return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: err.Error()}}}, UpdateServiceOutput{}, nil
+		}
+		*p.out = &cleaned
+	}
+
+	if input.Port != nil {
+		if *input.Port <= 0 || *input.Port > 65535 {
+			return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: "invalid port: must be between 1 and 65535"}}}, UpdateServiceOutput{}, nil
+		}
+		port := strconv.Itoa(*input.Port)
+		update.Port = &port
 	}
 
-	region := DefaultRegion
-	if input.Region != "" {
-		if input.Region != "eu-west" {
-			return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: "invalid region: only 'eu-west' is supported"}}}, CreateResourceOutput{}, nil
+	for _, ev := range input.EnvVars {
+		if ev.Key == "" {
+			return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: "env var key must not be empty"}}}, UpdateServiceOutput{}, nil
 		}
-		region = input.Region
+		update.SetEnvVars = append(update.SetEnvVars, deployments.EnvVar{
+			Key:   ev.Key,
+			Value: ev.Value,
+			Scope: ev.Scope,
+		})
+	}
+	if err := s.validateEnvVars(ctx, user.ID, input.Project, input.EnvVars, nil); err != nil {
+		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: err.Error()}}}, UpdateServiceOutput{}, nil
+	}
+
+	s.logger.Info("updating service",
+		"user_id", user.ID,
+		"project", input.Project,
+		"name", input.Name,
+		"redeploy", update.Redeploy,
+	)
+
+	result, err := s.deployService.UpdateService(ctx, update)
+	if err != nil {
+		s.logger.Error("failed to update service", "error", err)
+		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("failed to update service: %v", err)}}}, UpdateServiceOutput{}, nil
+	}
+
+	output := UpdateServiceOutput{
+		ServiceID: result.Service.ID,
+		Name:      input.Name,
+		Changed:   result.Changed,
+		Status:    "updated",
+		Message:   "Service updated. Changes apply on the next deploy; call redeploy_service or pass redeploy=true.",
+	}
+	switch {
+	case len(result.Changed) == 0:
+		output.Status = "unchanged"
+		output.Message = "No changes: service config already matches the request."
+	case result.WorkflowID != "":
+		output.Status = "queued"
+		output.Message = fmt.Sprintf("Service updated, redeploy started (workflow_id: %s)", result.WorkflowID)
+	}
+
+	return nil, output, nil
+}
+
+func (s *Server) handleCreateResource(ctx context.Context, req *mcp.CallToolRequest, input CreateResourceInput) (*mcp.CallToolResult, CreateResourceOutput, error) {
+	user := UserFromContext(ctx)
+	if user == nil {
+		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: "not authenticated"}}}, CreateResourceOutput{}, nil
+	}
+
+	if input.Name == "" {
+		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: "name is required"}}}, CreateResourceOutput{}, nil
+	}
+
+	if input.Type != "" && input.Type != resources.TypeSQLite && input.Type != resources.TypePostgres && input.Type != resources.TypeRedis {
+		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: "invalid type: must be 'sqlite', 'postgres' or 'redis'"}}}, CreateResourceOutput{}, nil
+	}
+	isSQLite := input.Type == "" || input.Type == resources.TypeSQLite
+	if isSQLite && input.Region != "" && input.Region != "eu-west" {
+		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: "invalid region: sqlite only supports 'eu-west'"}}}, CreateResourceOutput{}, nil
+	}
+	if input.Persistent && input.Type != resources.TypeRedis {
+		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: "persistent only applies to redis"}}}, CreateResourceOutput{}, nil
+	}
+	dbType, size, region := resourceDefaults(input.Type, input.Size, input.Region)
+
+	project, err := s.deployService.ResolveProject(ctx, user.ID, input.Project)
+	if err != nil {
+		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("failed to create resource: %v", err)}}}, CreateResourceOutput{}, nil
 	}
 
 	s.logger.Info("creating resource",
 		"user_id", user.ID,
+		"project", project.Ref,
 		"name", input.Name,
 		"type", dbType,
 		"size", size,
 		"region", region,
+		"persistent", input.Persistent,
 	)
 
 	result, err := s.resourcesService.ProvisionDatabase(ctx, resources.ProvisionDatabaseInput{
-		UserID:    user.ID,
-		ProjectID: nil,
-		Name:      input.Name,
-		Type:      dbType,
-		Size:      size,
-		Region:    region,
+		UserID:     user.ID,
+		ProjectID:  &project.ID,
+		Name:       input.Name,
+		Type:       dbType,
+		Size:       size,
+		Region:     region,
+		Persistent: input.Persistent,
 	})
 	if err != nil {
 		s.logger.Error("failed to create resource", "error", err)
@@ -441,12 +981,16 @@ func (s *Server) handleCreateResource(ctx context.Context, req *mcp.CallToolRequ
 	output := CreateResourceOutput{
 		ResourceID: result.ResourceID,
 		Name:       result.Name,
+		Project:    project.Ref,
 		Type:       result.Type,
 		Region:     result.Region,
 		URL:        result.URL,
 		AuthToken:  result.AuthToken,
 		Status:     result.Status,
 	}
+	if result.Status == resources.StatusProvisioning {
+		output.Message = "Provisioning started. Call get_resource for the connection string once status is active; services reach it from the same project only."
+	}
 
 	return nil, output, nil
 }
@@ -457,7 +1001,7 @@ func (s *Server) handleListResources(ctx context.Context, req *mcp.CallToolReque
 		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: "not authenticated"}}}, ListResourcesOutput{}, nil
 	}
 
-	resources, err := s.resourcesService.ListResources(ctx, user.ID, 100, 0)
+	resources, err := s.resourcesService.ListResources(ctx, user.ID, input.Project, 100, 0)
 	if err != nil {
 		s.logger.Error("failed to list resources", "error", err)
 		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("failed to list resources: %v", err)}}}, ListResourcesOutput{}, nil
@@ -468,10 +1012,12 @@ func (s *Server) handleListResources(ctx context.Context, req *mcp.CallToolReque
 		resourceInfos[i] = ResourceInfo{
 			ResourceID: r.ID,
 			Name:       r.Name,
+			Project:    r.Project,
 			Type:       r.Type,
 			Region:     r.Region,
 			Status:     r.Status,
 			CreatedAt:  r.CreatedAt.Format(time.RFC3339),
+			Usage:      resourceUsage(r.Usage),
 		}
 	}
 
@@ -488,14 +1034,15 @@ func (s *Server) handleGetResourceDetails(ctx context.Context, req *mcp.CallTool
 		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: "name is required"}}}, GetResourceDetailsOutput{}, nil
 	}
 
-	resource, err := s.resourcesService.GetResourceByName(ctx, user.ID, input.Name)
+	resource, err := s.resourcesService.GetResourceByName(ctx, user.ID, input.Project, input.Name)
 	if err != nil {
-		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("resource not found: %s", input.Name)}}}, GetResourceDetailsOutput{}, nil
+		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: err.Error()}}}, GetResourceDetailsOutput{}, nil
 	}
 
 	output := GetResourceDetailsOutput{
 		ResourceID: resource.ID,
 		Name:       resource.Name,
+		Project:    resource.Project,
 		Type:       resource.Type,
 		Region:     resource.Region,
 		Status:     resource.Status,
@@ -503,7 +1050,18 @@ func (s *Server) handleGetResourceDetails(ctx context.Context, req *mcp.CallTool
 		UpdatedAt:  resource.UpdatedAt.Format(time.RFC3339),
 	}
 
-	if resource.Credentials != nil {
+	output.ForkedFrom = resource.Metadata["forked_from"]
+	output.ForkedAt = resource.Metadata["forked_at"]
+	output.RestoredTo = resource.Metadata["restored_to"]
+	output.RestoredAt = resource.Metadata["restored_at"]
+	output.ReplacedDatabase = resource.Metadata["replaced_database"]
+	output.Usage = resourceUsage(resource.Usage)
+
+	switch {
+	case resource.Credentials == nil:
+	case resource.Type == resources.TypeRedis:
+		output.RedisURL = resource.Credentials.URL
+	default:
 		output.DatabaseURL = resource.Credentials.URL
 		output.AuthToken = resource.Credentials.AuthToken
 	}
@@ -553,6 +1111,9 @@ func (s *Server) handleGetService(ctx context.Context, req *mcp.CallToolRequest,
 	runtime := &RuntimeDetails{
 		Status: depStatus,
 	}
+	if svc.SleptAt.Valid {
+		runtime.Status = "sleeping"
+	}
 
 	output := GetServiceOutput{
 		ServiceID:  svc.ID,
@@ -566,6 +1127,29 @@ func (s *Server) handleGetService(ctx context.Context, req *mcp.CallToolRequest,
 		Deployment: deployment,
 		Runtime:    runtime,
 	}
+	if svc.SleepAfter != nil {
+		output.SleepAfter = formatSleepAfter(*svc.SleepAfter)
+	}
+
+	// Diagnosing waits on the cluster, so it only runs when asked or when
+	// the service is known to be in trouble. A sleeping or never-deployed
+	// service has no pods to diagnose.
+	wantDiagnosis := input.Diagnose || depStatus == k8sdeployments.StatusFailed
+	if wantDiagnosis && svc.CurrentDeploymentID != nil && !svc.SleptAt.Valid {
+		if health, err := s.deployService.DiagnoseService(ctx, svc); err != nil {
+			s.logger.Warn("failed to diagnose service", "service_id", svc.ID, "error", err)
+		} else {
+			output.Health = &HealthDetails{
+				Status:        health.Status,
+				Reason:        health.Reason,
+				Message:       health.Message,
+				RestartCount:  health.RestartCount,
+				ExitCode:      health.ExitCode,
+				LastErrorLine: health.LastErrorLine,
+				Suggestion:    health.Suggestion,
+			}
+		}
+	}
 
 	if zr, dz, err := s.dnsService.GetCustomDomainForService(ctx, svc.ID); err == nil {
 		domain := zr.Name + "." + dz.Zone
@@ -581,7 +1165,16 @@ func (s *Server) handleGetService(ctx context.Context, req *mcp.CallToolRequest,
 		if err := json.Unmarshal(svc.EnvVars, &envVars); err == nil {
 			output.EnvVars = make([]EnvVarInfo, len(envVars))
 			for i, ev := range envVars {
-				output.EnvVars[i] = EnvVarInfo(ev)
+				output.EnvVars[i] = EnvVarInfo{Key: ev.Key, Value: ev.Value, Scope: ev.Scope}
+				if ev.Scope == "" {
+					output.EnvVars[i].Scope = k8sdeployments.EnvScopeBoth
+				}
+				refs, _ := resources.ParseEnvRefs(ev.Value)
+				for _, ref := range refs {
+					if !slices.Contains(output.EnvVars[i].References, ref.Resource) {
+						output.EnvVars[i].References = append(output.EnvVars[i].References, ref.Resource)
+					}
+				}
 			}
 		}
 	}
@@ -623,9 +1216,15 @@ func (s *Server) handleDeleteResource(ctx context.Context, req *mcp.CallToolRequ
 		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: "resources service is not configured"}}}, DeleteResourceOutput{}, nil
 	}
 
-	resource, err := s.resourcesService.GetResourceByName(ctx, user.ID, input.Name)
+	resource, err := s.resourcesService.GetResourceByName(ctx, user.ID, input.Project, input.Name)
 	if err != nil {
-		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("resource not found: %s", input.Name)}}}, DeleteResourceOutput{}, nil
+		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: err.Error()}}}, DeleteResourceOutput{}, nil
+	}
+
+	boundServiceIDs, err := s.resourcesService.ListBoundServiceIDs(ctx, resource.ID)
+	if err != nil {
+		s.logger.Error("failed to list bound services", "error", err)
+		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("failed to delete resource: %v", err)}}}, DeleteResourceOutput{}, nil
 	}
 
 	if err := s.resourcesService.DeleteResource(ctx, user.ID, resource.ID); err != nil {
@@ -633,10 +1232,17 @@ func (s *Server) handleDeleteResource(ctx context.Context, req *mcp.CallToolRequ
 		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("failed to delete resource: %v", err)}}}, DeleteResourceOutput{}, nil
 	}
 
+	// Bound services keep the deleted credentials in their env until they
+	// are redeployed without them.
+	message := "Resource deleted successfully"
+	if redeployed := s.redeployServices(ctx, boundServiceIDs); redeployed > 0 {
+		message = fmt.Sprintf("Resource deleted successfully; redeploying %d service(s) that used it", redeployed)
+	}
+
 	return nil, DeleteResourceOutput{
 		ResourceID: resource.ID,
 		Name:       resource.Name,
-		Message:    "Resource deleted successfully",
+		Message:    message,
 	}, nil
 }
 
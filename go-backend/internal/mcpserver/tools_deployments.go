@@ -0,0 +1,222 @@
+package mcpserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/augustdev/autoclip/internal/deployments"
+	"github.com/augustdev/autoclip/internal/helpers"
+	"github.com/augustdev/autoclip/internal/k8sdeployments"
+	deploymentsdb "github.com/augustdev/autoclip/internal/storage/pg/generated/deployments"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	DefaultDeploymentsLimit = 20
+	MaxDeploymentsLimit     = 100
+	DefaultDeployLogLines   = 100
+)
+
+func (s *Server) handleListDeployments(ctx context.Context, req *mcp.CallToolRequest, input ListDeploymentsInput) (*mcp.CallToolResult, ListDeploymentsOutput, error) {
+	user := UserFromContext(ctx)
+	if user == nil {
+		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: "not authenticated"}}}, ListDeploymentsOutput{}, nil
+	}
+
+	if input.Name == "" {
+		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: "name is required"}}}, ListDeploymentsOutput{}, nil
+	}
+
+	svc, err := s.deployService.GetServiceByName(ctx, deployments.GetServiceByNameParams{
+		Name:    input.Name,
+		Project: input.Project,
+		UserID:  user.ID,
+	})
+	if err != nil {
+		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: err.Error()}}}, ListDeploymentsOutput{}, nil
+	}
+
+	limit := input.Limit
+	if limit <= 0 {
+		limit = DefaultDeploymentsLimit
+	}
+	limit = min(limit, MaxDeploymentsLimit)
+	offset := max(input.Offset, 0)
+
+	deps, total, err := s.deployService.ListDeployments(ctx, deployments.ListDeploymentsParams{
+		ServiceID: svc.ID,
+		Limit:     int32(limit),
+		Offset:    int32(offset),
+	})
+	if err != nil {
+		s.logger.Error("failed to list deployments", "service_id", svc.ID, "error", err)
+		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("failed to list deployments: %v", err)}}}, ListDeploymentsOutput{}, nil
+	}
+
+	now := time.Now()
+	infos := make([]DeploymentInfo, len(deps))
+	for i := range deps {
+		infos[i] = deploymentToInfo(&deps[i], now)
+	}
+
+	return nil, ListDeploymentsOutput{
+		ServiceID:   svc.ID,
+		Deployments: infos,
+		TotalCount:  total,
+		HasMore:     int64(offset+len(deps)) < total,
+	}, nil
+}
+
+func (s *Server) handleGetDeployment(ctx context.Context, req *mcp.CallToolRequest, input GetDeploymentInput) (*mcp.CallToolResult, GetDeploymentOutput, error) {
+	user := UserFromContext(ctx)
+	if user == nil {
+		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: "not authenticated"}}}, GetDeploymentOutput{}, nil
+	}
+
+	if input.Name == "" {
+		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: "name is required"}}}, GetDeploymentOutput{}, nil
+	}
+	if input.DeploymentID == "" {
+		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: "deployment_id is required"}}}, GetDeploymentOutput{}, nil
+	}
+
+	project := "default"
+	if input.Project != "" {
+		project = input.Project
+	}
+
+	svc, err := s.deployService.GetServiceByName(ctx, deployments.GetServiceByNameParams{
+		Name:    input.Name,
+		Project: project,
+		UserID:  user.ID,
+	})
+	if err != nil {
+		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: err.Error()}}}, GetDeploymentOutput{}, nil
+	}
+
+	dep, err := s.deployService.GetDeployment(ctx, svc.ID, input.DeploymentID)
+	if err != nil {
+		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: err.Error()}}}, GetDeploymentOutput{}, nil
+	}
+
+	now := time.Now()
+	output := GetDeploymentOutput{
+		DeploymentInfo: deploymentToInfo(dep, now),
+		ServiceID:      svc.ID,
+	}
+
+	logLines := input.LogLines
+	if logLines <= 0 {
+		logLines = DefaultDeployLogLines
+	}
+	logLines = min(logLines, MaxLogLines)
+
+	start, end := deploymentLogWindow(dep, now)
+	ns := k8sdeployments.NamespaceName(user.ID, project)
+	svcName := k8sdeployments.ServiceName(helpers.Deref(svc.Name))
+	lines, err := k8sdeployments.QueryBuildLogsRange(ctx, s.lokiQueryURL, s.lokiUsername, s.lokiPassword, ns, svcName, start, end, logLines)
+	if err != nil {
+		s.logger.Warn("failed to query deployment build logs", "deployment_id", dep.ID, "error", err)
+	} else if len(lines) > 0 {
+		output.Logs = strings.Join(lines, "\n")
+	}
+
+	return nil, output, nil
+}
+
+func (s *Server) handleCancelDeployment(ctx context.Context, req *mcp.CallToolRequest, input CancelDeploymentInput) (*mcp.CallToolResult, CancelDeploymentOutput, error) {
+	user := UserFromContext(ctx)
+	if user == nil {
+		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: "not authenticated"}}}, CancelDeploymentOutput{}, nil
+	}
+
+	if input.Name == "" {
+		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: "name is required"}}}, CancelDeploymentOutput{}, nil
+	}
+
+	result, err := s.deployService.CancelDeployment(ctx, deployments.CancelDeploymentParams{
+		Name:         input.Name,
+		Project:      input.Project,
+		UserID:       user.ID,
+		DeploymentID: input.DeploymentID,
+	})
+	if err != nil {
+		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("failed to cancel deployment: %v", err)}}}, CancelDeploymentOutput{}, nil
+	}
+
+	message := fmt.Sprintf("Deployment %s cancelled.", result.DeploymentID)
+	if result.ActiveDeploymentID != nil {
+		message += fmt.Sprintf(" Deployment %s keeps serving traffic.", *result.ActiveDeploymentID)
+	}
+
+	return nil, CancelDeploymentOutput{
+		ServiceID:          result.ServiceID,
+		Name:               result.Name,
+		DeploymentID:       result.DeploymentID,
+		PreviousStatus:     result.PreviousStatus,
+		Status:             "cancelled",
+		ActiveDeploymentID: result.ActiveDeploymentID,
+		Message:            message,
+	}, nil
+}
+
+func deploymentToInfo(dep *deploymentsdb.Deployment, now time.Time) DeploymentInfo {
+	info := DeploymentInfo{
+		DeploymentID: dep.ID,
+		Status:       dep.Status,
+		Trigger:      dep.Trigger,
+		TriggerRef:   dep.TriggerRef,
+		CommitHash:   dep.CommitHash,
+		ImageRef:     dep.ImageRef,
+		ErrorMessage: dep.ErrorMessage,
+		CreatedAt:    dep.CreatedAt.Time.Format(time.RFC3339),
+		StartedAt:    formatTimestamptz(dep.StartedAt),
+		FinishedAt:   formatTimestamptz(dep.FinishedAt),
+	}
+
+	if len(dep.BuildProgress) > 0 {
+		var progress any
+		if err := json.Unmarshal(dep.BuildProgress, &progress); err == nil {
+			info.BuildProgress = progress
+		}
+	}
+
+	// Finished deployments report their total run time; in-flight ones report
+	// time elapsed so far.
+	if dep.StartedAt.Valid {
+		end := now
+		if dep.FinishedAt.Valid {
+			end = dep.FinishedAt.Time
+		}
+		secs := int64(end.Sub(dep.StartedAt.Time).Seconds())
+		info.DurationSeconds = &secs
+	}
+
+	return info
+}
+
+// deploymentLogWindow bounds a deployment's build logs by its lifetime, with
+// slack at the end for log batches flushed after the status update.
+func deploymentLogWindow(dep *deploymentsdb.Deployment, now time.Time) (time.Time, time.Time) {
+	start := dep.CreatedAt.Time
+	if dep.StartedAt.Valid {
+		start = dep.StartedAt.Time
+	}
+	end := now
+	if dep.FinishedAt.Valid {
+		end = dep.FinishedAt.Time.Add(30 * time.Second)
+	}
+	return start, end
+}
+
+func formatTimestamptz(ts pgtype.Timestamptz) *string {
+	if !ts.Valid {
+		return nil
+	}
+	formatted := ts.Time.Format(time.RFC3339)
+	return &formatted
+}
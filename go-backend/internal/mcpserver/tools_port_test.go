@@ -64,6 +64,11 @@ func TestResolveServicePort(t *testing.T) {
 			requestedPort: intPtr(3000),
 			want:          "3000",
 		},
+		{
+			name:      "dockercompose nil port defers to the compose file",
+			buildPack: "dockercompose",
+			want:      "",
+		},
 	}
 
 	for _, tt := range tests {
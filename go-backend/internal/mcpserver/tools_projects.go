@@ -0,0 +1,100 @@
+package mcpserver
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func (s *Server) handleListProjects(ctx context.Context, req *mcp.CallToolRequest, input ListProjectsInput) (*mcp.CallToolResult, ListProjectsOutput, error) {
+	user := UserFromContext(ctx)
+	if user == nil {
+		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: "not authenticated"}}}, ListProjectsOutput{}, nil
+	}
+
+	list, err := s.deployService.ListProjects(ctx, user.ID, 100, 0)
+	if err != nil {
+		s.logger.Error("failed to list projects", "error", err)
+		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: "failed to list projects"}}}, ListProjectsOutput{}, nil
+	}
+
+	infos := make([]ProjectInfo, len(list))
+	for i, p := range list {
+		infos[i] = ProjectInfo{
+			ProjectID: p.ID,
+			Name:      p.Ref,
+			IsDefault: p.IsDefault,
+			CreatedAt: p.CreatedAt.Time.Format(time.RFC3339),
+		}
+	}
+	return nil, ListProjectsOutput{Projects: infos}, nil
+}
+
+func (s *Server) handleCreateProject(ctx context.Context, req *mcp.CallToolRequest, input CreateProjectInput) (*mcp.CallToolResult, CreateProjectOutput, error) {
+	user := UserFromContext(ctx)
+	if user == nil {
+		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: "not authenticated"}}}, CreateProjectOutput{}, nil
+	}
+
+	if input.Name == "" {
+		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: "name is required"}}}, CreateProjectOutput{}, nil
+	}
+
+	project, err := s.deployService.CreateProject(ctx, user.ID, input.Name)
+	if err != nil {
+		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: err.Error()}}}, CreateProjectOutput{}, nil
+	}
+
+	return nil, CreateProjectOutput{
+		ProjectID: project.ID,
+		Name:      project.Ref,
+		Message:   fmt.Sprintf("Project %s created. Pass project='%s' to create services and resources in it.", project.Ref, project.Ref),
+	}, nil
+}
+
+func (s *Server) handleRenameProject(ctx context.Context, req *mcp.CallToolRequest, input RenameProjectInput) (*mcp.CallToolResult, RenameProjectOutput, error) {
+	user := UserFromContext(ctx)
+	if user == nil {
+		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: "not authenticated"}}}, RenameProjectOutput{}, nil
+	}
+
+	if input.Project == "" || input.NewName == "" {
+		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: "project and new_name are required"}}}, RenameProjectOutput{}, nil
+	}
+
+	project, err := s.deployService.RenameProject(ctx, user.ID, input.Project, input.NewName)
+	if err != nil {
+		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: err.Error()}}}, RenameProjectOutput{}, nil
+	}
+
+	return nil, RenameProjectOutput{
+		ProjectID: project.ID,
+		Name:      project.Ref,
+		Message:   fmt.Sprintf("Project %s renamed to %s", input.Project, project.Ref),
+	}, nil
+}
+
+func (s *Server) handleDeleteProject(ctx context.Context, req *mcp.CallToolRequest, input DeleteProjectInput) (*mcp.CallToolResult, DeleteProjectOutput, error) {
+	user := UserFromContext(ctx)
+	if user == nil {
+		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: "not authenticated"}}}, DeleteProjectOutput{}, nil
+	}
+
+	if input.Project == "" {
+		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: "project is required"}}}, DeleteProjectOutput{}, nil
+	}
+
+	result, err := s.deployService.DeleteProject(ctx, user.ID, input.Project)
+	if err != nil {
+		s.logger.Error("failed to delete project", "error", err)
+		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: err.Error()}}}, DeleteProjectOutput{}, nil
+	}
+
+	return nil, DeleteProjectOutput{
+		ProjectID: result.ProjectID,
+		Name:      result.Ref,
+		Message:   fmt.Sprintf("Deleting project %s with its services, resources, repos and domains. It disappears from list_projects once done.", result.Ref),
+	}, nil
+}
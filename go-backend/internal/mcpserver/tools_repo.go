@@ -9,7 +9,11 @@ import (
 	"net/http"
 	"slices"
 	"strings"
+	"time"
 
+	"github.com/augustdev/autoclip/internal/deployments"
+	"github.com/augustdev/autoclip/internal/internalgit"
+	"github.com/augustdev/autoclip/internal/k8sdeployments"
 	"github.com/augustdev/autoclip/internal/storage/pg/generated/users"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
@@ -208,6 +212,156 @@ func (s *Server) getPrivateGitToken(ctx context.Context, user *users.User, input
 	}, nil
 }
 
+func (s *Server) handleSetRepoPolicy(ctx context.Context, req *mcp.CallToolRequest, input SetRepoPolicyInput) (*mcp.CallToolResult, SetRepoPolicyOutput, error) {
+	user := UserFromContext(ctx)
+	if user == nil {
+		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: "not authenticated"}}}, SetRepoPolicyOutput{}, nil
+	}
+
+	if input.Name == "" {
+		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: "name is required"}}}, SetRepoPolicyOutput{}, nil
+	}
+
+	projectRef := input.Project
+	if projectRef == "" {
+		projectRef = "default"
+	}
+	project, err := s.deployService.GetProjectByRef(ctx, user.ID, projectRef)
+	if err != nil {
+		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("project not found: %s", projectRef)}}}, SetRepoPolicyOutput{}, nil
+	}
+	repo, err := s.internalGitSvc.GetRepoByProjectAndName(ctx, project.ID, input.Name)
+	if err != nil {
+		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("repo '%s' not found in project '%s'", input.Name, projectRef)}}}, SetRepoPolicyOutput{}, nil
+	}
+
+	updated, err := s.internalGitSvc.UpdateRepoPolicy(ctx, user.ID, repo.FullName, internalgit.RepoPolicyUpdate{
+		ProtectedBranches: input.ProtectedBranches,
+		MaxPackBytes:      mbToBytes(input.MaxPackMB),
+		MaxFileBytes:      mbToBytes(input.MaxFileMB),
+	})
+	if err != nil {
+		s.logger.Error("failed to update repo policy", "error", err, "repo", repo.FullName)
+		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("failed to update repo policy: %v", err)}}}, SetRepoPolicyOutput{}, nil
+	}
+
+	return nil, SetRepoPolicyOutput{
+		Repo:              updated.FullName,
+		ProtectedBranches: updated.ProtectedBranches,
+		MaxPackMB:         bytesToMB(updated.MaxPackBytes),
+		MaxFileMB:         bytesToMB(updated.MaxFileBytes),
+		Message:           "Policy applies to the next push. Unset limits use the server defaults.",
+	}, nil
+}
+
+func mbToBytes(mb *int) *int64 {
+	if mb == nil {
+		return nil
+	}
+	b := int64(*mb) << 20
+	return &b
+}
+
+func bytesToMB(b *int64) *int {
+	if b == nil {
+		return nil
+	}
+	mb := int(*b >> 20)
+	return &mb
+}
+
+func (s *Server) handleGetUploadURL(ctx context.Context, req *mcp.CallToolRequest, input GetUploadURLInput) (*mcp.CallToolResult, GetUploadURLOutput, error) {
+	user := UserFromContext(ctx)
+	if user == nil {
+		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: "not authenticated"}}}, GetUploadURLOutput{}, nil
+	}
+
+	if input.Name == "" {
+		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: "name is required"}}}, GetUploadURLOutput{}, nil
+	}
+
+	project := "default"
+	if input.Project != "" {
+		project = input.Project
+	}
+
+	svc, err := s.deployService.GetServiceByName(ctx, deployments.GetServiceByNameParams{
+		Name:    input.Name,
+		Project: project,
+		UserID:  user.ID,
+	})
+	if err != nil {
+		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: err.Error()}}}, GetUploadURLOutput{}, nil
+	}
+	if svc.SourceType != k8sdeployments.SourceUpload {
+		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("service '%s' deploys from %s, not uploads", input.Name, svc.SourceType)}}}, GetUploadURLOutput{}, nil
+	}
+
+	result, err := s.internalGitSvc.CreateUploadToken(ctx, user.ID, svc.ID)
+	if err != nil {
+		s.logger.Error("failed to create upload token", "error", err, "service_id", svc.ID)
+		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("failed to create upload token: %v", err)}}}, GetUploadURLOutput{}, nil
+	}
+
+	return nil, GetUploadURLOutput{
+		UploadURL: result.UploadURL,
+		Command:   result.Command,
+		ExpiresAt: result.ExpiresAt,
+	}, nil
+}
+
+func (s *Server) handleListRepos(ctx context.Context, req *mcp.CallToolRequest, input ListReposInput) (*mcp.CallToolResult, ListReposOutput, error) {
+	user := UserFromContext(ctx)
+	if user == nil {
+		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: "not authenticated"}}}, ListReposOutput{}, nil
+	}
+
+	projectList, err := s.deployService.ListProjects(ctx, user.ID, 100, 0)
+	if err != nil {
+		s.logger.Error("failed to list projects", "error", err)
+		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("failed to list projects: %v", err)}}}, ListReposOutput{}, nil
+	}
+	projectNames := make(map[string]string, len(projectList))
+	projectID := ""
+	for _, p := range projectList {
+		projectNames[p.ID] = p.Name
+		if input.Project != "" && (p.Name == input.Project || p.ID == input.Project) {
+			projectID = p.ID
+		}
+	}
+	if input.Project != "" && projectID == "" {
+		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("project not found: %s", input.Project)}}}, ListReposOutput{}, nil
+	}
+
+	result, err := s.internalGitSvc.ListRepos(ctx, user.ID, projectID)
+	if err != nil {
+		s.logger.Error("failed to list repos", "error", err)
+		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("failed to list repos: %v", err)}}}, ListReposOutput{}, nil
+	}
+
+	repos := make([]RepoInfo, len(result.Repos))
+	for i, r := range result.Repos {
+		repos[i] = RepoInfo{
+			Name:              r.Name,
+			Project:           projectNames[r.ProjectID],
+			FullName:          r.FullName,
+			SizeBytes:         r.SizeBytes,
+			ProtectedBranches: r.ProtectedBranches,
+			CreatedAt:         r.CreatedAt.Time.Format(time.RFC3339),
+		}
+		if r.SizeUpdatedAt.Valid {
+			repos[i].SizeUpdatedAt = r.SizeUpdatedAt.Time.Format(time.RFC3339)
+		}
+	}
+
+	return nil, ListReposOutput{
+		Repos:          repos,
+		UsedBytes:      result.UsedBytes,
+		RepoQuotaBytes: result.RepoQuotaBytes,
+		UserQuotaBytes: result.UserQuotaBytes,
+	}, nil
+}
+
 func (s *Server) getGitHubGitToken(ctx context.Context, user *users.User, repoName string) (*mcp.CallToolResult, GetGitTokenOutput, error) {
 	creds, err := s.authService.GetGitHubCredsByUserID(ctx, user.ID)
 	if err != nil {
@@ -0,0 +1,262 @@
+package mcpserver
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/augustdev/autoclip/internal/resources"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func (s *Server) handleForkResource(ctx context.Context, req *mcp.CallToolRequest, input ForkResourceInput) (*mcp.CallToolResult, ForkResourceOutput, error) {
+	user := UserFromContext(ctx)
+	if user == nil {
+		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: "not authenticated"}}}, ForkResourceOutput{}, nil
+	}
+
+	if input.Source == "" || input.Name == "" {
+		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: "source and name are required"}}}, ForkResourceOutput{}, nil
+	}
+
+	if s.resourcesService == nil {
+		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: "resources service is not configured"}}}, ForkResourceOutput{}, nil
+	}
+
+	forkInput := resources.ForkDatabaseInput{
+		UserID:  user.ID,
+		Project: input.Project,
+		Source:  input.Source,
+		Name:    input.Name,
+		Size:    input.Size,
+	}
+	if input.Timestamp != "" {
+		at, err := time.Parse(time.RFC3339, input.Timestamp)
+		if err != nil {
+			return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("invalid timestamp %q: use RFC 3339 such as 2025-01-02T15:04:05Z", input.Timestamp)}}}, ForkResourceOutput{}, nil
+		}
+		forkInput.Timestamp = &at
+	}
+
+	result, err := s.resourcesService.ForkDatabase(ctx, forkInput)
+	if err != nil {
+		s.logger.Error("failed to fork resource", "error", err)
+		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("failed to fork resource: %v", err)}}}, ForkResourceOutput{}, nil
+	}
+
+	fork, err := s.resourcesService.GetResource(ctx, user.ID, result.ResourceID)
+	if err != nil {
+		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("failed to fork resource: %v", err)}}}, ForkResourceOutput{}, nil
+	}
+
+	return nil, ForkResourceOutput{
+		ResourceID: result.ResourceID,
+		Name:       result.Name,
+		ForkedFrom: fork.Metadata["forked_from"],
+		ForkedAt:   fork.Metadata["forked_at"],
+		URL:        result.URL,
+		AuthToken:  result.AuthToken,
+		Status:     result.Status,
+	}, nil
+}
+
+func (s *Server) handleRestoreResource(ctx context.Context, req *mcp.CallToolRequest, input RestoreResourceInput) (*mcp.CallToolResult, RestoreResourceOutput, error) {
+	user := UserFromContext(ctx)
+	if user == nil {
+		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: "not authenticated"}}}, RestoreResourceOutput{}, nil
+	}
+
+	if input.Name == "" || input.Timestamp == "" {
+		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: "name and timestamp are required"}}}, RestoreResourceOutput{}, nil
+	}
+	at, err := time.Parse(time.RFC3339, input.Timestamp)
+	if err != nil {
+		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("invalid timestamp %q: use RFC 3339 such as 2025-01-02T15:04:05Z", input.Timestamp)}}}, RestoreResourceOutput{}, nil
+	}
+
+	if s.resourcesService == nil {
+		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: "resources service is not configured"}}}, RestoreResourceOutput{}, nil
+	}
+
+	result, err := s.resourcesService.RestoreDatabase(ctx, resources.RestoreDatabaseInput{
+		UserID:    user.ID,
+		Project:   input.Project,
+		Name:      input.Name,
+		Timestamp: at,
+	})
+	if err != nil {
+		s.logger.Error("failed to restore resource", "error", err)
+		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("failed to restore resource: %v", err)}}}, RestoreResourceOutput{}, nil
+	}
+
+	// The restored database has new credentials, which services only pick
+	// up on their next deploy.
+	message := "Resource restored"
+	boundServiceIDs, err := s.resourcesService.ListBoundServiceIDs(ctx, result.ResourceID)
+	if err != nil {
+		s.logger.Error("failed to list bound services", "error", err)
+		message = "Resource restored, but the services using it could not be listed; redeploy them to pick up the new credentials"
+	} else if redeployed := s.redeployServices(ctx, boundServiceIDs); redeployed > 0 {
+		message = fmt.Sprintf("Resource restored; redeploying %d service(s) that use it", redeployed)
+	}
+	message += ". The old database is kept: call finish_restore once the services work, or with undo to go back to it"
+
+	return nil, RestoreResourceOutput{
+		ResourceID: result.ResourceID,
+		Name:       result.Name,
+		RestoredTo: at.UTC().Format(time.RFC3339),
+		URL:        result.URL,
+		AuthToken:  result.AuthToken,
+		Message:    message,
+	}, nil
+}
+
+func (s *Server) handleFinishRestore(ctx context.Context, req *mcp.CallToolRequest, input FinishRestoreInput) (*mcp.CallToolResult, FinishRestoreOutput, error) {
+	user := UserFromContext(ctx)
+	if user == nil {
+		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: "not authenticated"}}}, FinishRestoreOutput{}, nil
+	}
+
+	if input.Name == "" {
+		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: "name is required"}}}, FinishRestoreOutput{}, nil
+	}
+
+	if s.resourcesService == nil {
+		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: "resources service is not configured"}}}, FinishRestoreOutput{}, nil
+	}
+
+	result, err := s.resourcesService.FinishRestore(ctx, resources.FinishRestoreInput{
+		UserID:  user.ID,
+		Project: input.Project,
+		Name:    input.Name,
+		Undo:    input.Undo,
+	})
+	if err != nil {
+		s.logger.Error("failed to finish restore", "error", err)
+		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("failed to finish restore: %v", err)}}}, FinishRestoreOutput{}, nil
+	}
+
+	message := "Restore finished; the database from before it was deleted"
+	if input.Undo {
+		// Switching back gives the resource new credentials again.
+		message = "Restore undone"
+		boundServiceIDs, err := s.resourcesService.ListBoundServiceIDs(ctx, result.ResourceID)
+		if err != nil {
+			s.logger.Error("failed to list bound services", "error", err)
+			message = "Restore undone, but the services using it could not be listed; redeploy them to pick up the new credentials"
+		} else if redeployed := s.redeployServices(ctx, boundServiceIDs); redeployed > 0 {
+			message = fmt.Sprintf("Restore undone; redeploying %d service(s) that use it", redeployed)
+		}
+	}
+
+	return nil, FinishRestoreOutput{
+		ResourceID: result.ResourceID,
+		Name:       result.Name,
+		URL:        result.URL,
+		AuthToken:  result.AuthToken,
+		Message:    message,
+	}, nil
+}
+
+func (s *Server) handleRotateResourceCredentials(ctx context.Context, req *mcp.CallToolRequest, input RotateResourceCredentialsInput) (*mcp.CallToolResult, RotateResourceCredentialsOutput, error) {
+	user := UserFromContext(ctx)
+	if user == nil {
+		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: "not authenticated"}}}, RotateResourceCredentialsOutput{}, nil
+	}
+
+	if input.Name == "" {
+		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: "name is required"}}}, RotateResourceCredentialsOutput{}, nil
+	}
+
+	if s.resourcesService == nil {
+		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: "resources service is not configured"}}}, RotateResourceCredentialsOutput{}, nil
+	}
+
+	result, err := s.resourcesService.RotateCredentials(ctx, user.ID, input.Project, input.Name, input.InvalidateOld)
+	if err != nil {
+		s.logger.Error("failed to rotate resource credentials", "error", err)
+		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("failed to rotate credentials: %v", err)}}}, RotateResourceCredentialsOutput{}, nil
+	}
+
+	// Services hold the credentials in their env, so they only switch to
+	// the new ones on their next deploy.
+	message := "Credentials rotated"
+	boundServiceIDs, err := s.resourcesService.ListBoundServiceIDs(ctx, result.ResourceID)
+	if err != nil {
+		s.logger.Error("failed to list bound services", "error", err)
+		message = "Credentials rotated, but the services using them could not be listed; redeploy them to pick up the new credentials"
+	} else if redeployed := s.redeployServices(ctx, boundServiceIDs); redeployed > 0 {
+		message = fmt.Sprintf("Credentials rotated; redeploying %d service(s) that use them", redeployed)
+	}
+
+	return nil, RotateResourceCredentialsOutput{
+		ResourceID: result.ResourceID,
+		Name:       result.Name,
+		URL:        result.URL,
+		AuthToken:  result.AuthToken,
+		Message:    message,
+	}, nil
+}
+
+func (s *Server) handleCreateReadOnlyToken(ctx context.Context, req *mcp.CallToolRequest, input CreateReadOnlyTokenInput) (*mcp.CallToolResult, CreateReadOnlyTokenOutput, error) {
+	user := UserFromContext(ctx)
+	if user == nil {
+		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: "not authenticated"}}}, CreateReadOnlyTokenOutput{}, nil
+	}
+
+	if input.Name == "" {
+		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: "name is required"}}}, CreateReadOnlyTokenOutput{}, nil
+	}
+
+	if s.resourcesService == nil {
+		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: "resources service is not configured"}}}, CreateReadOnlyTokenOutput{}, nil
+	}
+
+	days := input.ExpiresInDays
+	if days == 0 {
+		days = DefaultReadOnlyTokenDays
+	}
+
+	creds, err := s.resourcesService.CreateReadOnlyToken(ctx, user.ID, input.Project, input.Name, days)
+	if err != nil {
+		s.logger.Error("failed to create read-only token", "error", err)
+		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("failed to create read-only token: %v", err)}}}, CreateReadOnlyTokenOutput{}, nil
+	}
+
+	return nil, CreateReadOnlyTokenOutput{
+		Name:      input.Name,
+		URL:       creds.URL,
+		AuthToken: creds.AuthToken,
+		ExpiresAt: time.Now().AddDate(0, 0, days).UTC().Format(time.RFC3339),
+	}, nil
+}
+
+func resourceUsage(u *resources.Usage) *ResourceUsage {
+	if u == nil {
+		return nil
+	}
+	return &ResourceUsage{
+		StorageBytes:   u.StorageBytes,
+		SizeLimitBytes: u.SizeLimitBytes,
+		RowsRead:       u.RowsRead,
+		RowsWritten:    u.RowsWritten,
+		WritesBlocked:  u.WritesBlocked,
+		Warning:        u.Warning,
+		UpdatedAt:      u.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+// redeployServices redeploys the services after a change to a resource
+// they use and returns how many were started. A failure is logged and
+// skipped so the others still go out.
+func (s *Server) redeployServices(ctx context.Context, serviceIDs []string) int {
+	redeployed := 0
+	for _, svcID := range serviceIDs {
+		if _, err := s.deployService.RedeployService(ctx, svcID); err != nil {
+			s.logger.Error("failed to redeploy service after resource change", "service_id", svcID, "error", err)
+			continue
+		}
+		redeployed++
+	}
+	return redeployed
+}
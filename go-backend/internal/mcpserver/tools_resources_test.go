@@ -0,0 +1,34 @@
+package mcpserver
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateServiceResources(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   []ServiceResourceInput
+		wantErr string
+	}{
+		{name: "none"},
+		{name: "default type", input: []ServiceResourceInput{{Name: "main-db"}, {Name: "cache", Type: "sqlite"}}},
+		{name: "missing name", input: []ServiceResourceInput{{Type: "sqlite"}}, wantErr: "name is required"},
+		{name: "unknown type", input: []ServiceResourceInput{{Name: "db", Type: "mysql"}}, wantErr: "invalid type"},
+		{name: "duplicate", input: []ServiceResourceInput{{Name: "db"}, {Name: "db"}}, wantErr: "listed twice"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateServiceResources(tt.input)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("validateServiceResources() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("validateServiceResources() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -11,40 +11,91 @@ type WhoamiOutput struct {
 
 type EnvVar struct {
 	Key   string `json:"key" jsonschema:"description=Environment variable name"`
-	Value string `json:"value" jsonschema:"description=Environment variable value"`
+	Value string `json:"value" jsonschema:"description=Environment variable value. Use ${{resources.<name>.url}} or ${{resources.<name>.auth_token}} to reference a resource's credentials; they are resolved at runtime on every deploy so rotations carry over. Builds don't see them."`
+	Scope string `json:"scope,omitempty" jsonschema:"description=Where the variable is visible: build passes it to the build only and runtime sets it on the running service only. When updating an existing variable an omitted scope keeps its current one.,enum=build,enum=runtime,enum=both,default=both"`
 }
 
 type CreateServiceInput struct {
-	Repo   string `json:"repo" jsonschema:"description=Repository name (e.g. 'myapp')"`
+	Repo   string `json:"repo,omitempty" jsonschema:"description=Repository name (e.g. 'myapp'). Required unless image or upload is set."`
 	Host   string `json:"host,omitempty" jsonschema:"description=Git host,enum=ml.ink,enum=github.com,default=ml.ink"`
 	Branch string `json:"branch,omitempty" jsonschema:"description=Branch to deploy,default=main"`
 	Name   string `json:"name" jsonschema:"description=Name for the deployment"`
 	Region string `json:"region,omitempty" jsonschema:"description=Cluster region to deploy to,enum=eu-central-1,default=eu-central-1"`
 
 	Project   string   `json:"project,omitempty" jsonschema:"description=Project name,default=default"`
-	BuildPack string   `json:"build_pack,omitempty" jsonschema:"description=Build pack to use. 'railpack' (default) auto-detects and builds most apps. 'static' serves files as-is with no build step. 'dockerfile' uses a custom Dockerfile. Use 'railpack' with publish_directory for Vite/React/Vue SPAs that need a build step then static serving via nginx.,enum=railpack,enum=dockerfile,enum=static,enum=dockercompose,default=railpack"`
+	BuildPack string   `json:"build_pack,omitempty" jsonschema:"description=Build pack to use. 'railpack' (default) auto-detects and builds most apps. 'static' serves files as-is with no build step. 'dockerfile' uses a custom Dockerfile. Use 'railpack' with publish_directory for Vite/React/Vue SPAs that need a build step then static serving via nginx. 'dockercompose' runs every service in the repo's compose file; each is reachable from the others by its compose name and the one publishing ports is served publicly (set port to choose if several do).,enum=railpack,enum=dockerfile,enum=static,enum=dockercompose,default=railpack"`
 	Port      *int     `json:"port,omitempty" jsonschema:"description=Port the application listens on"`
 	EnvVars   []EnvVar `json:"env_vars,omitempty" jsonschema:"description=Environment variables"`
 
 	Memory string `json:"memory,omitempty" jsonschema:"description=Memory limit. 256Mi for most apps; 512Mi for heavier apps.,enum=256Mi,enum=512Mi,enum=1024Mi,enum=2048Mi,enum=4096Mi,default=256Mi"`
 	VCPUs  string `json:"vcpus,omitempty" jsonschema:"description=vCPUs,enum=0.5,enum=1,enum=2,enum=4,default=0.5"`
 
+	Replicas    *int              `json:"replicas,omitempty" jsonschema:"description=Number of instances to run. More than 1 keeps the service up through node loss and spreads instances across nodes.,minimum=1,default=1"`
+	Autoscaling *AutoscalingInput `json:"autoscaling,omitempty" jsonschema:"description=Scale between min_replicas and max_replicas on CPU and/or memory load. Overrides replicas."`
+	SleepAfter  string            `json:"sleep_after,omitempty" jsonschema:"description=Scale to zero after this long without inbound traffic (e.g. '30m' or '2h'; between 5m and 168h). The next request wakes the service and is held until it is ready. Not available with autoscaling."`
+
 	BuildCommand string `json:"build_command,omitempty" jsonschema:"description=Custom build command (overrides auto-detected). Only used with build_pack=railpack."`
 	StartCommand string `json:"start_command,omitempty" jsonschema:"description=Custom start command (overrides auto-detected). Only used with build_pack=railpack."`
 
+	HealthCheck *HealthCheckInput `json:"health_check,omitempty" jsonschema:"description=HTTP health check used for readiness and liveness. Without one the service is ready once its port accepts connections."`
+
+	Resources []ServiceResourceInput `json:"resources,omitempty" jsonschema:"description=Databases to create (or reuse by name) and wire into the service. A single resource is injected as DATABASE_URL and DATABASE_AUTH_TOKEN; with several the names are prefixed (main-db gives MAIN_DB_DATABASE_URL). Your own env_vars take precedence."`
+
 	PublishDirectory string `json:"publish_directory,omitempty" jsonschema:"description=Directory containing built static files (e.g. 'dist'). When set with build_pack=railpack the app is built then served as static files via nginx. Recommended for Vite/React/Vue SPAs."`
 
 	RootDirectory  string `json:"root_directory,omitempty" jsonschema:"description=Subdirectory within the repo to use as build context (e.g. 'frontend' or 'services/api'). For monorepo deployments."`
 	DockerfilePath string `json:"dockerfile_path,omitempty" jsonschema:"description=Path to Dockerfile relative to root_directory (e.g. 'worker.Dockerfile' or 'build/Dockerfile'). Only used with build_pack=dockerfile."`
+
+	Image            string `json:"image,omitempty" jsonschema:"description=Prebuilt OCI image to deploy instead of building a repo (e.g. 'nginx:1.27' or 'ghcr.io/acme/api:latest'). Each deploy pins the tag to its current digest so redeploying picks up a moved tag."`
+	RegistryUsername string `json:"registry_username,omitempty" jsonschema:"description=Username for a private image registry. Only used with image."`
+	RegistryPassword string `json:"registry_password,omitempty" jsonschema:"description=Password or access token for a private image registry. Stored encrypted. Only used with image."`
+	Upload           bool   `json:"upload,omitempty" jsonschema:"description=Build from uploaded source tarballs instead of a repo. Returns an upload command; each upload deploys."`
+}
+
+type AutoscalingInput struct {
+	MinReplicas             int  `json:"min_replicas" jsonschema:"description=Minimum number of instances,minimum=1,default=1"`
+	MaxReplicas             int  `json:"max_replicas" jsonschema:"description=Maximum number of instances,minimum=1"`
+	TargetCPUUtilization    *int `json:"target_cpu_utilization,omitempty" jsonschema:"description=Average CPU utilization (percent of requested vCPUs) to scale at,minimum=1,maximum=100"`
+	TargetMemoryUtilization *int `json:"target_memory_utilization,omitempty" jsonschema:"description=Average memory utilization (percent of requested memory) to scale at,minimum=1,maximum=100"`
+}
+
+type HealthCheckInput struct {
+	Path                string `json:"path" jsonschema:"description=HTTP path to request (e.g. '/healthz'). Any 2xx or 3xx answer counts as healthy."`
+	InitialDelaySeconds int    `json:"initial_delay_seconds,omitempty" jsonschema:"description=Seconds to wait after start before the first check,minimum=0,maximum=60,default=0"`
+	TimeoutSeconds      int    `json:"timeout_seconds,omitempty" jsonschema:"description=Seconds to wait for a response,minimum=1,maximum=60,default=3"`
+	FailureThreshold    int    `json:"failure_threshold,omitempty" jsonschema:"description=Consecutive failures before the instance is taken out of rotation and restarted,minimum=1,maximum=10,default=3"`
+}
+
+type ServiceResourceInput struct {
+	Name string `json:"name" jsonschema:"description=Resource name. An existing resource with this name is reused; otherwise one is created."`
+	Type string `json:"type,omitempty" jsonschema:"description=Resource type. redis is injected as REDIS_URL,enum=sqlite,enum=postgres,enum=redis,default=sqlite"`
+	Size string `json:"size,omitempty" jsonschema:"description=Size if the resource is created (see create_resource)"`
 }
 
 type CreateServiceOutput struct {
-	ServiceID  string `json:"service_id"`
-	Name       string `json:"name"`
-	Status     string `json:"status"`
-	Repo       string `json:"repo"`
-	CommitHash string `json:"commit_hash,omitempty"`
-	Message    string `json:"message"`
+	ServiceID  string          `json:"service_id"`
+	Name       string          `json:"name"`
+	Status     string          `json:"status"`
+	Repo       string          `json:"repo,omitempty"`
+	Image      string          `json:"image,omitempty"`
+	CommitHash string          `json:"commit_hash,omitempty"`
+	Resources  []WiredResource `json:"resources,omitempty"`
+	Message    string          `json:"message"`
+
+	// Set for upload services
+	UploadURL     string `json:"upload_url,omitempty"`
+	UploadCommand string `json:"upload_command,omitempty"`
+	ExpiresAt     string `json:"expires_at,omitempty"`
+}
+
+// WiredResource reports a resource bound to a new service and the env vars
+// its credentials are injected as.
+type WiredResource struct {
+	ResourceID string   `json:"resource_id"`
+	Name       string   `json:"name"`
+	Type       string   `json:"type"`
+	Created    bool     `json:"created"`
+	EnvVars    []string `json:"env_vars"`
 }
 
 type RedeployServiceInput struct {
@@ -60,6 +111,116 @@ type RedeployServiceOutput struct {
 	Message    string `json:"message"`
 }
 
+type UpdateServiceInput struct {
+	Name    string `json:"name" jsonschema:"description=Name of the service to update (required)"`
+	Project string `json:"project,omitempty" jsonschema:"description=Project name,default=default"`
+
+	Branch      string            `json:"branch,omitempty" jsonschema:"description=Branch to deploy"`
+	BuildPack   string            `json:"build_pack,omitempty" jsonschema:"description=Build pack to use,enum=railpack,enum=dockerfile,enum=static,enum=dockercompose"`
+	Port        *int              `json:"port,omitempty" jsonschema:"description=Port the application listens on"`
+	Memory      string            `json:"memory,omitempty" jsonschema:"description=Memory limit,enum=256Mi,enum=512Mi,enum=1024Mi,enum=2048Mi,enum=4096Mi"`
+	VCPUs       string            `json:"vcpus,omitempty" jsonschema:"description=vCPUs,enum=0.5,enum=1,enum=2,enum=4"`
+	Replicas    *int              `json:"replicas,omitempty" jsonschema:"description=Number of instances to run,minimum=1"`
+	Autoscaling *AutoscalingInput `json:"autoscaling,omitempty" jsonschema:"description=Scale between min_replicas and max_replicas on CPU and/or memory load. Replaces the current autoscaling; pass max_replicas 0 to turn it off and run replicas instances."`
+	SleepAfter  *string           `json:"sleep_after,omitempty" jsonschema:"description=Scale to zero after this long without inbound traffic (e.g. '30m'). Pass '0' to keep the service running."`
+
+	EnvVars       []EnvVar `json:"env_vars,omitempty" jsonschema:"description=Environment variables to add or update. Existing keys not listed are kept."`
+	RemoveEnvVars []string `json:"remove_env_vars,omitempty" jsonschema:"description=Environment variable keys to remove"`
+
+	BuildCommand     *string `json:"build_command,omitempty" jsonschema:"description=Custom build command. Pass an empty string to clear."`
+	StartCommand     *string `json:"start_command,omitempty" jsonschema:"description=Custom start command. Pass an empty string to clear."`
+	PublishDirectory *string `json:"publish_directory,omitempty" jsonschema:"description=Directory containing built static files. Only used with build_pack=railpack. Pass an empty string to clear."`
+	RootDirectory    *string `json:"root_directory,omitempty" jsonschema:"description=Subdirectory within the repo to use as build context. Pass an empty string to clear."`
+	DockerfilePath   *string `json:"dockerfile_path,omitempty" jsonschema:"description=Path to Dockerfile relative to root_directory. Only used with build_pack=dockerfile. Pass an empty string to clear."`
+
+	HealthCheck *HealthCheckInput `json:"health_check,omitempty" jsonschema:"description=HTTP health check. Pass an empty path to go back to the default port check."`
+
+	Redeploy *bool `json:"redeploy,omitempty" jsonschema:"description=Start a redeploy with the new config after updating,default=true"`
+}
+
+type UpdateServiceOutput struct {
+	ServiceID string   `json:"service_id"`
+	Name      string   `json:"name"`
+	Changed   []string `json:"changed"`
+	Status    string   `json:"status"`
+	Message   string   `json:"message"`
+}
+
+type RollbackServiceInput struct {
+	Name         string `json:"name" jsonschema:"description=Name of the service to roll back (required)"`
+	Project      string `json:"project,omitempty" jsonschema:"description=Project name,default=default"`
+	DeploymentID string `json:"deployment_id,omitempty" jsonschema:"description=Deployment ID to restore or 'previous' for the deployment active before the current one,default=previous"`
+}
+
+type RollbackServiceOutput struct {
+	ServiceID          string  `json:"service_id"`
+	Name               string  `json:"name"`
+	DeploymentID       string  `json:"deployment_id"`
+	SourceDeploymentID string  `json:"source_deployment_id"`
+	CommitHash         *string `json:"commit_hash,omitempty"`
+	ImageRef           string  `json:"image_ref"`
+	Status             string  `json:"status"`
+	Message            string  `json:"message"`
+}
+
+type CancelDeploymentInput struct {
+	Name         string `json:"name" jsonschema:"description=Name of the service (required)"`
+	Project      string `json:"project,omitempty" jsonschema:"description=Project name,default=default"`
+	DeploymentID string `json:"deployment_id,omitempty" jsonschema:"description=Deployment ID to cancel; defaults to the service's latest deployment"`
+}
+
+type CancelDeploymentOutput struct {
+	ServiceID          string  `json:"service_id"`
+	Name               string  `json:"name"`
+	DeploymentID       string  `json:"deployment_id"`
+	PreviousStatus     string  `json:"previous_status"`
+	Status             string  `json:"status"`
+	ActiveDeploymentID *string `json:"active_deployment_id,omitempty"`
+	Message            string  `json:"message"`
+}
+
+type ListDeploymentsInput struct {
+	Name    string `json:"name" jsonschema:"description=Service name (required)"`
+	Project string `json:"project,omitempty" jsonschema:"description=Project name,default=default"`
+	Limit   int    `json:"limit,omitempty" jsonschema:"description=Maximum number of deployments to return (max: 100),default=20"`
+	Offset  int    `json:"offset,omitempty" jsonschema:"description=Number of deployments to skip,default=0"`
+}
+
+type DeploymentInfo struct {
+	DeploymentID    string  `json:"deployment_id"`
+	Status          string  `json:"status"`
+	Trigger         string  `json:"trigger"`
+	TriggerRef      *string `json:"trigger_ref,omitempty"`
+	CommitHash      *string `json:"commit_hash,omitempty"`
+	ImageRef        *string `json:"image_ref,omitempty"`
+	ErrorMessage    *string `json:"error_message,omitempty"`
+	BuildProgress   any     `json:"build_progress,omitempty"`
+	CreatedAt       string  `json:"created_at"`
+	StartedAt       *string `json:"started_at,omitempty"`
+	FinishedAt      *string `json:"finished_at,omitempty"`
+	DurationSeconds *int64  `json:"duration_seconds,omitempty"`
+}
+
+type ListDeploymentsOutput struct {
+	ServiceID   string           `json:"service_id"`
+	Deployments []DeploymentInfo `json:"deployments"`
+	TotalCount  int64            `json:"total_count"`
+	HasMore     bool             `json:"has_more"`
+}
+
+type GetDeploymentInput struct {
+	Name         string `json:"name" jsonschema:"description=Service name (required)"`
+	Project      string `json:"project,omitempty" jsonschema:"description=Project name,default=default"`
+	DeploymentID string `json:"deployment_id" jsonschema:"description=Deployment ID from list_deployments (required)"`
+	LogLines     int    `json:"log_lines,omitempty" jsonschema:"description=Number of build log lines to fetch (max: 500),default=100"`
+}
+
+type GetDeploymentOutput struct {
+	DeploymentInfo
+	ServiceID string `json:"service_id"`
+	Logs      string `json:"logs,omitempty"`
+}
+
 type ListServicesInput struct{}
 
 type ListServicesOutput struct {
@@ -77,6 +238,16 @@ type RuntimeDetails struct {
 	Logs   string `json:"logs,omitempty"`
 }
 
+type HealthDetails struct {
+	Status        string `json:"status"`
+	Reason        string `json:"reason,omitempty"`
+	Message       string `json:"message,omitempty"`
+	RestartCount  int32  `json:"restart_count"`
+	ExitCode      *int32 `json:"exit_code,omitempty"`
+	LastErrorLine string `json:"last_error_line,omitempty"`
+	Suggestion    string `json:"suggestion,omitempty"`
+}
+
 type ServiceInfo struct {
 	ServiceID  string             `json:"service_id"`
 	Name       string             `json:"name"`
@@ -91,57 +262,91 @@ const (
 )
 
 type CreateResourceInput struct {
-	Name   string `json:"name" jsonschema:"description=Name for the resource (required)"`
-	Type   string `json:"type,omitempty" jsonschema:"description=Resource type,enum=sqlite,default=sqlite"`
-	Size   string `json:"size,omitempty" jsonschema:"description=Size limit for databases,default=100mb"`
-	Region string `json:"region,omitempty" jsonschema:"description=Region,enum=eu-west,default=eu-west"`
+	Name    string `json:"name" jsonschema:"description=Name for the resource (required)"`
+	Project string `json:"project,omitempty" jsonschema:"description=Project name. Created if it doesn't exist,default=default"`
+	Type    string `json:"type,omitempty" jsonschema:"description=Resource type. sqlite is a managed Turso database; postgres and redis run in the project next to its services and start out provisioning,enum=sqlite,enum=postgres,enum=redis,default=sqlite"`
+	Size    string `json:"size,omitempty" jsonschema:"description=Size limit for sqlite (default 100mb); storage tier for postgres: 1gb (default) 5gb 20gb or 50gb; memory limit for redis: 128mb 256mb (default) 512mb or 1gb"`
+	Region  string `json:"region,omitempty" jsonschema:"description=Region. sqlite lives in eu-west; postgres and redis in the cluster region of their services,enum=eu-west,enum=eu-central-1"`
+
+	Persistent bool `json:"persistent,omitempty" jsonschema:"description=redis only. Keep data on disk across restarts and refuse writes when full instead of evicting keys. Without it redis is an in-memory cache"`
 }
 
 type CreateResourceOutput struct {
 	ResourceID string `json:"resource_id"`
 	Name       string `json:"name"`
+	Project    string `json:"project"`
 	Type       string `json:"type"`
 	Region     string `json:"region"`
 	URL        string `json:"database_url"`
-	AuthToken  string `json:"auth_token"`
+	AuthToken  string `json:"auth_token,omitempty"`
 	Status     string `json:"status"`
+	Message    string `json:"message,omitempty"`
 }
 
-type ListResourcesInput struct{}
+type ListResourcesInput struct {
+	Project string `json:"project,omitempty" jsonschema:"description=Only list the resources of this project. Lists every project's when omitted"`
+}
 
 type ListResourcesOutput struct {
 	Resources []ResourceInfo `json:"resources"`
 }
 
 type ResourceInfo struct {
-	ResourceID string `json:"resource_id"`
-	Name       string `json:"name"`
-	Type       string `json:"type"`
-	Region     string `json:"region"`
-	Status     string `json:"status"`
-	CreatedAt  string `json:"created_at"`
+	ResourceID string         `json:"resource_id"`
+	Name       string         `json:"name"`
+	Project    string         `json:"project"`
+	Type       string         `json:"type"`
+	Region     string         `json:"region"`
+	Status     string         `json:"status"`
+	CreatedAt  string         `json:"created_at"`
+	Usage      *ResourceUsage `json:"usage,omitempty"`
+}
+
+// ResourceUsage is a sqlite resource's size against the size it was
+// created with, and the rows read and written this usage period.
+type ResourceUsage struct {
+	StorageBytes   int64  `json:"storage_bytes"`
+	SizeLimitBytes int64  `json:"size_limit_bytes,omitempty"`
+	RowsRead       int64  `json:"rows_read"`
+	RowsWritten    int64  `json:"rows_written"`
+	WritesBlocked  bool   `json:"writes_blocked,omitempty"`
+	Warning        string `json:"warning,omitempty"`
+	UpdatedAt      string `json:"updated_at"`
 }
 
 type GetResourceDetailsInput struct {
-	Name string `json:"name" jsonschema:"description=Resource name (required)"`
+	Name    string `json:"name" jsonschema:"description=Resource name (required)"`
+	Project string `json:"project,omitempty" jsonschema:"description=Project name,default=default"`
 }
 
 type GetResourceDetailsOutput struct {
 	ResourceID  string `json:"resource_id"`
 	Name        string `json:"name"`
+	Project     string `json:"project"`
 	Type        string `json:"type"`
 	Region      string `json:"region"`
-	DatabaseURL string `json:"database_url"`
-	AuthToken   string `json:"auth_token"`
+	DatabaseURL string `json:"database_url,omitempty"`
+	RedisURL    string `json:"redis_url,omitempty"`
+	AuthToken   string `json:"auth_token,omitempty"`
 	Status      string `json:"status"`
 	CreatedAt   string `json:"created_at"`
 	UpdatedAt   string `json:"updated_at"`
+	ForkedFrom  string `json:"forked_from,omitempty"`
+	ForkedAt    string `json:"forked_at,omitempty"`
+	RestoredTo  string `json:"restored_to,omitempty"`
+	RestoredAt  string `json:"restored_at,omitempty"`
+	// ReplacedDatabase is set while a restore waits for finish_restore.
+	ReplacedDatabase string `json:"replaced_database,omitempty"`
+
+	Usage *ResourceUsage `json:"usage,omitempty"`
 }
 
 const (
 	DefaultRegion = "eu-west"
 	DefaultDBType = "sqlite"
 	DefaultDBSize = "100mb"
+
+	DefaultReadOnlyTokenDays = 7
 )
 
 type GetServiceInput struct {
@@ -150,6 +355,7 @@ type GetServiceInput struct {
 	IncludeEnv      bool   `json:"include_env,omitempty" jsonschema:"description=Include environment variables,default=false"`
 	DeployLogLines  int    `json:"deploy_log_lines,omitempty" jsonschema:"description=Number of deployment log lines to fetch (max: 500),default=0"`
 	RuntimeLogLines int    `json:"runtime_log_lines,omitempty" jsonschema:"description=Number of runtime log lines to fetch (max: 500),default=0"`
+	Diagnose        bool   `json:"diagnose,omitempty" jsonschema:"description=Inspect the running instances and classify why the service is unhealthy. Takes up to 20s. Always done when the latest deployment failed.,default=false"`
 }
 
 type CustomDomainDetails struct {
@@ -161,21 +367,27 @@ type CustomDomainDetails struct {
 type GetServiceOutput struct {
 	Deployment   *DeploymentDetails   `json:"deployment,omitempty"`
 	Runtime      *RuntimeDetails      `json:"runtime,omitempty"`
+	Health       *HealthDetails       `json:"health,omitempty"`
 	ServiceID    string               `json:"service_id"`
 	Name         string               `json:"name"`
 	Project      string               `json:"project"`
 	Repo         string               `json:"repo"`
 	Branch       string               `json:"branch"`
 	URL          *string              `json:"url,omitempty"`
+	SleepAfter   string               `json:"sleep_after,omitempty"`
 	CreatedAt    string               `json:"created_at"`
 	UpdatedAt    string               `json:"updated_at"`
 	EnvVars      []EnvVarInfo         `json:"env_vars,omitempty"`
 	CustomDomain *CustomDomainDetails `json:"custom_domain,omitempty"`
 }
 
+// EnvVarInfo shows an env var as stored: a value referencing resource
+// credentials keeps the reference, never the secret it resolves to.
 type EnvVarInfo struct {
-	Key   string `json:"key"`
-	Value string `json:"value,omitempty"`
+	Key        string   `json:"key"`
+	Value      string   `json:"value,omitempty"`
+	Scope      string   `json:"scope"`
+	References []string `json:"references,omitempty"`
 }
 
 const MaxLogLines = 500
@@ -192,7 +404,8 @@ type DeleteServiceOutput struct {
 }
 
 type DeleteResourceInput struct {
-	Name string `json:"name" jsonschema:"description=Resource name (required)"`
+	Name    string `json:"name" jsonschema:"description=Resource name (required)"`
+	Project string `json:"project,omitempty" jsonschema:"description=Project name,default=default"`
 }
 
 type DeleteResourceOutput struct {
@@ -201,6 +414,80 @@ type DeleteResourceOutput struct {
 	Message    string `json:"message"`
 }
 
+type ForkResourceInput struct {
+	Source    string `json:"source" jsonschema:"description=Name of the sqlite resource to copy (required)"`
+	Project   string `json:"project,omitempty" jsonschema:"description=Project of the source. The copy is created in the same project,default=default"`
+	Name      string `json:"name" jsonschema:"description=Name for the copy (required)"`
+	Size      string `json:"size,omitempty" jsonschema:"description=Declared size of the copy such as 1gb. Defaults to the source's size"`
+	Timestamp string `json:"timestamp,omitempty" jsonschema:"description=Copy the data as it was at this RFC 3339 time (e.g. 2025-01-02T15:04:05Z) instead of now"`
+}
+
+type ForkResourceOutput struct {
+	ResourceID string `json:"resource_id"`
+	Name       string `json:"name"`
+	ForkedFrom string `json:"forked_from"`
+	ForkedAt   string `json:"forked_at"`
+	URL        string `json:"database_url"`
+	AuthToken  string `json:"auth_token"`
+	Status     string `json:"status"`
+}
+
+type RestoreResourceInput struct {
+	Name      string `json:"name" jsonschema:"description=Name of the sqlite resource to restore (required)"`
+	Project   string `json:"project,omitempty" jsonschema:"description=Project name,default=default"`
+	Timestamp string `json:"timestamp" jsonschema:"description=RFC 3339 time to roll the data back to (e.g. 2025-01-02T15:04:05Z) (required)"`
+}
+
+type RestoreResourceOutput struct {
+	ResourceID string `json:"resource_id"`
+	Name       string `json:"name"`
+	RestoredTo string `json:"restored_to"`
+	URL        string `json:"database_url"`
+	AuthToken  string `json:"auth_token"`
+	Message    string `json:"message"`
+}
+
+type FinishRestoreInput struct {
+	Name    string `json:"name" jsonschema:"description=Name of the restored sqlite resource (required)"`
+	Project string `json:"project,omitempty" jsonschema:"description=Project name,default=default"`
+	Undo    bool   `json:"undo,omitempty" jsonschema:"description=Switch back to the database from before the restore and delete the restored one instead"`
+}
+
+type FinishRestoreOutput struct {
+	ResourceID string `json:"resource_id"`
+	Name       string `json:"name"`
+	URL        string `json:"database_url"`
+	AuthToken  string `json:"auth_token"`
+	Message    string `json:"message"`
+}
+
+type RotateResourceCredentialsInput struct {
+	Name          string `json:"name" jsonschema:"description=Name of the sqlite or postgres resource (required). Redis resources are rejected"`
+	Project       string `json:"project,omitempty" jsonschema:"description=Project name,default=default"`
+	InvalidateOld bool   `json:"invalidate_old,omitempty" jsonschema:"description=Revoke every token issued before (read-only ones included) instead of letting them run until they expire. Use it when a token leaked. Postgres always invalidates the old password"`
+}
+
+type RotateResourceCredentialsOutput struct {
+	ResourceID string `json:"resource_id"`
+	Name       string `json:"name"`
+	URL        string `json:"database_url"`
+	AuthToken  string `json:"auth_token"`
+	Message    string `json:"message"`
+}
+
+type CreateReadOnlyTokenInput struct {
+	Name          string `json:"name" jsonschema:"description=Name of the sqlite resource (required). Postgres and redis resources are rejected"`
+	Project       string `json:"project,omitempty" jsonschema:"description=Project name,default=default"`
+	ExpiresInDays int    `json:"expires_in_days,omitempty" jsonschema:"description=Days until the token expires,minimum=1,maximum=365,default=7"`
+}
+
+type CreateReadOnlyTokenOutput struct {
+	Name      string `json:"name"`
+	URL       string `json:"database_url"`
+	AuthToken string `json:"auth_token"`
+	ExpiresAt string `json:"expires_at"`
+}
+
 // Unified repo tools
 
 type CreateRepoInput struct {
@@ -228,6 +515,56 @@ type GetGitTokenOutput struct {
 	ExpiresAt string `json:"expires_at"`
 }
 
+type SetRepoPolicyInput struct {
+	Name              string   `json:"name" jsonschema:"description=Repository name (e.g. 'myapp')"`
+	Project           string   `json:"project,omitempty" jsonschema:"description=Project name,default=default"`
+	ProtectedBranches []string `json:"protected_branches,omitempty" jsonschema:"description=Branch names or globs (e.g. 'main' or 'release/*') that refuse force pushes and deletes. Replaces the current list; pass an empty list to protect nothing. Omit to keep it."`
+	MaxPackMB         *int     `json:"max_pack_mb,omitempty" jsonschema:"description=Largest pack one push may send in MiB. 0 restores the server default. Omit to keep it."`
+	MaxFileMB         *int     `json:"max_file_mb,omitempty" jsonschema:"description=Largest file a push may add in MiB. 0 restores the server default. Omit to keep it."`
+}
+
+type SetRepoPolicyOutput struct {
+	Repo              string   `json:"repo"`
+	ProtectedBranches []string `json:"protected_branches"`
+	MaxPackMB         *int     `json:"max_pack_mb,omitempty"`
+	MaxFileMB         *int     `json:"max_file_mb,omitempty"`
+	Message           string   `json:"message"`
+}
+
+type GetUploadURLInput struct {
+	Name    string `json:"name" jsonschema:"description=Name of the upload service"`
+	Project string `json:"project,omitempty" jsonschema:"description=Project name,default=default"`
+}
+
+type GetUploadURLOutput struct {
+	UploadURL string `json:"upload_url"`
+	Command   string `json:"command"`
+	ExpiresAt string `json:"expires_at"`
+}
+
+type ListReposInput struct {
+	Project string `json:"project,omitempty" jsonschema:"description=Only list the repos of this project. Omit to list all."`
+}
+
+// RepoInfo is an ml.ink repo and its size on disk as of its last push or
+// garbage collection.
+type RepoInfo struct {
+	Name              string   `json:"name"`
+	Project           string   `json:"project"`
+	FullName          string   `json:"full_name"`
+	SizeBytes         int64    `json:"size_bytes"`
+	SizeUpdatedAt     string   `json:"size_updated_at,omitempty"`
+	ProtectedBranches []string `json:"protected_branches"`
+	CreatedAt         string   `json:"created_at"`
+}
+
+type ListReposOutput struct {
+	Repos          []RepoInfo `json:"repos"`
+	UsedBytes      int64      `json:"used_bytes"`
+	RepoQuotaBytes int64      `json:"repo_quota_bytes"`
+	UserQuotaBytes int64      `json:"user_quota_bytes"`
+}
+
 // Custom domain (backed by delegated zones)
 
 type AddCustomDomainInput struct {
@@ -300,3 +637,47 @@ type DelegationInfo struct {
 type ListDelegationsOutput struct {
 	Delegations []DelegationInfo `json:"delegations"`
 }
+
+type ProjectInfo struct {
+	ProjectID string `json:"project_id"`
+	Name      string `json:"name"`
+	IsDefault bool   `json:"is_default"`
+	CreatedAt string `json:"created_at"`
+}
+
+type ListProjectsInput struct{}
+
+type ListProjectsOutput struct {
+	Projects []ProjectInfo `json:"projects"`
+}
+
+type CreateProjectInput struct {
+	Name string `json:"name" jsonschema:"description=Project name: up to 20 lowercase letters and digits and dashes (required)"`
+}
+
+type CreateProjectOutput struct {
+	ProjectID string `json:"project_id"`
+	Name      string `json:"name"`
+	Message   string `json:"message"`
+}
+
+type RenameProjectInput struct {
+	Project string `json:"project" jsonschema:"description=Current project name (required)"`
+	NewName string `json:"new_name" jsonschema:"description=New project name (required)"`
+}
+
+type RenameProjectOutput struct {
+	ProjectID string `json:"project_id"`
+	Name      string `json:"name"`
+	Message   string `json:"message"`
+}
+
+type DeleteProjectInput struct {
+	Project string `json:"project" jsonschema:"description=Project name (required)"`
+}
+
+type DeleteProjectOutput struct {
+	ProjectID string `json:"project_id"`
+	Name      string `json:"name"`
+	Message   string `json:"message"`
+}
@@ -18,6 +18,7 @@ type ServiceMetrics struct {
 	MemoryUsageMB              MetricSeries
 	NetworkReceiveBytesPerSec  MetricSeries
 	NetworkTransmitBytesPerSec MetricSeries
+	ReplicaCount               MetricSeries
 }
 
 func (c *Client) GetServiceMetrics(ctx context.Context, namespace, serviceName string, start, end time.Time, step string) (*ServiceMetrics, error) {
@@ -44,6 +45,13 @@ func (c *Client) GetServiceMetrics(ctx context.Context, namespace, serviceName s
 		`sum(rate(container_network_transmit_bytes_total{namespace="%s", pod=~"%s-.*"}[5m]))`,
 		namespace, serviceName,
 	)
+	// Count pods via their pod-level cgroup series, matching the Deployment's
+	// <name>-<replicaset>-<pod> naming so similarly prefixed services aren't
+	// counted.
+	replicaQuery := fmt.Sprintf(
+		`count(container_memory_working_set_bytes{job="kubelet", namespace="%s", pod=~"%s-[a-z0-9]+-[a-z0-9]+", container=""})`,
+		namespace, serviceName,
+	)
 
 	var result ServiceMetrics
 	g, gCtx := errgroup.WithContext(ctx)
@@ -87,9 +95,32 @@ func (c *Client) GetServiceMetrics(ctx context.Context, namespace, serviceName s
 		return nil
 	})
 
+	g.Go(func() error {
+		points, err := c.QueryRange(gCtx, replicaQuery, startStr, endStr, step)
+		if err != nil {
+			return fmt.Errorf("replica count query: %w", err)
+		}
+		result.ReplicaCount = MetricSeries{Metric: "replica_count", DataPoints: points}
+		return nil
+	})
+
 	if err := g.Wait(); err != nil {
 		return nil, fmt.Errorf("metrics temporarily unavailable: %w", err)
 	}
 
 	return &result, nil
 }
+
+// GetNetworkReceiveRate returns a service's inbound bytes per second across
+// all of its pods, used to decide whether it has gone idle.
+func (c *Client) GetNetworkReceiveRate(ctx context.Context, namespace, serviceName string, start, end time.Time, step string) ([]DataPoint, error) {
+	query := fmt.Sprintf(
+		`sum(rate(container_network_receive_bytes_total{namespace="%s", pod=~"%s-[a-z0-9]+-[a-z0-9]+"}[5m]))`,
+		namespace, serviceName,
+	)
+	points, err := c.QueryRange(ctx, query, fmt.Sprintf("%d", start.Unix()), fmt.Sprintf("%d", end.Unix()), step)
+	if err != nil {
+		return nil, fmt.Errorf("network rx query: %w", err)
+	}
+	return points, nil
+}
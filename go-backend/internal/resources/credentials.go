@@ -0,0 +1,171 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	dbresources "github.com/augustdev/autoclip/internal/storage/pg/generated/resources"
+	"go.temporal.io/sdk/client"
+)
+
+// MaxReadOnlyTokenDays caps how long a read-only token stays valid.
+const MaxReadOnlyTokenDays = 365
+
+// RotateCredentials replaces the credentials of a sqlite or postgres
+// resource. A sqlite resource gets a new auth token: with invalidateOld
+// every token issued before, read-only ones included, stops working first;
+// otherwise they stay valid until they expire. A postgres resource gets a
+// new password, and the old one always stops working. Services using the
+// resource need a redeploy to pick the new credentials up.
+func (s *Service) RotateCredentials(ctx context.Context, userID, projectRef, name string, invalidateOld bool) (*ProvisionDatabaseOutput, error) {
+	resource, err := s.GetResourceByName(ctx, userID, projectRef, name)
+	if err != nil {
+		return nil, err
+	}
+	if resource.Type == TypePostgres {
+		return s.rotatePostgresPassword(ctx, resource)
+	}
+	if err := checkTursoResource(resource, "rotated"); err != nil {
+		return nil, err
+	}
+	if resource.Credentials == nil {
+		return nil, fmt.Errorf("resource %s has no credentials to rotate", name)
+	}
+	dbName := tursoDBNameOf(resource)
+
+	s.logger.Info("rotating credentials",
+		"user_id", userID,
+		"resource_id", resource.ID,
+		"invalidate_old", invalidateOld,
+	)
+
+	if invalidateOld {
+		if err := s.tursoClient.RotateAuthTokens(ctx, dbName); err != nil {
+			return nil, err
+		}
+	}
+	authToken, err := s.tursoClient.CreateAuthToken(ctx, dbName, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	creds := &Credentials{URL: resource.Credentials.URL, AuthToken: authToken}
+	encryptedCreds, err := encryptCredentials(creds, s.authConfig.APIKeyEncryptionKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt credentials: %w", err)
+	}
+
+	metadata := resource.Metadata
+	if metadata == nil {
+		metadata = make(map[string]string)
+	}
+	metadata["rotated_at"] = time.Now().UTC().Format(time.RFC3339)
+	metadataJSON, err := json.Marshal(metadata)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal metadata: %w", err)
+	}
+
+	if err := s.resourcesQ.UpdateResourceCredentials(ctx, dbresources.UpdateResourceCredentialsParams{
+		ID:          resource.ID,
+		Credentials: []byte(encryptedCreds),
+		ExternalID:  resource.ExternalID,
+		Metadata:    metadataJSON,
+		Status:      resource.Status,
+	}); err != nil {
+		// With invalidateOld the stored token no longer works either, so
+		// the resource is unusable until a rotation succeeds.
+		return nil, fmt.Errorf("failed to save rotated credentials: %w", err)
+	}
+
+	s.logger.Info("credentials rotated", "resource_id", resource.ID)
+
+	return &ProvisionDatabaseOutput{
+		ResourceID: resource.ID,
+		Name:       resource.Name,
+		Type:       resource.Type,
+		Region:     resource.Region,
+		URL:        creds.URL,
+		AuthToken:  authToken,
+		Status:     resource.Status,
+	}, nil
+}
+
+// rotatePostgresPassword has the region's worker, which can reach the
+// database, change the password and store the new connection string, and
+// waits for it to finish.
+func (s *Service) rotatePostgresPassword(ctx context.Context, resource *Resource) (*ProvisionDatabaseOutput, error) {
+	if resource.Status != StatusActive {
+		return nil, fmt.Errorf("resource %s is %s; only active resources can be rotated", resource.Name, resource.Status)
+	}
+	cluster, ok := s.clusters[resource.Region]
+	if !ok {
+		return nil, fmt.Errorf("unknown region %q for resource %s", resource.Region, resource.ID)
+	}
+
+	s.logger.Info("rotating postgres password", "user_id", resource.UserID, "resource_id", resource.ID)
+
+	run, err := s.temporalClient.ExecuteWorkflow(ctx, client.StartWorkflowOptions{
+		ID:        rotateWorkflowID(resource.ID),
+		TaskQueue: cluster.TaskQueue,
+	}, RotatePostgresPasswordWorkflowName, PostgresWorkflowInput{
+		ResourceID: resource.ID,
+		Tenant:     resource.UserID,
+		ProjectRef: resource.Project,
+		Name:       resource.Name,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to start rotation: %w", err)
+	}
+	if err := run.Get(ctx, nil); err != nil {
+		return nil, fmt.Errorf("failed to rotate password: %w", err)
+	}
+
+	rotated, err := s.GetResource(ctx, resource.UserID, resource.ID)
+	if err != nil {
+		return nil, err
+	}
+	if rotated.Credentials == nil {
+		return nil, fmt.Errorf("resource %s has no credentials after rotation", resource.Name)
+	}
+
+	s.logger.Info("credentials rotated", "resource_id", resource.ID)
+
+	return &ProvisionDatabaseOutput{
+		ResourceID: rotated.ID,
+		Name:       rotated.Name,
+		Type:       rotated.Type,
+		Region:     rotated.Region,
+		URL:        rotated.Credentials.URL,
+		Status:     rotated.Status,
+	}, nil
+}
+
+// CreateReadOnlyToken issues a token for a sqlite resource that can only
+// read, valid for days days. It isn't stored: it's meant to be handed to
+// something outside the platform, such as an analytics tool.
+func (s *Service) CreateReadOnlyToken(ctx context.Context, userID, projectRef, name string, days int) (*Credentials, error) {
+	if days < 1 || days > MaxReadOnlyTokenDays {
+		return nil, fmt.Errorf("expiry must be between 1 and %d days", MaxReadOnlyTokenDays)
+	}
+	resource, err := s.GetResourceByName(ctx, userID, projectRef, name)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkTursoResource(resource, "given read-only tokens"); err != nil {
+		return nil, err
+	}
+	if resource.Credentials == nil {
+		return nil, fmt.Errorf("resource %s has no connection URL", name)
+	}
+
+	token, err := s.tursoClient.CreateReadOnlyToken(ctx, tursoDBNameOf(resource), fmt.Sprintf("P%dD", days))
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("read-only token issued", "user_id", userID, "resource_id", resource.ID, "days", days)
+
+	return &Credentials{URL: resource.Credentials.URL, AuthToken: token}, nil
+}
@@ -12,7 +12,12 @@ import (
 )
 
 func encryptCredentials(creds *Credentials, encryptionKey string) (string, error) {
-	plaintext, err := json.Marshal(creds)
+	return encryptJSON(creds, encryptionKey)
+}
+
+// encryptJSON seals v with AES-GCM under a key derived from encryptionKey.
+func encryptJSON(v any, encryptionKey string) (string, error) {
+	plaintext, err := json.Marshal(v)
 	if err != nil {
 		return "", fmt.Errorf("failed to marshal credentials: %w", err)
 	}
@@ -39,37 +44,74 @@ func encryptCredentials(creds *Credentials, encryptionKey string) (string, error
 }
 
 func decryptCredentials(encrypted string, encryptionKey string) (*Credentials, error) {
+	var creds Credentials
+	if err := decryptJSON(encrypted, encryptionKey, &creds); err != nil {
+		return nil, err
+	}
+	return &creds, nil
+}
+
+func decryptJSON(encrypted string, encryptionKey string, v any) error {
 	ciphertext, err := base64.StdEncoding.DecodeString(encrypted)
 	if err != nil {
-		return nil, fmt.Errorf("failed to decode base64: %w", err)
+		return fmt.Errorf("failed to decode base64: %w", err)
 	}
 
 	key := sha256.Sum256([]byte(encryptionKey))
 
 	block, err := aes.NewCipher(key[:])
 	if err != nil {
-		return nil, fmt.Errorf("failed to create cipher: %w", err)
+		return fmt.Errorf("failed to create cipher: %w", err)
 	}
 
 	gcm, err := cipher.NewGCM(block)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create GCM: %w", err)
+		return fmt.Errorf("failed to create GCM: %w", err)
 	}
 
 	if len(ciphertext) < gcm.NonceSize() {
-		return nil, fmt.Errorf("ciphertext too short")
+		return fmt.Errorf("ciphertext too short")
 	}
 
 	nonce, ciphertext := ciphertext[:gcm.NonceSize()], ciphertext[gcm.NonceSize():]
 	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to decrypt: %w", err)
+		return fmt.Errorf("failed to decrypt: %w", err)
 	}
 
-	var creds Credentials
-	if err := json.Unmarshal(plaintext, &creds); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal credentials: %w", err)
+	if err := json.Unmarshal(plaintext, v); err != nil {
+		return fmt.Errorf("failed to unmarshal credentials: %w", err)
 	}
+	return nil
+}
 
-	return &creds, nil
+// DecryptCredentials decrypts a resource's stored credentials for callers
+// outside this package that inject them, such as the deploy worker.
+func DecryptCredentials(encrypted []byte, encryptionKey string) (*Credentials, error) {
+	return decryptCredentials(string(encrypted), encryptionKey)
+}
+
+// EncryptCredentials encrypts credentials for storage by callers outside
+// this package that provision resources, such as the deploy worker.
+func EncryptCredentials(creds *Credentials, encryptionKey string) ([]byte, error) {
+	encrypted, err := encryptCredentials(creds, encryptionKey)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(encrypted), nil
+}
+
+// EncryptSecret encrypts other credentials kept at rest the way resource
+// credentials are, such as a service's registry login.
+func EncryptSecret(v any, encryptionKey string) ([]byte, error) {
+	encrypted, err := encryptJSON(v, encryptionKey)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(encrypted), nil
+}
+
+// DecryptSecret decrypts what EncryptSecret stored into v.
+func DecryptSecret(encrypted []byte, encryptionKey string, v any) error {
+	return decryptJSON(string(encrypted), encryptionKey, v)
 }
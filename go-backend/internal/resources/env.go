@@ -0,0 +1,108 @@
+package resources
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+var nonEnvChars = regexp.MustCompile(`[^A-Z0-9]+`)
+
+// EnvVars returns the env vars a service bound to a resource gets. prefix is
+// empty when the resource is the service's only one. Postgres carries its
+// password in the URL, so only resources with a separate token get
+// DATABASE_AUTH_TOKEN. Redis gets REDIS_URL, the name its clients look for.
+func EnvVars(prefix, resourceType string, creds *Credentials) map[string]string {
+	if resourceType == TypeRedis {
+		return map[string]string{prefix + "REDIS_URL": creds.URL}
+	}
+	env := map[string]string{prefix + "DATABASE_URL": creds.URL}
+	if creds.AuthToken != "" {
+		env[prefix+"DATABASE_AUTH_TOKEN"] = creds.AuthToken
+	}
+	return env
+}
+
+// EnvVarNames lists the env vars EnvVars sets for a resource of the type.
+func EnvVarNames(prefix, resourceType string) []string {
+	switch resourceType {
+	case TypeSQLite:
+		return []string{prefix + "DATABASE_AUTH_TOKEN", prefix + "DATABASE_URL"}
+	case TypeRedis:
+		return []string{prefix + "REDIS_URL"}
+	}
+	return []string{prefix + "DATABASE_URL"}
+}
+
+// EnvPrefix keeps the env vars of several resources bound to one service
+// apart: "main-db" becomes MAIN_DB_DATABASE_URL.
+func EnvPrefix(name string) string {
+	prefix := strings.Trim(nonEnvChars.ReplaceAllString(strings.ToUpper(name), "_"), "_")
+	if prefix == "" {
+		return ""
+	}
+	if prefix[0] >= '0' && prefix[0] <= '9' {
+		prefix = "R_" + prefix
+	}
+	return prefix + "_"
+}
+
+// envRefPattern matches a reference to a resource's credentials in an env
+// var value, such as ${{resources.main-db.auth_token}}.
+var envRefPattern = regexp.MustCompile(`\$\{\{\s*resources\.([A-Za-z0-9_-]+)\.([a-z_]+)\s*\}\}`)
+
+// EnvRef is a reference to one credential field of a resource by name.
+type EnvRef struct {
+	Resource string
+	Field    string
+}
+
+// EnvRefFields are the credential fields a reference can name.
+var EnvRefFields = []string{"url", "auth_token"}
+
+// ParseEnvRefs returns the resource references in an env var value. It
+// fails on a reference to a field resources don't have.
+func ParseEnvRefs(value string) ([]EnvRef, error) {
+	var refs []EnvRef
+	for _, m := range envRefPattern.FindAllStringSubmatch(value, -1) {
+		if !slices.Contains(EnvRefFields, m[2]) {
+			return nil, fmt.Errorf("invalid reference %s: field must be one of %s", m[0], strings.Join(EnvRefFields, ", "))
+		}
+		refs = append(refs, EnvRef{Resource: m[1], Field: m[2]})
+	}
+	return refs, nil
+}
+
+// HasEnvRefs reports whether value references a resource's credentials.
+func HasEnvRefs(value string) bool {
+	return envRefPattern.MatchString(value)
+}
+
+// ExpandEnvRefs replaces the resource references in value with the
+// credentials lookup returns for each resource.
+func ExpandEnvRefs(value string, lookup func(name string) (*Credentials, error)) (string, error) {
+	if _, err := ParseEnvRefs(value); err != nil {
+		return "", err
+	}
+	var expandErr error
+	expanded := envRefPattern.ReplaceAllStringFunc(value, func(ref string) string {
+		if expandErr != nil {
+			return ref
+		}
+		m := envRefPattern.FindStringSubmatch(ref)
+		creds, err := lookup(m[1])
+		if err != nil {
+			expandErr = fmt.Errorf("resolve %s: %w", ref, err)
+			return ref
+		}
+		if m[2] == "auth_token" {
+			return creds.AuthToken
+		}
+		return creds.URL
+	})
+	if expandErr != nil {
+		return "", expandErr
+	}
+	return expanded, nil
+}
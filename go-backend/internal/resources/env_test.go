@@ -0,0 +1,70 @@
+package resources
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestEnvPrefix(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "main-db", want: "MAIN_DB_"},
+		{name: "cache", want: "CACHE_"},
+		{name: "2nd.db", want: "R_2ND_DB_"},
+		{name: "--", want: ""},
+	}
+	for _, tt := range tests {
+		if got := EnvPrefix(tt.name); got != tt.want {
+			t.Errorf("EnvPrefix(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestEnvVars(t *testing.T) {
+	env := EnvVars("MAIN_DB_", TypeSQLite, &Credentials{URL: "libsql://db", AuthToken: "token"})
+	if env["MAIN_DB_DATABASE_URL"] != "libsql://db" || env["MAIN_DB_DATABASE_AUTH_TOKEN"] != "token" || len(env) != 2 {
+		t.Fatalf("EnvVars() = %v", env)
+	}
+	env = EnvVars("", TypeRedis, &Credentials{URL: "redis://cache"})
+	if env["REDIS_URL"] != "redis://cache" || len(env) != 1 {
+		t.Fatalf("EnvVars(redis) = %v", env)
+	}
+}
+
+func TestExpandEnvRefs(t *testing.T) {
+	lookup := func(name string) (*Credentials, error) {
+		if name != "main-db" {
+			return nil, fmt.Errorf("not found")
+		}
+		return &Credentials{URL: "libsql://main", AuthToken: "secret"}, nil
+	}
+	tests := []struct {
+		name    string
+		value   string
+		want    string
+		wantErr string
+	}{
+		{name: "plain value", value: "production", want: "production"},
+		{name: "url", value: "${{resources.main-db.url}}", want: "libsql://main"},
+		{name: "embedded with spaces", value: "Bearer ${{ resources.main-db.auth_token }}", want: "Bearer secret"},
+		{name: "unknown field", value: "${{resources.main-db.password}}", wantErr: "field must be one of"},
+		{name: "unknown resource", value: "${{resources.other.url}}", wantErr: "not found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ExpandEnvRefs(tt.value, lookup)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ExpandEnvRefs() error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Fatalf("ExpandEnvRefs() = %q, %v, want %q", got, err, tt.want)
+			}
+		})
+	}
+}
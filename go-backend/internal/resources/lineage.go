@@ -0,0 +1,334 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"strconv"
+	"strings"
+	"time"
+
+	dbresources "github.com/augustdev/autoclip/internal/storage/pg/generated/resources"
+	"github.com/augustdev/autoclip/internal/turso"
+)
+
+// ForkDatabase creates a sqlite resource holding a copy of another one's
+// data, as it is now or as it was at input.Timestamp. The copy lives in the
+// source's project and records where it came from in its metadata.
+func (s *Service) ForkDatabase(ctx context.Context, input ForkDatabaseInput) (*ProvisionDatabaseOutput, error) {
+	if err := validateResourceName(input.Name); err != nil {
+		return nil, err
+	}
+	source, project, err := s.getResourceInProject(ctx, input.UserID, input.Project, input.Source)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkTursoResource(source, "forked"); err != nil {
+		return nil, err
+	}
+	if err := s.checkNameFree(ctx, project, input.Name); err != nil {
+		return nil, err
+	}
+
+	seed := &turso.Seed{Type: turso.SeedDatabase, Name: tursoDBNameOf(source)}
+	forkedAt := time.Now().UTC()
+	if input.Timestamp != nil {
+		if err := checkRestorePoint(source, *input.Timestamp); err != nil {
+			return nil, err
+		}
+		forkedAt = input.Timestamp.UTC()
+		seed.Timestamp = forkedAt.Format(time.RFC3339)
+	}
+
+	s.logger.Info("forking database",
+		"user_id", input.UserID,
+		"source", source.Name,
+		"name", input.Name,
+		"timestamp", seed.Timestamp,
+	)
+
+	size := input.Size
+	if size == "" {
+		size = source.Metadata["size"]
+	}
+
+	return s.provisionTurso(ctx, ProvisionDatabaseInput{
+		UserID: input.UserID,
+		Name:   input.Name,
+		Type:   TypeSQLite,
+		Size:   size,
+		Region: source.Region,
+	}, project, seed, Metadata{
+		ForkedFrom:   source.Name,
+		ForkedFromID: source.ID,
+		ForkedAt:     forkedAt.Format(time.RFC3339),
+	})
+}
+
+// RestoreDatabase rolls a sqlite resource back to its state at
+// input.Timestamp. Turso can't restore in place, so a new database is
+// created from that point in time and takes over the resource, with new
+// credentials. The old one is kept until FinishRestore, so services still
+// using its credentials keep working until their redeploy and a restore to
+// the wrong point in time can be undone. Services using the resource need
+// a redeploy to pick the new credentials up.
+func (s *Service) RestoreDatabase(ctx context.Context, input RestoreDatabaseInput) (*ProvisionDatabaseOutput, error) {
+	resource, project, err := s.getResourceInProject(ctx, input.UserID, input.Project, input.Name)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkTursoResource(resource, "restored"); err != nil {
+		return nil, err
+	}
+	if replaced := resource.Metadata["replaced_database"]; replaced != "" {
+		return nil, fmt.Errorf("resource %s still keeps %s from its last restore; finish or undo that restore first", resource.Name, replaced)
+	}
+	if err := checkRestorePoint(resource, input.Timestamp); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	oldDBName := tursoDBNameOf(resource)
+	newDBName := restoredTursoDBName(input.UserID, tursoProjectRef(project), resource.Name, now)
+	restoredTo := input.Timestamp.UTC().Format(time.RFC3339)
+
+	s.logger.Info("restoring database",
+		"user_id", input.UserID,
+		"resource_id", resource.ID,
+		"from", oldDBName,
+		"to", newDBName,
+		"timestamp", restoredTo,
+	)
+
+	db, err := s.tursoClient.CreateDatabase(ctx, &turso.CreateDatabaseRequest{
+		Name:      newDBName,
+		Group:     resource.Metadata["group"],
+		SizeLimit: resource.Metadata["size"],
+		Seed: &turso.Seed{
+			Type:      turso.SeedDatabase,
+			Name:      oldDBName,
+			Timestamp: restoredTo,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create restored Turso database: %w", err)
+	}
+
+	authToken, err := s.tursoClient.CreateAuthToken(ctx, newDBName, nil)
+	if err != nil {
+		_ = s.tursoClient.DeleteDatabase(ctx, newDBName)
+		return nil, fmt.Errorf("failed to create auth token: %w", err)
+	}
+
+	url := fmt.Sprintf("libsql://%s", db.Hostname)
+	encryptedCreds, err := encryptCredentials(&Credentials{URL: url, AuthToken: authToken}, s.authConfig.APIKeyEncryptionKey)
+	if err != nil {
+		_ = s.tursoClient.DeleteDatabase(ctx, newDBName)
+		return nil, fmt.Errorf("failed to encrypt credentials: %w", err)
+	}
+
+	metadata := maps.Clone(resource.Metadata)
+	if metadata == nil {
+		metadata = make(map[string]string)
+	}
+	keepReplaced(metadata, oldDBName)
+	metadata["hostname"] = db.Hostname
+	metadata["database_name"] = newDBName
+	metadata["restored_to"] = restoredTo
+	metadata["restored_at"] = now.UTC().Format(time.RFC3339)
+	metadataJSON, err := json.Marshal(metadata)
+	if err != nil {
+		_ = s.tursoClient.DeleteDatabase(ctx, newDBName)
+		return nil, fmt.Errorf("failed to marshal metadata: %w", err)
+	}
+
+	if err := s.resourcesQ.UpdateResourceCredentials(ctx, dbresources.UpdateResourceCredentialsParams{
+		ID:          resource.ID,
+		Credentials: []byte(encryptedCreds),
+		ExternalID:  &db.DbID,
+		Metadata:    metadataJSON,
+		Status:      StatusActive,
+	}); err != nil {
+		_ = s.tursoClient.DeleteDatabase(ctx, newDBName)
+		return nil, fmt.Errorf("failed to save restored resource: %w", err)
+	}
+
+	s.logger.Info("database restored", "resource_id", resource.ID, "url", url, "replaced", oldDBName)
+
+	return &ProvisionDatabaseOutput{
+		ResourceID: resource.ID,
+		Name:       resource.Name,
+		Type:       resource.Type,
+		Region:     resource.Region,
+		URL:        url,
+		AuthToken:  authToken,
+		Status:     StatusActive,
+	}, nil
+}
+
+// FinishRestore ends a restore of a sqlite resource. It deletes the
+// database the restore replaced, or with input.Undo switches the resource
+// back to it, with new credentials, and deletes the restored one instead.
+func (s *Service) FinishRestore(ctx context.Context, input FinishRestoreInput) (*ProvisionDatabaseOutput, error) {
+	resource, _, err := s.getResourceInProject(ctx, input.UserID, input.Project, input.Name)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkTursoResource(resource, "restored"); err != nil {
+		return nil, err
+	}
+	replaced := resource.Metadata["replaced_database"]
+	if replaced == "" {
+		return nil, fmt.Errorf("resource %s has no restore to finish", resource.Name)
+	}
+	if resource.Credentials == nil {
+		return nil, fmt.Errorf("resource %s has no credentials", resource.Name)
+	}
+
+	metadata := maps.Clone(resource.Metadata)
+	creds := resource.Credentials
+	externalID := resource.ExternalID
+	drop := replaced
+	if input.Undo {
+		db, err := s.tursoClient.GetDatabase(ctx, replaced)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get replaced Turso database: %w", err)
+		}
+		authToken, err := s.tursoClient.CreateAuthToken(ctx, replaced, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create auth token: %w", err)
+		}
+		drop = tursoDBNameOf(resource)
+		creds = &Credentials{URL: fmt.Sprintf("libsql://%s", db.Hostname), AuthToken: authToken}
+		externalID = &db.DbID
+		metadata["hostname"] = db.Hostname
+		undoReplaced(metadata)
+	} else {
+		clearReplaced(metadata)
+	}
+
+	encryptedCreds, err := encryptCredentials(creds, s.authConfig.APIKeyEncryptionKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt credentials: %w", err)
+	}
+	metadataJSON, err := json.Marshal(metadata)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal metadata: %w", err)
+	}
+
+	s.logger.Info("finishing restore",
+		"user_id", input.UserID,
+		"resource_id", resource.ID,
+		"undo", input.Undo,
+		"delete", drop,
+	)
+
+	// Services may still hold the credentials of the database being
+	// dropped, so it goes only once the resource no longer points at it.
+	if err := s.resourcesQ.UpdateResourceCredentials(ctx, dbresources.UpdateResourceCredentialsParams{
+		ID:          resource.ID,
+		Credentials: []byte(encryptedCreds),
+		ExternalID:  externalID,
+		Metadata:    metadataJSON,
+		Status:      resource.Status,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to save resource: %w", err)
+	}
+	if err := s.tursoClient.DeleteDatabase(ctx, drop); err != nil {
+		s.logger.Error("failed to delete Turso database", "error", err, "name", drop)
+	}
+
+	return &ProvisionDatabaseOutput{
+		ResourceID: resource.ID,
+		Name:       resource.Name,
+		Type:       resource.Type,
+		Region:     resource.Region,
+		URL:        creds.URL,
+		AuthToken:  creds.AuthToken,
+		Status:     resource.Status,
+	}, nil
+}
+
+// restoreKeys are the lineage metadata a restore overwrites, which undoing
+// it puts back.
+var restoreKeys = []string{"restored_to", "restored_at"}
+
+// keepReplaced records the database a restore replaces, and the metadata
+// describing it, until the restore is finished or undone.
+func keepReplaced(metadata map[string]string, dbName string) {
+	metadata["replaced_database"] = dbName
+	for _, k := range restoreKeys {
+		if v, ok := metadata[k]; ok {
+			metadata["replaced_"+k] = v
+		}
+	}
+}
+
+// undoReplaced points metadata back at the database a restore replaced.
+func undoReplaced(metadata map[string]string) {
+	metadata["database_name"] = metadata["replaced_database"]
+	for _, k := range restoreKeys {
+		if v, ok := metadata["replaced_"+k]; ok {
+			metadata[k] = v
+		} else {
+			delete(metadata, k)
+		}
+	}
+	clearReplaced(metadata)
+}
+
+// clearReplaced forgets the database a restore replaced.
+func clearReplaced(metadata map[string]string) {
+	delete(metadata, "replaced_database")
+	for _, k := range restoreKeys {
+		delete(metadata, "replaced_"+k)
+	}
+}
+
+func checkTursoResource(r *Resource, action string) error {
+	if r.Provider != ProviderTurso {
+		return fmt.Errorf("resource %s is a %s resource; only sqlite resources can be %s", r.Name, r.Type, action)
+	}
+	if r.Status != StatusActive {
+		return fmt.Errorf("resource %s is %s; only active resources can be %s", r.Name, r.Status, action)
+	}
+	return nil
+}
+
+// checkRestorePoint refuses points in time the database has no history
+// for. How far back Turso keeps history depends on the plan, so a point
+// that is too old is left for Turso to reject.
+func checkRestorePoint(r *Resource, at time.Time) error {
+	if at.After(time.Now()) {
+		return fmt.Errorf("timestamp %s is in the future", at.Format(time.RFC3339))
+	}
+	if at.Before(r.CreatedAt) {
+		return fmt.Errorf("timestamp %s is before resource %s was created (%s)", at.Format(time.RFC3339), r.Name, r.CreatedAt.Format(time.RFC3339))
+	}
+	if restoredAt, err := time.Parse(time.RFC3339, r.Metadata["restored_at"]); err == nil && at.Before(restoredAt) {
+		return fmt.Errorf("timestamp %s is before %s was last restored (%s); the history before that was replaced", at.Format(time.RFC3339), r.Name, restoredAt.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// tursoDBNameOf returns the Turso database behind a sqlite resource.
+func tursoDBNameOf(r *Resource) string {
+	if name := r.Metadata["database_name"]; name != "" {
+		return name
+	}
+	// Resources created before the name was recorded are all named the way
+	// default project ones still are.
+	return generateTursoDBName(r.UserID, "", r.Name)
+}
+
+// restoredTursoDBName names the database a restore creates, which has to
+// differ from the one it replaces while both exist.
+func restoredTursoDBName(userID, projectRef, name string, now time.Time) string {
+	suffix := "-r" + strconv.FormatInt(now.Unix(), 36)
+	base := generateTursoDBName(userID, projectRef, name)
+	if len(base) > 64-len(suffix) {
+		base = strings.TrimRight(base[:64-len(suffix)], "-")
+	}
+	return base + suffix
+}
@@ -0,0 +1,124 @@
+package resources
+
+import (
+	"maps"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCheckRestorePoint(t *testing.T) {
+	created := time.Now().Add(-48 * time.Hour)
+	restoredAt := time.Now().Add(-time.Hour)
+	r := &Resource{Name: "main-db", CreatedAt: created}
+	restored := &Resource{
+		Name:      "main-db",
+		CreatedAt: created,
+		Metadata:  map[string]string{"restored_at": restoredAt.UTC().Format(time.RFC3339)},
+	}
+
+	tests := []struct {
+		name     string
+		resource *Resource
+		at       time.Time
+		wantErr  string
+	}{
+		{name: "within history", resource: r, at: time.Now().Add(-24 * time.Hour)},
+		{name: "future", resource: r, at: time.Now().Add(time.Hour), wantErr: "in the future"},
+		{name: "before creation", resource: r, at: created.Add(-time.Minute), wantErr: "before resource main-db was created"},
+		{name: "after last restore", resource: restored, at: time.Now().Add(-30 * time.Minute)},
+		{name: "before last restore", resource: restored, at: time.Now().Add(-2 * time.Hour), wantErr: "last restored"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkRestorePoint(tt.resource, tt.at)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("checkRestorePoint() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("checkRestorePoint() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTursoDBNameOf(t *testing.T) {
+	r := &Resource{UserID: "User1234abcd", Name: "main-db"}
+	if got := tursoDBNameOf(r); got != "user1234-main-db" {
+		t.Fatalf("tursoDBNameOf() = %q, want user1234-main-db", got)
+	}
+
+	if got := generateTursoDBName(r.UserID, "staging", r.Name); got != "user1234-staging-main-db" {
+		t.Fatalf("generateTursoDBName() outside the default project = %q, want user1234-staging-main-db", got)
+	}
+
+	restored := restoredTursoDBName(r.UserID, "", strings.Repeat("x", 70), time.Unix(1700000000, 0))
+	if len(restored) > 64 || !strings.HasSuffix(restored, "-rs44we8") {
+		t.Fatalf("restoredTursoDBName() = %q, want at most 64 characters ending in the restore time", restored)
+	}
+	r.Metadata = map[string]string{"database_name": restored}
+	if got := tursoDBNameOf(r); got != restored {
+		t.Fatalf("tursoDBNameOf() after restore = %q, want %q", got, restored)
+	}
+}
+
+func TestReplacedMetadata(t *testing.T) {
+	original := map[string]string{
+		"size":          "100mb",
+		"database_name": "user1234-main-db-rs1",
+		"restored_to":   "2025-01-01T00:00:00Z",
+		"restored_at":   "2025-01-02T00:00:00Z",
+	}
+
+	restored := maps.Clone(original)
+	keepReplaced(restored, "user1234-main-db-rs1")
+	restored["database_name"] = "user1234-main-db-rs2"
+	restored["restored_to"] = "2025-01-03T00:00:00Z"
+	restored["restored_at"] = "2025-01-04T00:00:00Z"
+
+	undone := maps.Clone(restored)
+	undoReplaced(undone)
+	if !maps.Equal(undone, original) {
+		t.Fatalf("undoReplaced() = %v, want %v", undone, original)
+	}
+
+	finished := maps.Clone(restored)
+	clearReplaced(finished)
+	want := map[string]string{
+		"size":          "100mb",
+		"database_name": "user1234-main-db-rs2",
+		"restored_to":   "2025-01-03T00:00:00Z",
+		"restored_at":   "2025-01-04T00:00:00Z",
+	}
+	if !maps.Equal(finished, want) {
+		t.Fatalf("clearReplaced() = %v, want %v", finished, want)
+	}
+
+	// A first restore has no lineage to put back.
+	first := map[string]string{"size": "100mb"}
+	keepReplaced(first, "user1234-main-db")
+	first["restored_at"] = "2025-01-04T00:00:00Z"
+	undoReplaced(first)
+	if want := map[string]string{"size": "100mb", "database_name": "user1234-main-db"}; !maps.Equal(first, want) {
+		t.Fatalf("undoReplaced() after a first restore = %v, want %v", first, want)
+	}
+}
+
+func TestCheckTursoResource(t *testing.T) {
+	for _, r := range []*Resource{
+		{Name: "main-db", Type: TypePostgres, Provider: ProviderCluster, Status: StatusActive},
+		{Name: "cache", Type: TypeRedis, Provider: ProviderCluster, Status: StatusActive},
+	} {
+		err := checkTursoResource(r, "rotated")
+		if err == nil || !strings.Contains(err.Error(), "only sqlite resources can be rotated") {
+			t.Fatalf("checkTursoResource(%s) error = %v, want sqlite only", r.Type, err)
+		}
+	}
+	r := &Resource{Name: "app-db", Type: TypeSQLite, Provider: ProviderTurso, Status: StatusActive}
+	if err := checkTursoResource(r, "rotated"); err != nil {
+		t.Fatalf("checkTursoResource(sqlite) error = %v", err)
+	}
+}
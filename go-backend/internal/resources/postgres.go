@@ -0,0 +1,68 @@
+package resources
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Postgres resources are provisioned by workflows on the region's deploy
+// worker, which registers them under these names. Their inputs are defined
+// here so this package doesn't depend on the worker's.
+const (
+	ProvisionPostgresWorkflowName = "ProvisionPostgresWorkflow"
+	DeletePostgresWorkflowName    = "DeletePostgresWorkflow"
+
+	RotatePostgresPasswordWorkflowName = "RotatePostgresPasswordWorkflow"
+)
+
+// provisionWorkflowID and deleteWorkflowID keep a resource from being
+// provisioned or torn down twice at once.
+func provisionWorkflowID(resourceID string) string {
+	return "provision-resource-" + resourceID
+}
+
+func deleteWorkflowID(resourceID string) string {
+	return "delete-resource-" + resourceID
+}
+
+// rotateWorkflowID keeps two rotations of one resource from racing.
+func rotateWorkflowID(resourceID string) string {
+	return "rotate-resource-" + resourceID
+}
+
+// PostgresTier is what a postgres size buys: the volume and the limits of
+// the single instance serving it.
+type PostgresTier struct {
+	Storage string `json:"storage"`
+	Memory  string `json:"memory"`
+	VCPUs   string `json:"vcpus"`
+}
+
+// PostgresSizes lists the tiers smallest first.
+var PostgresSizes = []string{"1gb", "5gb", "20gb", "50gb"}
+
+var PostgresTiers = map[string]PostgresTier{
+	"1gb":  {Storage: "1Gi", Memory: "256Mi", VCPUs: "0.25"},
+	"5gb":  {Storage: "5Gi", Memory: "512Mi", VCPUs: "0.5"},
+	"20gb": {Storage: "20Gi", Memory: "1024Mi", VCPUs: "1"},
+	"50gb": {Storage: "50Gi", Memory: "2048Mi", VCPUs: "2"},
+}
+
+// PostgresWorkflowInput locates a postgres resource on the cluster. The
+// worker derives the namespace from Tenant and ProjectRef the same way it
+// does for services, so the database sits next to them.
+type PostgresWorkflowInput struct {
+	ResourceID string       `json:"resource_id"`
+	Tenant     string       `json:"tenant"`
+	ProjectRef string       `json:"project_ref"`
+	Name       string       `json:"name"`
+	Tier       PostgresTier `json:"tier"`
+}
+
+func postgresTier(size string) (PostgresTier, error) {
+	tier, ok := PostgresTiers[size]
+	if !ok {
+		return PostgresTier{}, fmt.Errorf("invalid postgres size %q (supported: %s)", size, strings.Join(PostgresSizes, ", "))
+	}
+	return tier, nil
+}
@@ -0,0 +1,54 @@
+package resources
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Redis resources are provisioned like postgres ones, by workflows the
+// region's deploy worker registers under these names.
+const (
+	ProvisionRedisWorkflowName = "ProvisionRedisWorkflow"
+	DeleteRedisWorkflowName    = "DeleteRedisWorkflow"
+)
+
+// RedisTier is what a redis size buys. MaxMemory is the dataset limit redis
+// enforces itself; Memory leaves the process headroom above it. Storage
+// only applies with persistence and leaves room for rewriting the
+// append-only file.
+type RedisTier struct {
+	MaxMemory string `json:"max_memory"`
+	Memory    string `json:"memory"`
+	VCPUs     string `json:"vcpus"`
+	Storage   string `json:"storage"`
+}
+
+// RedisSizes lists the tiers smallest first.
+var RedisSizes = []string{"128mb", "256mb", "512mb", "1gb"}
+
+var RedisTiers = map[string]RedisTier{
+	"128mb": {MaxMemory: "128mb", Memory: "192Mi", VCPUs: "0.1", Storage: "1Gi"},
+	"256mb": {MaxMemory: "256mb", Memory: "384Mi", VCPUs: "0.25", Storage: "1Gi"},
+	"512mb": {MaxMemory: "512mb", Memory: "768Mi", VCPUs: "0.5", Storage: "2Gi"},
+	"1gb":   {MaxMemory: "1gb", Memory: "1536Mi", VCPUs: "0.5", Storage: "4Gi"},
+}
+
+// RedisWorkflowInput locates a redis resource on the cluster, like
+// PostgresWorkflowInput. Without Persistent the data lives in memory only
+// and is lost when the instance restarts.
+type RedisWorkflowInput struct {
+	ResourceID string    `json:"resource_id"`
+	Tenant     string    `json:"tenant"`
+	ProjectRef string    `json:"project_ref"`
+	Name       string    `json:"name"`
+	Tier       RedisTier `json:"tier"`
+	Persistent bool      `json:"persistent"`
+}
+
+func redisTier(size string) (RedisTier, error) {
+	tier, ok := RedisTiers[size]
+	if !ok {
+		return RedisTier{}, fmt.Errorf("invalid redis size %q (supported: %s)", size, strings.Join(RedisSizes, ", "))
+	}
+	return tier, nil
+}
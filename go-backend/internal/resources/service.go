@@ -9,55 +9,99 @@ import (
 	"strings"
 
 	"github.com/augustdev/autoclip/internal/auth"
+	"github.com/augustdev/autoclip/internal/storage/pg/generated/clusters"
 	"github.com/augustdev/autoclip/internal/storage/pg/generated/projects"
 	dbresources "github.com/augustdev/autoclip/internal/storage/pg/generated/resources"
 	"github.com/augustdev/autoclip/internal/turso"
+	"go.temporal.io/sdk/client"
 )
 
+// tursoAPI is the part of the Turso client sqlite resources use.
+type tursoAPI interface {
+	GetDatabaseUsage(ctx context.Context, dbName string) (*turso.DatabaseUsage, error)
+	GetDatabaseConfiguration(ctx context.Context, dbName string) (*turso.DatabaseConfiguration, error)
+	SetBlockWrites(ctx context.Context, dbName string, block bool) error
+	CreateDatabase(ctx context.Context, req *turso.CreateDatabaseRequest) (*turso.Database, error)
+	GetDatabase(ctx context.Context, dbName string) (*turso.Database, error)
+	DeleteDatabase(ctx context.Context, dbName string) error
+	CreateAuthToken(ctx context.Context, dbName string, req *turso.CreateTokenRequest) (string, error)
+	CreateReadOnlyToken(ctx context.Context, dbName string, expiration string) (string, error)
+	RotateAuthTokens(ctx context.Context, dbName string) error
+}
+
 type Service struct {
-	resourcesQ  dbresources.Querier
-	projectsQ   projects.Querier
-	tursoClient *turso.Client
-	authConfig  auth.Config
-	logger      *slog.Logger
+	resourcesQ     dbresources.Querier
+	projectsQ      projects.Querier
+	tursoClient    tursoAPI
+	temporalClient client.Client
+	clusters       map[string]clusters.Cluster
+	authConfig     auth.Config
+	logger         *slog.Logger
 }
 
 func NewService(
 	resourcesQ dbresources.Querier,
 	projectsQ projects.Querier,
 	tursoClient *turso.Client,
+	temporalClient client.Client,
+	clusters map[string]clusters.Cluster,
 	authConfig auth.Config,
 	logger *slog.Logger,
 ) *Service {
 	return &Service{
-		resourcesQ:  resourcesQ,
-		projectsQ:   projectsQ,
-		tursoClient: tursoClient,
-		authConfig:  authConfig,
-		logger:      logger,
+		resourcesQ:     resourcesQ,
+		projectsQ:      projectsQ,
+		tursoClient:    tursoClient,
+		temporalClient: temporalClient,
+		clusters:       clusters,
+		authConfig:     authConfig,
+		logger:         logger,
 	}
 }
 
 func (s *Service) ProvisionDatabase(ctx context.Context, input ProvisionDatabaseInput) (*ProvisionDatabaseOutput, error) {
-	if input.Type != TypeSQLite {
-		return nil, fmt.Errorf("unsupported database type: %s (only 'sqlite' is supported)", input.Type)
+	if input.Type != TypeSQLite && input.Type != TypePostgres && input.Type != TypeRedis {
+		return nil, fmt.Errorf("unsupported resource type: %s (supported: sqlite, postgres, redis)", input.Type)
 	}
 
-	group, ok := turso.RegionToGroup[input.Region]
-	if !ok {
-		return nil, fmt.Errorf("unsupported region: %s (supported: %v)", input.Region, turso.ValidRegions())
+	if err := validateResourceName(input.Name); err != nil {
+		return nil, err
 	}
 
-	if err := validateResourceName(input.Name); err != nil {
+	project, err := s.resolveProject(ctx, input.UserID, input.ProjectID)
+	if err != nil {
 		return nil, err
 	}
 
-	_, err := s.resourcesQ.GetResourceByUserAndName(ctx, dbresources.GetResourceByUserAndNameParams{
-		UserID: input.UserID,
-		Name:   input.Name,
+	if err := s.checkNameFree(ctx, project, input.Name); err != nil {
+		return nil, err
+	}
+
+	if input.Type == TypePostgres || input.Type == TypeRedis {
+		return s.provisionClusterResource(ctx, input, project)
+	}
+	return s.provisionTurso(ctx, input, project, nil, Metadata{})
+}
+
+// checkNameFree refuses a name already taken by another resource in the
+// project. Names only need to be unique per project.
+func (s *Service) checkNameFree(ctx context.Context, project projects.Project, name string) error {
+	_, err := s.resourcesQ.GetResourceByProjectAndName(ctx, dbresources.GetResourceByProjectAndNameParams{
+		ProjectID: project.ID,
+		Name:      name,
 	})
 	if err == nil {
-		return nil, fmt.Errorf("resource with name '%s' already exists", input.Name)
+		return fmt.Errorf("resource with name '%s' already exists in project %s", name, project.Ref)
+	}
+	return nil
+}
+
+// provisionTurso creates a sqlite resource, empty or from seed. metadata
+// carries what the caller records besides the database's own details.
+func (s *Service) provisionTurso(ctx context.Context, input ProvisionDatabaseInput, project projects.Project, seed *turso.Seed, metadata Metadata) (*ProvisionDatabaseOutput, error) {
+	group, ok := turso.RegionToGroup[input.Region]
+	if !ok {
+		return nil, fmt.Errorf("unsupported region: %s (supported: %v)", input.Region, turso.ValidRegions())
 	}
 
 	size := input.Size
@@ -65,7 +109,7 @@ func (s *Service) ProvisionDatabase(ctx context.Context, input ProvisionDatabase
 		size = DefaultSize
 	}
 
-	tursoDBName := generateTursoDBName(input.UserID, input.Name)
+	tursoDBName := generateTursoDBName(input.UserID, tursoProjectRef(project), input.Name)
 
 	s.logger.Info("provisioning database",
 		"user_id", input.UserID,
@@ -81,6 +125,7 @@ func (s *Service) ProvisionDatabase(ctx context.Context, input ProvisionDatabase
 		Name:      tursoDBName,
 		Group:     group,
 		SizeLimit: size,
+		Seed:      seed,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Turso database: %w", err)
@@ -101,20 +146,19 @@ func (s *Service) ProvisionDatabase(ctx context.Context, input ProvisionDatabase
 		return nil, fmt.Errorf("failed to encrypt credentials: %w", err)
 	}
 
-	metadata := Metadata{Size: size, Hostname: db.Hostname, Group: group}
+	metadata.Size = size
+	metadata.Hostname = db.Hostname
+	metadata.Group = group
+	metadata.DatabaseName = tursoDBName
 	metadataJSON, err := json.Marshal(metadata)
 	if err != nil {
 		_ = s.tursoClient.DeleteDatabase(ctx, tursoDBName)
 		return nil, fmt.Errorf("failed to marshal metadata: %w", err)
 	}
 
-	projectID := ""
-	if input.ProjectID != nil {
-		projectID = *input.ProjectID
-	}
 	resource, err := s.resourcesQ.CreateResource(ctx, dbresources.CreateResourceParams{
 		UserID:      input.UserID,
-		ProjectID:   projectID,
+		ProjectID:   project.ID,
 		Name:        input.Name,
 		Type:        TypeSQLite,
 		Provider:    ProviderTurso,
@@ -142,6 +186,156 @@ func (s *Service) ProvisionDatabase(ctx context.Context, input ProvisionDatabase
 	}, nil
 }
 
+// provisionClusterResource records a postgres or redis resource and starts
+// its provisioning on the region's deploy worker, which stores the
+// credentials and marks it active once it accepts connections.
+func (s *Service) provisionClusterResource(ctx context.Context, input ProvisionDatabaseInput, project projects.Project) (*ProvisionDatabaseOutput, error) {
+	region := input.Region
+	if region == "" {
+		region = DefaultPostgresRegion
+	}
+	cluster, ok := s.clusters[region]
+	if !ok || cluster.Status != "active" {
+		return nil, fmt.Errorf("unsupported region for %s: %s", input.Type, region)
+	}
+
+	size := input.Size
+	metadata := Metadata{}
+	var workflowName string
+	var workflowInput func(resourceID string) any
+	switch input.Type {
+	case TypeRedis:
+		if size == "" {
+			size = DefaultRedisSize
+		}
+		tier, err := redisTier(size)
+		if err != nil {
+			return nil, err
+		}
+		if input.Persistent {
+			metadata.Persistence = "aof"
+		}
+		workflowName = ProvisionRedisWorkflowName
+		workflowInput = func(resourceID string) any {
+			return RedisWorkflowInput{
+				ResourceID: resourceID,
+				Tenant:     input.UserID,
+				ProjectRef: project.Ref,
+				Name:       input.Name,
+				Tier:       tier,
+				Persistent: input.Persistent,
+			}
+		}
+	default:
+		if size == "" {
+			size = DefaultPostgresSize
+		}
+		tier, err := postgresTier(size)
+		if err != nil {
+			return nil, err
+		}
+		workflowName = ProvisionPostgresWorkflowName
+		workflowInput = func(resourceID string) any {
+			return PostgresWorkflowInput{
+				ResourceID: resourceID,
+				Tenant:     input.UserID,
+				ProjectRef: project.Ref,
+				Name:       input.Name,
+				Tier:       tier,
+			}
+		}
+	}
+	metadata.Size = size
+
+	metadataJSON, err := json.Marshal(metadata)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal metadata: %w", err)
+	}
+
+	resource, err := s.resourcesQ.CreateResource(ctx, dbresources.CreateResourceParams{
+		UserID:    input.UserID,
+		ProjectID: project.ID,
+		Name:      input.Name,
+		Type:      input.Type,
+		Provider:  ProviderCluster,
+		Region:    region,
+		Metadata:  metadataJSON,
+		Status:    StatusProvisioning,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to save resource: %w", err)
+	}
+
+	s.logger.Info("provisioning cluster resource",
+		"user_id", input.UserID,
+		"resource_id", resource.ID,
+		"name", input.Name,
+		"type", input.Type,
+		"size", size,
+		"region", region,
+	)
+
+	_, err = s.temporalClient.ExecuteWorkflow(ctx, client.StartWorkflowOptions{
+		ID:        provisionWorkflowID(resource.ID),
+		TaskQueue: cluster.TaskQueue,
+	}, workflowName, workflowInput(resource.ID))
+	if err != nil {
+		if statusErr := s.resourcesQ.UpdateResourceStatus(ctx, dbresources.UpdateResourceStatusParams{
+			ID:     resource.ID,
+			Status: StatusFailed,
+		}); statusErr != nil {
+			s.logger.Error("failed to mark resource failed", "error", statusErr, "resource_id", resource.ID)
+		}
+		return nil, fmt.Errorf("failed to start provisioning: %w", err)
+	}
+
+	return &ProvisionDatabaseOutput{
+		ResourceID: resource.ID,
+		Name:       resource.Name,
+		Type:       resource.Type,
+		Region:     resource.Region,
+		Status:     resource.Status,
+	}, nil
+}
+
+// lookupProject returns the user's project with ref, the default project
+// when ref is empty or "default". Unlike resolveProject it never creates
+// one, since it serves lookups.
+func (s *Service) lookupProject(ctx context.Context, userID, ref string) (projects.Project, error) {
+	if ref == "" || ref == "default" {
+		project, err := s.projectsQ.GetDefaultProject(ctx, userID)
+		if err != nil {
+			return projects.Project{}, fmt.Errorf("default project not found for user")
+		}
+		return project, nil
+	}
+	project, err := s.projectsQ.GetProjectByRef(ctx, projects.GetProjectByRefParams{
+		UserID: userID,
+		Ref:    ref,
+	})
+	if err != nil {
+		return projects.Project{}, fmt.Errorf("project not found: %s", ref)
+	}
+	return project, nil
+}
+
+// resolveProject returns the project a new resource belongs to, the user's
+// default one unless projectID names another.
+func (s *Service) resolveProject(ctx context.Context, userID string, projectID *string) (projects.Project, error) {
+	if projectID != nil && *projectID != "" {
+		project, err := s.projectsQ.GetProjectByID(ctx, *projectID)
+		if err != nil || project.UserID != userID {
+			return projects.Project{}, fmt.Errorf("project not found")
+		}
+		return project, nil
+	}
+	project, err := s.projectsQ.GetDefaultProject(ctx, userID)
+	if err != nil {
+		return projects.Project{}, fmt.Errorf("default project not found for user")
+	}
+	return project, nil
+}
+
 func (s *Service) GetResource(ctx context.Context, userID, resourceID string) (*Resource, error) {
 	dbResource, err := s.resourcesQ.GetResourceByID(ctx, resourceID)
 	if err != nil {
@@ -152,37 +346,94 @@ func (s *Service) GetResource(ctx context.Context, userID, resourceID string) (*
 		return nil, fmt.Errorf("resource not found")
 	}
 
-	return s.dbResourceToResource(&dbResource, true)
+	resource, err := s.dbResourceToResource(&dbResource, true)
+	if err != nil {
+		return nil, err
+	}
+	resource.Usage = s.getUsage(ctx, resource.ID)
+	return resource, nil
 }
 
-func (s *Service) GetResourceByName(ctx context.Context, userID, name string) (*Resource, error) {
-	dbResource, err := s.resourcesQ.GetResourceByUserAndName(ctx, dbresources.GetResourceByUserAndNameParams{
-		UserID: userID,
-		Name:   name,
+// GetResourceByName returns the resource called name in the user's project
+// with ref projectRef, the default project when it is empty.
+func (s *Service) GetResourceByName(ctx context.Context, userID, projectRef, name string) (*Resource, error) {
+	resource, _, err := s.getResourceInProject(ctx, userID, projectRef, name)
+	return resource, err
+}
+
+func (s *Service) getResourceInProject(ctx context.Context, userID, projectRef, name string) (*Resource, projects.Project, error) {
+	project, err := s.lookupProject(ctx, userID, projectRef)
+	if err != nil {
+		return nil, projects.Project{}, err
+	}
+
+	dbResource, err := s.resourcesQ.GetResourceByProjectAndName(ctx, dbresources.GetResourceByProjectAndNameParams{
+		ProjectID: project.ID,
+		Name:      name,
 	})
 	if err != nil {
-		return nil, fmt.Errorf("resource not found: %w", err)
+		return nil, projects.Project{}, fmt.Errorf("resource not found: %s in project %s", name, project.Ref)
 	}
 
-	return s.dbResourceToResource(&dbResource, true)
+	resource, err := s.dbResourceToResource(&dbResource, true)
+	if err != nil {
+		return nil, projects.Project{}, err
+	}
+	resource.Project = project.Ref
+	resource.Usage = s.getUsage(ctx, resource.ID)
+	return resource, project, nil
 }
 
-func (s *Service) ListResources(ctx context.Context, userID string, limit, offset int32) ([]*Resource, error) {
-	dbResources, err := s.resourcesQ.ListResourcesByUser(ctx, dbresources.ListResourcesByUserParams{
-		UserID: userID,
-		Limit:  limit,
-		Offset: offset,
-	})
-	if err != nil {
-		return nil, fmt.Errorf("failed to list resources: %w", err)
+// ListResources returns the user's resources, only those of the project
+// with ref projectRef unless it is empty.
+func (s *Service) ListResources(ctx context.Context, userID, projectRef string, limit, offset int32) ([]*Resource, error) {
+	var dbResources []dbresources.Resource
+	projectRefs := make(map[string]string)
+	if projectRef != "" {
+		project, err := s.lookupProject(ctx, userID, projectRef)
+		if err != nil {
+			return nil, err
+		}
+		projectRefs[project.ID] = project.Ref
+		dbResources, err = s.resourcesQ.ListResourcesByProject(ctx, dbresources.ListResourcesByProjectParams{
+			ProjectID: project.ID,
+			Limit:     limit,
+			Offset:    offset,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list resources: %w", err)
+		}
+	} else {
+		var err error
+		dbResources, err = s.resourcesQ.ListResourcesByUser(ctx, dbresources.ListResourcesByUserParams{
+			UserID: userID,
+			Limit:  limit,
+			Offset: offset,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list resources: %w", err)
+		}
+		userProjects, err := s.projectsQ.ListProjectsByUserID(ctx, projects.ListProjectsByUserIDParams{
+			UserID: userID,
+			Limit:  1000,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list projects: %w", err)
+		}
+		for _, p := range userProjects {
+			projectRefs[p.ID] = p.Ref
+		}
 	}
 
+	usage := s.listUsage(ctx, userID)
 	resources := make([]*Resource, len(dbResources))
 	for i, dbr := range dbResources {
 		r, err := s.dbResourceToResource(&dbr, false)
 		if err != nil {
 			return nil, err
 		}
+		r.Project = projectRefs[dbr.ProjectID]
+		r.Usage = usage[r.ID]
 		resources[i] = r
 	}
 
@@ -196,9 +447,19 @@ func (s *Service) DeleteResource(ctx context.Context, userID, resourceID string)
 	}
 
 	if resource.Provider == ProviderTurso && resource.ExternalID != nil {
-		tursoDBName := generateTursoDBName(userID, resource.Name)
-		if err := s.tursoClient.DeleteDatabase(ctx, tursoDBName); err != nil {
-			s.logger.Error("failed to delete Turso database", "error", err, "name", tursoDBName)
+		for _, tursoDBName := range []string{tursoDBNameOf(resource), resource.Metadata["replaced_database"]} {
+			if tursoDBName == "" {
+				continue
+			}
+			if err := s.tursoClient.DeleteDatabase(ctx, tursoDBName); err != nil {
+				s.logger.Error("failed to delete Turso database", "error", err, "name", tursoDBName)
+			}
+		}
+	}
+
+	if resource.Provider == ProviderCluster {
+		if err := s.deleteClusterResource(ctx, resource); err != nil {
+			return err
 		}
 	}
 
@@ -214,6 +475,60 @@ func (s *Service) DeleteResource(ctx context.Context, userID, resourceID string)
 	return nil
 }
 
+// deleteClusterResource starts tearing down an in-cluster resource. The
+// record is only removed once that has started, so a failure leaves it to
+// retry rather than orphaning the volume.
+func (s *Service) deleteClusterResource(ctx context.Context, resource *Resource) error {
+	cluster, ok := s.clusters[resource.Region]
+	if !ok {
+		return fmt.Errorf("unknown region %q for resource %s", resource.Region, resource.ID)
+	}
+	project, err := s.projectsQ.GetProjectByID(ctx, *resource.ProjectID)
+	if err != nil {
+		return fmt.Errorf("get project: %w", err)
+	}
+
+	var workflowName string
+	var workflowInput any
+	switch resource.Type {
+	case TypeRedis:
+		workflowName = DeleteRedisWorkflowName
+		workflowInput = RedisWorkflowInput{
+			ResourceID: resource.ID,
+			Tenant:     resource.UserID,
+			ProjectRef: project.Ref,
+			Name:       resource.Name,
+		}
+	default:
+		workflowName = DeletePostgresWorkflowName
+		workflowInput = PostgresWorkflowInput{
+			ResourceID: resource.ID,
+			Tenant:     resource.UserID,
+			ProjectRef: project.Ref,
+			Name:       resource.Name,
+		}
+	}
+
+	_, err = s.temporalClient.ExecuteWorkflow(ctx, client.StartWorkflowOptions{
+		ID:        deleteWorkflowID(resource.ID),
+		TaskQueue: cluster.TaskQueue,
+	}, workflowName, workflowInput)
+	if err != nil {
+		return fmt.Errorf("failed to start teardown: %w", err)
+	}
+	return nil
+}
+
+// ListBoundServiceIDs returns the services whose env the resource is
+// injected into, which need a redeploy when it changes or goes away.
+func (s *Service) ListBoundServiceIDs(ctx context.Context, resourceID string) ([]string, error) {
+	ids, err := s.resourcesQ.ListServiceIDsByResource(ctx, resourceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list bound services: %w", err)
+	}
+	return ids, nil
+}
+
 func (s *Service) dbResourceToResource(dbr *dbresources.Resource, decryptCreds bool) (*Resource, error) {
 	resource := &Resource{
 		ID:         dbr.ID,
@@ -248,12 +563,25 @@ func (s *Service) dbResourceToResource(dbr *dbresources.Resource, decryptCreds b
 	return resource, nil
 }
 
-func generateTursoDBName(userID, name string) string {
+// tursoProjectRef is the project part of a Turso database name. Turso names
+// are per organization, so a resource outside the default project carries
+// its project's ref to stay apart from a namesake in another project.
+func tursoProjectRef(project projects.Project) string {
+	if project.IsDefault {
+		return ""
+	}
+	return project.Ref
+}
+
+func generateTursoDBName(userID, projectRef, name string) string {
 	prefix := strings.ToLower(userID)
 	if len(prefix) > 8 {
 		prefix = prefix[:8]
 	}
 
+	if projectRef != "" {
+		name = projectRef + "-" + name
+	}
 	cleanName := strings.ToLower(name)
 	cleanName = regexp.MustCompile(`[^a-z0-9-]`).ReplaceAllString(cleanName, "-")
 	cleanName = regexp.MustCompile(`-+`).ReplaceAllString(cleanName, "-")
@@ -6,6 +6,7 @@ type Resource struct {
 	ID          string            `json:"id"`
 	UserID      string            `json:"user_id"`
 	ProjectID   *string           `json:"project_id,omitempty"`
+	Project     string            `json:"project,omitempty"`
 	Name        string            `json:"name"`
 	Type        string            `json:"type"`
 	Provider    string            `json:"provider"`
@@ -16,6 +17,19 @@ type Resource struct {
 	Status      string            `json:"status"`
 	CreatedAt   time.Time         `json:"created_at"`
 	UpdatedAt   time.Time         `json:"updated_at"`
+	// Usage is what a sqlite resource last reported using, nil until the
+	// usage workflow has recorded it.
+	Usage *Usage `json:"usage,omitempty"`
+}
+
+type Usage struct {
+	StorageBytes   int64     `json:"storage_bytes"`
+	RowsRead       int64     `json:"rows_read"`
+	RowsWritten    int64     `json:"rows_written"`
+	SizeLimitBytes int64     `json:"size_limit_bytes,omitempty"`
+	WritesBlocked  bool      `json:"writes_blocked,omitempty"`
+	Warning        string    `json:"warning,omitempty"`
+	UpdatedAt      time.Time `json:"updated_at"`
 }
 
 type Credentials struct {
@@ -24,9 +38,29 @@ type Credentials struct {
 }
 
 type Metadata struct {
-	Size     string `json:"size,omitempty"`
-	Hostname string `json:"hostname,omitempty"`
-	Group    string `json:"group,omitempty"`
+	Size      string `json:"size,omitempty"`
+	Hostname  string `json:"hostname,omitempty"`
+	Group     string `json:"group,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+	// Persistence is "aof" for a redis resource kept on disk.
+	Persistence string `json:"persistence,omitempty"`
+	// DatabaseName is the Turso database behind a sqlite resource once a
+	// restore has replaced the one named after the resource.
+	DatabaseName string `json:"database_name,omitempty"`
+
+	// Lineage of a sqlite resource: the resource it was forked from and the
+	// point in time it was copied at, and when it was last restored and to
+	// which point in time.
+	ForkedFrom   string `json:"forked_from,omitempty"`
+	ForkedFromID string `json:"forked_from_id,omitempty"`
+	ForkedAt     string `json:"forked_at,omitempty"`
+	RestoredTo   string `json:"restored_to,omitempty"`
+	RestoredAt   string `json:"restored_at,omitempty"`
+	// ReplacedDatabase is the Turso database the last restore replaced,
+	// kept until the restore is finished or undone.
+	ReplacedDatabase string `json:"replaced_database,omitempty"`
+	// RotatedAt is when a sqlite resource's auth token was last replaced.
+	RotatedAt string `json:"rotated_at,omitempty"`
 }
 
 type ProvisionDatabaseInput struct {
@@ -36,6 +70,35 @@ type ProvisionDatabaseInput struct {
 	Type      string
 	Size      string
 	Region    string
+	// Persistent keeps a redis resource's data on disk.
+	Persistent bool
+}
+
+type ForkDatabaseInput struct {
+	UserID string
+	// Project holds the source, and the copy is created next to it.
+	Project string
+	Source  string
+	Name    string
+	// Size is the fork's declared size, the source's when empty.
+	Size string
+	// Timestamp copies the source as it was then rather than now.
+	Timestamp *time.Time
+}
+
+type RestoreDatabaseInput struct {
+	UserID    string
+	Project   string
+	Name      string
+	Timestamp time.Time
+}
+
+type FinishRestoreInput struct {
+	UserID  string
+	Project string
+	Name    string
+	// Undo switches back to the database the restore replaced.
+	Undo bool
 }
 
 type ProvisionDatabaseOutput struct {
@@ -51,6 +114,7 @@ type ProvisionDatabaseOutput struct {
 const (
 	TypeSQLite   = "sqlite"
 	TypePostgres = "postgres"
+	TypeRedis    = "redis"
 	TypeMongo    = "mongo"
 	TypeLLM      = "llm"
 )
@@ -60,6 +124,9 @@ const (
 	ProviderNeon   = "neon"
 	ProviderAtlas  = "atlas"
 	ProviderOpenAI = "openai"
+	// ProviderCluster resources run in the project's namespace on a
+	// deploy cluster, next to the services that use them.
+	ProviderCluster = "cluster"
 )
 
 const (
@@ -73,4 +140,9 @@ const (
 const (
 	DefaultSize   = "100mb"
 	DefaultRegion = "eu-west"
+
+	DefaultPostgresSize   = "1gb"
+	DefaultPostgresRegion = "eu-central-1"
+
+	DefaultRedisSize = "256mb"
 )
@@ -0,0 +1,112 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	dbresources "github.com/augustdev/autoclip/internal/storage/pg/generated/resources"
+)
+
+// usageWarnPercent is how full a sqlite resource gets, relative to its
+// declared size, before its usage carries a warning. Writes are blocked at
+// 100%.
+const usageWarnPercent = 80
+
+// ParseSize converts a declared size such as "100mb" or "1gb" to bytes.
+// Units are binary, like the volumes of the in-cluster resources, and a
+// bare number is bytes.
+func ParseSize(size string) (int64, error) {
+	s := strings.ToLower(strings.TrimSpace(size))
+	multiplier := int64(1)
+	for _, unit := range []struct {
+		suffix string
+		bytes  int64
+	}{
+		{"kb", 1 << 10},
+		{"mb", 1 << 20},
+		{"gb", 1 << 30},
+		{"tb", 1 << 40},
+		{"b", 1},
+	} {
+		if strings.HasSuffix(s, unit.suffix) {
+			s = strings.TrimSpace(strings.TrimSuffix(s, unit.suffix))
+			multiplier = unit.bytes
+			break
+		}
+	}
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid size %q: use a size like 100mb or 1gb", size)
+	}
+	return n * multiplier, nil
+}
+
+// UsageWarning describes how close a database is to its declared size, or
+// returns "" while it is comfortably under it or has no size to compare
+// against.
+func UsageWarning(storageBytes, sizeLimitBytes int64, writesBlocked bool) string {
+	if sizeLimitBytes <= 0 {
+		return ""
+	}
+	used := fmt.Sprintf("%s of %s", formatBytes(storageBytes), formatBytes(sizeLimitBytes))
+	switch {
+	case writesBlocked:
+		return fmt.Sprintf("database is over its declared size (%s used); writes are blocked until it is back under, so fork it with a larger size to keep writing", used)
+	case storageBytes >= sizeLimitBytes:
+		return fmt.Sprintf("database is over its declared size (%s used); writes will be blocked", used)
+	case storageBytes*100 >= sizeLimitBytes*usageWarnPercent:
+		return fmt.Sprintf("database is at %d%% of its declared size (%s used); writes are blocked once it is full", storageBytes*100/sizeLimitBytes, used)
+	}
+	return ""
+}
+
+// formatBytes writes n in the units sizes are declared in.
+func formatBytes(n int64) string {
+	switch {
+	case n >= 1<<30:
+		return strconv.FormatFloat(float64(n)/(1<<30), 'f', 1, 64) + "gb"
+	case n >= 1<<20:
+		return strconv.FormatFloat(float64(n)/(1<<20), 'f', 1, 64) + "mb"
+	case n >= 1<<10:
+		return strconv.FormatFloat(float64(n)/(1<<10), 'f', 1, 64) + "kb"
+	}
+	return strconv.FormatInt(n, 10) + "b"
+}
+
+// getUsage returns the last recorded usage of a resource, or nil when none
+// has been recorded yet.
+func (s *Service) getUsage(ctx context.Context, resourceID string) *Usage {
+	u, err := s.resourcesQ.GetResourceUsage(ctx, resourceID)
+	if err != nil {
+		return nil
+	}
+	return usageFromDB(&u)
+}
+
+// listUsage returns the recorded usage of the user's resources by ID.
+func (s *Service) listUsage(ctx context.Context, userID string) map[string]*Usage {
+	rows, err := s.resourcesQ.ListResourceUsageByUser(ctx, userID)
+	if err != nil {
+		s.logger.Error("failed to list resource usage", "error", err, "user_id", userID)
+		return nil
+	}
+	usage := make(map[string]*Usage, len(rows))
+	for i := range rows {
+		usage[rows[i].ResourceID] = usageFromDB(&rows[i])
+	}
+	return usage
+}
+
+func usageFromDB(u *dbresources.ResourceUsage) *Usage {
+	return &Usage{
+		StorageBytes:   u.StorageBytes,
+		RowsRead:       u.RowsRead,
+		RowsWritten:    u.RowsWritten,
+		SizeLimitBytes: u.SizeLimitBytes,
+		WritesBlocked:  u.WritesBlocked,
+		Warning:        UsageWarning(u.StorageBytes, u.SizeLimitBytes, u.WritesBlocked),
+		UpdatedAt:      u.UpdatedAt.Time,
+	}
+}
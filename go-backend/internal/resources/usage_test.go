@@ -0,0 +1,176 @@
+package resources
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"slices"
+	"strings"
+	"testing"
+
+	dbresources "github.com/augustdev/autoclip/internal/storage/pg/generated/resources"
+	"github.com/augustdev/autoclip/internal/turso"
+)
+
+func TestParseSize(t *testing.T) {
+	tests := []struct {
+		size    string
+		want    int64
+		wantErr bool
+	}{
+		{size: "100mb", want: 100 << 20},
+		{size: "1GB", want: 1 << 30},
+		{size: " 512 kb ", want: 512 << 10},
+		{size: "4096", want: 4096},
+		{size: "", wantErr: true},
+		{size: "mb", wantErr: true},
+		{size: "-1gb", wantErr: true},
+		{size: "1.5gb", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseSize(tt.size)
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("ParseSize(%q) error = %v, wantErr %v", tt.size, err, tt.wantErr)
+		}
+		if got != tt.want {
+			t.Fatalf("ParseSize(%q) = %d, want %d", tt.size, got, tt.want)
+		}
+	}
+}
+
+func TestUsageWarning(t *testing.T) {
+	const limit = 100 << 20
+
+	tests := []struct {
+		name    string
+		storage int64
+		limit   int64
+		blocked bool
+		want    string
+	}{
+		{name: "no limit", storage: 1 << 30, limit: 0},
+		{name: "under threshold", storage: 50 << 20, limit: limit},
+		{name: "near full", storage: 85 << 20, limit: limit, want: "at 85% of its declared size (85.0mb of 100.0mb used)"},
+		{name: "over not yet blocked", storage: 120 << 20, limit: limit, want: "writes will be blocked"},
+		{name: "blocked", storage: 120 << 20, limit: limit, blocked: true, want: "writes are blocked until it is back under"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := UsageWarning(tt.storage, tt.limit, tt.blocked)
+			if tt.want == "" {
+				if got != "" {
+					t.Fatalf("UsageWarning() = %q, want none", got)
+				}
+				return
+			}
+			if !strings.Contains(got, tt.want) {
+				t.Fatalf("UsageWarning() = %q, want it to contain %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func (f *fakeTurso) GetDatabaseUsage(ctx context.Context, dbName string) (*turso.DatabaseUsage, error) {
+	return &turso.DatabaseUsage{StorageBytes: f.storageBytes}, nil
+}
+
+func (f *fakeTurso) GetDatabaseConfiguration(ctx context.Context, dbName string) (*turso.DatabaseConfiguration, error) {
+	return &turso.DatabaseConfiguration{BlockWrites: &f.blockWrites}, nil
+}
+
+func (f *fakeTurso) SetBlockWrites(ctx context.Context, dbName string, block bool) error {
+	if block {
+		f.calls = append(f.calls, "block "+dbName)
+	} else {
+		f.calls = append(f.calls, "unblock "+dbName)
+	}
+	f.blockWrites = block
+	return nil
+}
+
+func (f *fakeResources) UpsertResourceUsage(ctx context.Context, arg dbresources.UpsertResourceUsageParams) error {
+	f.usage = append(f.usage, arg)
+	return nil
+}
+
+func TestRecordResourceUsage(t *testing.T) {
+	const limit = 100 << 20
+
+	tests := []struct {
+		name        string
+		storage     int64
+		blockWrites bool
+		wantCalls   []string
+		wantResult  RecordResourceUsageResult
+	}{
+		{name: "under", storage: 50 << 20},
+		{
+			name:       "over",
+			storage:    120 << 20,
+			wantCalls:  []string{"block user-1-db"},
+			wantResult: RecordResourceUsageResult{StorageBytes: 120 << 20, Blocked: true},
+		},
+		{name: "over and blocked", storage: 120 << 20, blockWrites: true},
+		{
+			name:        "back under",
+			storage:     50 << 20,
+			blockWrites: true,
+			wantCalls:   []string{"unblock user-1-db"},
+			wantResult:  RecordResourceUsageResult{StorageBytes: 50 << 20, Unblocked: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resourcesQ := &fakeResources{resource: dbresources.Resource{
+				ID:       "res-1",
+				UserID:   "user-1",
+				Name:     "db",
+				Provider: ProviderTurso,
+				Metadata: []byte(`{"database_name":"user-1-db","size":"100mb"}`),
+				Status:   StatusActive,
+			}}
+			tursoClient := &fakeTurso{storageBytes: tt.storage, blockWrites: tt.blockWrites}
+			a := &Activities{resourcesQ: resourcesQ, tursoClient: tursoClient, logger: slog.New(slog.NewTextHandler(io.Discard, nil))}
+
+			result, err := a.RecordResourceUsage(context.Background(), RecordResourceUsageInput{ResourceID: "res-1"})
+			if err != nil {
+				t.Fatalf("RecordResourceUsage() error = %v", err)
+			}
+			if tt.wantResult.StorageBytes == 0 {
+				tt.wantResult.StorageBytes = tt.storage
+			}
+			if result != tt.wantResult {
+				t.Fatalf("result = %+v, want %+v", result, tt.wantResult)
+			}
+			if !slices.Equal(tursoClient.calls, tt.wantCalls) {
+				t.Fatalf("Turso calls = %v, want %v", tursoClient.calls, tt.wantCalls)
+			}
+			if len(resourcesQ.usage) != 1 || resourcesQ.usage[0].WritesBlocked != (tt.storage >= limit) {
+				t.Fatalf("stored usage = %+v, want writes_blocked %v", resourcesQ.usage, tt.storage >= limit)
+			}
+		})
+	}
+}
+
+// A restore swaps in a database whose writes aren't blocked while the
+// stored usage still says the replaced one's were.
+func TestRecordResourceUsage_AfterRestore(t *testing.T) {
+	resourcesQ := &fakeResources{resource: dbresources.Resource{
+		ID:       "res-1",
+		UserID:   "user-1",
+		Name:     "db",
+		Provider: ProviderTurso,
+		Metadata: []byte(`{"database_name":"user-1-db-r1","size":"100mb"}`),
+		Status:   StatusActive,
+	}, storedUsage: &dbresources.ResourceUsage{ResourceID: "res-1", WritesBlocked: true}}
+	tursoClient := &fakeTurso{storageBytes: 120 << 20}
+	a := &Activities{resourcesQ: resourcesQ, tursoClient: tursoClient, logger: slog.New(slog.NewTextHandler(io.Discard, nil))}
+
+	result, err := a.RecordResourceUsage(context.Background(), RecordResourceUsageInput{ResourceID: "res-1"})
+	if err != nil {
+		t.Fatalf("RecordResourceUsage() error = %v", err)
+	}
+	if !result.Blocked || !slices.Equal(tursoClient.calls, []string{"block user-1-db-r1"}) {
+		t.Fatalf("result = %+v, Turso calls = %v; want the replacement blocked", result, tursoClient.calls)
+	}
+}
@@ -0,0 +1,203 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/augustdev/autoclip/internal/cron"
+	"github.com/augustdev/autoclip/internal/helpers"
+	dbresources "github.com/augustdev/autoclip/internal/storage/pg/generated/resources"
+	"github.com/augustdev/autoclip/internal/turso"
+	"github.com/jackc/pgx/v5"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/worker"
+	"go.temporal.io/sdk/workflow"
+)
+
+const (
+	// UsageTaskQueue is served by cmd/worker, the worker given a Turso API
+	// token.
+	UsageTaskQueue = "default"
+
+	// RecordResourceUsageWorkflowID names the single usage cron.
+	RecordResourceUsageWorkflowID = "record-resource-usage"
+
+	// usageSchedule is how often sqlite usage is fetched. Turso refreshes
+	// its figures every few minutes, so polling faster gains nothing.
+	usageSchedule = "*/15 * * * *"
+)
+
+type Activities struct {
+	resourcesQ  dbresources.Querier
+	tursoClient tursoAPI
+	logger      *slog.Logger
+}
+
+func NewActivities(resourcesQ dbresources.Querier, tursoClient *turso.Client, logger *slog.Logger) *Activities {
+	return &Activities{
+		resourcesQ:  resourcesQ,
+		tursoClient: tursoClient,
+		logger:      logger,
+	}
+}
+
+type RecordResourceUsageInput struct {
+	ResourceID string
+}
+
+type RecordResourceUsageResult struct {
+	StorageBytes int64
+	// Blocked and Unblocked report a change of the database's writes_blocked
+	// made by this run.
+	Blocked   bool
+	Unblocked bool
+}
+
+type RecordAllResourceUsageResult struct {
+	Recorded  int
+	Blocked   int
+	Unblocked int
+}
+
+// ListUsageCandidates returns the active sqlite resources.
+func (a *Activities) ListUsageCandidates(ctx context.Context) ([]string, error) {
+	rows, err := a.resourcesQ.ListActiveResourcesByProvider(ctx, ProviderTurso)
+	if err != nil {
+		return nil, fmt.Errorf("list sqlite resources: %w", err)
+	}
+	ids := make([]string, len(rows))
+	for i, r := range rows {
+		ids[i] = r.ID
+	}
+	return ids, nil
+}
+
+// RecordResourceUsage stores what a sqlite resource uses and holds it to its
+// declared size: writes are blocked once it is full and unblocked when it
+// is back under.
+func (a *Activities) RecordResourceUsage(ctx context.Context, input RecordResourceUsageInput) (RecordResourceUsageResult, error) {
+	dbr, err := a.resourcesQ.GetResourceByID(ctx, input.ResourceID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return RecordResourceUsageResult{}, nil
+	}
+	if err != nil {
+		return RecordResourceUsageResult{}, fmt.Errorf("get resource: %w", err)
+	}
+	if dbr.Provider != ProviderTurso || dbr.Status != StatusActive {
+		return RecordResourceUsageResult{}, nil
+	}
+
+	resource := &Resource{ID: dbr.ID, UserID: dbr.UserID, Name: dbr.Name}
+	if dbr.Metadata != nil {
+		_ = json.Unmarshal(dbr.Metadata, &resource.Metadata)
+	}
+	dbName := tursoDBNameOf(resource)
+
+	usage, err := a.tursoClient.GetDatabaseUsage(ctx, dbName)
+	if err != nil {
+		return RecordResourceUsageResult{}, err
+	}
+
+	// A size that doesn't parse predates validation; it is reported but not
+	// enforced.
+	limit, err := ParseSize(resource.Metadata["size"])
+	if err != nil {
+		a.logger.Warn("resource has no usable size", "resource_id", dbr.ID, "size", resource.Metadata["size"])
+		limit = 0
+	}
+
+	// The database itself says whether its writes are blocked: the stored
+	// usage may describe one a restore has since replaced.
+	config, err := a.tursoClient.GetDatabaseConfiguration(ctx, dbName)
+	if err != nil {
+		return RecordResourceUsageResult{}, err
+	}
+	wasBlocked := helpers.Deref(config.BlockWrites)
+
+	over := limit > 0 && usage.StorageBytes >= limit
+	result := RecordResourceUsageResult{StorageBytes: usage.StorageBytes}
+	switch {
+	case over && !wasBlocked:
+		if err := a.tursoClient.SetBlockWrites(ctx, dbName, true); err != nil {
+			return RecordResourceUsageResult{}, err
+		}
+		a.logger.Warn("blocked writes to resource over its size",
+			"resource_id", dbr.ID,
+			"storage_bytes", usage.StorageBytes,
+			"size_limit_bytes", limit,
+		)
+		result.Blocked = true
+	case !over && wasBlocked:
+		if err := a.tursoClient.SetBlockWrites(ctx, dbName, false); err != nil {
+			return RecordResourceUsageResult{}, err
+		}
+		a.logger.Info("unblocked writes to resource back under its size", "resource_id", dbr.ID)
+		result.Unblocked = true
+	}
+
+	if err := a.resourcesQ.UpsertResourceUsage(ctx, dbresources.UpsertResourceUsageParams{
+		ResourceID:     dbr.ID,
+		StorageBytes:   usage.StorageBytes,
+		RowsRead:       usage.RowsRead,
+		RowsWritten:    usage.RowsWritten,
+		SizeLimitBytes: limit,
+		WritesBlocked:  over,
+	}); err != nil {
+		return RecordResourceUsageResult{}, fmt.Errorf("store usage: %w", err)
+	}
+	return result, nil
+}
+
+// RecordAllResourceUsageWorkflow records the usage of every active sqlite
+// resource, blocking or unblocking writes as each crosses its declared
+// size. A resource whose usage can't be recorded is logged and left out of
+// the counts.
+func RecordAllResourceUsageWorkflow(ctx workflow.Context) (RecordAllResourceUsageResult, error) {
+	logger := workflow.GetLogger(ctx)
+
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: time.Minute,
+		RetryPolicy:         &temporal.RetryPolicy{MaximumAttempts: 3},
+	})
+
+	var activities *Activities
+	var resourceIDs []string
+	if err := workflow.ExecuteActivity(ctx, activities.ListUsageCandidates).Get(ctx, &resourceIDs); err != nil {
+		return RecordAllResourceUsageResult{}, err
+	}
+
+	var result RecordAllResourceUsageResult
+	for _, resourceID := range resourceIDs {
+		var recorded RecordResourceUsageResult
+		if err := workflow.ExecuteActivity(ctx, activities.RecordResourceUsage, RecordResourceUsageInput{
+			ResourceID: resourceID,
+		}).Get(ctx, &recorded); err != nil {
+			logger.Warn("Failed to record resource usage", "resourceID", resourceID, "error", err)
+			continue
+		}
+		result.Recorded++
+		if recorded.Blocked {
+			result.Blocked++
+		}
+		if recorded.Unblocked {
+			result.Unblocked++
+		}
+	}
+	return result, nil
+}
+
+func RegisterWorkflowsAndActivities(w worker.Worker, activities *Activities) {
+	w.RegisterWorkflow(RecordAllResourceUsageWorkflow)
+	w.RegisterActivity(activities.ListUsageCandidates)
+	w.RegisterActivity(activities.RecordResourceUsage)
+}
+
+// StartUsageSchedule starts the RecordAllResourceUsageWorkflow cron.
+func StartUsageSchedule(ctx context.Context, c client.Client) error {
+	return cron.Start(ctx, c, RecordResourceUsageWorkflowID, UsageTaskQueue, usageSchedule, RecordAllResourceUsageWorkflow)
+}
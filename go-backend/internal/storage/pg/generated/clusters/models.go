@@ -70,6 +70,9 @@ type Deployment struct {
 	FinishedAt      pgtype.Timestamptz `json:"finished_at"`
 	CreatedAt       pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt       pgtype.Timestamptz `json:"updated_at"`
+	Replicas        int32              `json:"replicas"`
+	Autoscaling     []byte             `json:"autoscaling"`
+	Stack           []byte             `json:"stack"`
 }
 
 type GitToken struct {
@@ -83,6 +86,7 @@ type GitToken struct {
 	LastUsedAt  pgtype.Timestamptz `json:"last_used_at"`
 	RevokedAt   pgtype.Timestamptz `json:"revoked_at"`
 	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	ServiceID   *string            `json:"service_id"`
 }
 
 type GithubCred struct {
@@ -98,17 +102,22 @@ type GithubCred struct {
 }
 
 type InternalRepo struct {
-	ID        string             `json:"id"`
-	UserID    string             `json:"user_id"`
-	Name      string             `json:"name"`
-	CloneUrl  string             `json:"clone_url"`
-	Provider  string             `json:"provider"`
-	RepoID    *string            `json:"repo_id"`
-	FullName  string             `json:"full_name"`
-	CreatedAt pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
-	BarePath  *string            `json:"bare_path"`
-	ProjectID string             `json:"project_id"`
+	ID                string             `json:"id"`
+	UserID            string             `json:"user_id"`
+	Name              string             `json:"name"`
+	CloneUrl          string             `json:"clone_url"`
+	Provider          string             `json:"provider"`
+	RepoID            *string            `json:"repo_id"`
+	FullName          string             `json:"full_name"`
+	CreatedAt         pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt         pgtype.Timestamptz `json:"updated_at"`
+	BarePath          *string            `json:"bare_path"`
+	ProjectID         string             `json:"project_id"`
+	ProtectedBranches []string           `json:"protected_branches"`
+	MaxPackBytes      *int64             `json:"max_pack_bytes"`
+	MaxFileBytes      *int64             `json:"max_file_bytes"`
+	SizeBytes         int64              `json:"size_bytes"`
+	SizeUpdatedAt     pgtype.Timestamptz `json:"size_updated_at"`
 }
 
 type Project struct {
@@ -139,6 +148,16 @@ type Resource struct {
 	UpdatedAt     pgtype.Timestamptz `json:"updated_at"`
 }
 
+type ResourceUsage struct {
+	ResourceID     string             `json:"resource_id"`
+	StorageBytes   int64              `json:"storage_bytes"`
+	RowsRead       int64              `json:"rows_read"`
+	RowsWritten    int64              `json:"rows_written"`
+	SizeLimitBytes int64              `json:"size_limit_bytes"`
+	WritesBlocked  bool               `json:"writes_blocked"`
+	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
+}
+
 type Service struct {
 	ID                  string             `json:"id"`
 	UserID              string             `json:"user_id"`
@@ -162,6 +181,28 @@ type Service struct {
 	CreatedAt           pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt           pgtype.Timestamptz `json:"updated_at"`
 	Region              string             `json:"region"`
+	Replicas            int32              `json:"replicas"`
+	Autoscaling         []byte             `json:"autoscaling"`
+	SleepAfter          *int32             `json:"sleep_after"`
+	SleptAt             pgtype.Timestamptz `json:"slept_at"`
+	SourceType          string             `json:"source_type"`
+	Image               *string            `json:"image"`
+	RegistryCredentials []byte             `json:"registry_credentials"`
+}
+
+type ServiceResource struct {
+	ServiceID  string             `json:"service_id"`
+	ResourceID string             `json:"resource_id"`
+	EnvPrefix  string             `json:"env_prefix"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+}
+
+type SourceUpload struct {
+	ID        string             `json:"id"`
+	ServiceID string             `json:"service_id"`
+	Digest    string             `json:"digest"`
+	SizeBytes int64              `json:"size_bytes"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
 }
 
 type User struct {
@@ -176,6 +217,7 @@ type User struct {
 	GithubScopes   []string           `json:"github_scopes"`
 	CreatedAt      pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
+	MaxReplicas    int32              `json:"max_replicas"`
 }
 
 type ZoneRecord struct {
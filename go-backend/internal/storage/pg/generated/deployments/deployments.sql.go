@@ -55,11 +55,11 @@ func (q *Queries) CountDeploymentsByServiceID(ctx context.Context, serviceID str
 const createDeployment = `-- name: CreateDeployment :one
 INSERT INTO deployments (
     id, service_id, workflow_id, build_pack, build_config, env_vars_snapshot,
-    memory, vcpus, port, trigger, trigger_ref, commit_hash
+    memory, vcpus, port, trigger, trigger_ref, commit_hash, replicas, autoscaling
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14
 )
-RETURNING id, service_id, workflow_id, workflow_run_id, commit_hash, image_ref, build_pack, build_config, env_vars_snapshot, memory, vcpus, port, status, error_message, build_progress, trigger, trigger_ref, started_at, finished_at, created_at, updated_at
+RETURNING id, service_id, workflow_id, workflow_run_id, commit_hash, image_ref, build_pack, build_config, env_vars_snapshot, memory, vcpus, port, status, error_message, build_progress, trigger, trigger_ref, started_at, finished_at, created_at, updated_at, replicas, autoscaling, stack
 `
 
 type CreateDeploymentParams struct {
@@ -75,6 +75,8 @@ type CreateDeploymentParams struct {
 	Trigger         string  `json:"trigger"`
 	TriggerRef      *string `json:"trigger_ref"`
 	CommitHash      *string `json:"commit_hash"`
+	Replicas        int32   `json:"replicas"`
+	Autoscaling     []byte  `json:"autoscaling"`
 }
 
 func (q *Queries) CreateDeployment(ctx context.Context, arg CreateDeploymentParams) (Deployment, error) {
@@ -91,6 +93,8 @@ func (q *Queries) CreateDeployment(ctx context.Context, arg CreateDeploymentPara
 		arg.Trigger,
 		arg.TriggerRef,
 		arg.CommitHash,
+		arg.Replicas,
+		arg.Autoscaling,
 	)
 	var i Deployment
 	err := row.Scan(
@@ -115,12 +119,15 @@ func (q *Queries) CreateDeployment(ctx context.Context, arg CreateDeploymentPara
 		&i.FinishedAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Replicas,
+		&i.Autoscaling,
+		&i.Stack,
 	)
 	return i, err
 }
 
 const getActiveDeploymentByServiceID = `-- name: GetActiveDeploymentByServiceID :one
-SELECT id, service_id, workflow_id, workflow_run_id, commit_hash, image_ref, build_pack, build_config, env_vars_snapshot, memory, vcpus, port, status, error_message, build_progress, trigger, trigger_ref, started_at, finished_at, created_at, updated_at FROM deployments
+SELECT id, service_id, workflow_id, workflow_run_id, commit_hash, image_ref, build_pack, build_config, env_vars_snapshot, memory, vcpus, port, status, error_message, build_progress, trigger, trigger_ref, started_at, finished_at, created_at, updated_at, replicas, autoscaling, stack FROM deployments
 WHERE service_id = $1 AND status = 'active'
 `
 
@@ -149,12 +156,15 @@ func (q *Queries) GetActiveDeploymentByServiceID(ctx context.Context, serviceID
 		&i.FinishedAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Replicas,
+		&i.Autoscaling,
+		&i.Stack,
 	)
 	return i, err
 }
 
 const getDeploymentByID = `-- name: GetDeploymentByID :one
-SELECT id, service_id, workflow_id, workflow_run_id, commit_hash, image_ref, build_pack, build_config, env_vars_snapshot, memory, vcpus, port, status, error_message, build_progress, trigger, trigger_ref, started_at, finished_at, created_at, updated_at FROM deployments WHERE id = $1
+SELECT id, service_id, workflow_id, workflow_run_id, commit_hash, image_ref, build_pack, build_config, env_vars_snapshot, memory, vcpus, port, status, error_message, build_progress, trigger, trigger_ref, started_at, finished_at, created_at, updated_at, replicas, autoscaling, stack FROM deployments WHERE id = $1
 `
 
 func (q *Queries) GetDeploymentByID(ctx context.Context, id string) (Deployment, error) {
@@ -182,12 +192,15 @@ func (q *Queries) GetDeploymentByID(ctx context.Context, id string) (Deployment,
 		&i.FinishedAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Replicas,
+		&i.Autoscaling,
+		&i.Stack,
 	)
 	return i, err
 }
 
 const getDeploymentByWorkflowID = `-- name: GetDeploymentByWorkflowID :one
-SELECT id, service_id, workflow_id, workflow_run_id, commit_hash, image_ref, build_pack, build_config, env_vars_snapshot, memory, vcpus, port, status, error_message, build_progress, trigger, trigger_ref, started_at, finished_at, created_at, updated_at FROM deployments WHERE workflow_id = $1
+SELECT id, service_id, workflow_id, workflow_run_id, commit_hash, image_ref, build_pack, build_config, env_vars_snapshot, memory, vcpus, port, status, error_message, build_progress, trigger, trigger_ref, started_at, finished_at, created_at, updated_at, replicas, autoscaling, stack FROM deployments WHERE workflow_id = $1
 `
 
 func (q *Queries) GetDeploymentByWorkflowID(ctx context.Context, workflowID string) (Deployment, error) {
@@ -215,12 +228,15 @@ func (q *Queries) GetDeploymentByWorkflowID(ctx context.Context, workflowID stri
 		&i.FinishedAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Replicas,
+		&i.Autoscaling,
+		&i.Stack,
 	)
 	return i, err
 }
 
 const getLatestDeploymentByServiceID = `-- name: GetLatestDeploymentByServiceID :one
-SELECT id, service_id, workflow_id, workflow_run_id, commit_hash, image_ref, build_pack, build_config, env_vars_snapshot, memory, vcpus, port, status, error_message, build_progress, trigger, trigger_ref, started_at, finished_at, created_at, updated_at FROM deployments
+SELECT id, service_id, workflow_id, workflow_run_id, commit_hash, image_ref, build_pack, build_config, env_vars_snapshot, memory, vcpus, port, status, error_message, build_progress, trigger, trigger_ref, started_at, finished_at, created_at, updated_at, replicas, autoscaling, stack FROM deployments
 WHERE service_id = $1
 ORDER BY created_at DESC
 LIMIT 1
@@ -251,14 +267,56 @@ func (q *Queries) GetLatestDeploymentByServiceID(ctx context.Context, serviceID
 		&i.FinishedAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Replicas,
+		&i.Autoscaling,
+		&i.Stack,
+	)
+	return i, err
+}
+
+const getPreviousDeploymentByServiceID = `-- name: GetPreviousDeploymentByServiceID :one
+SELECT id, service_id, workflow_id, workflow_run_id, commit_hash, image_ref, build_pack, build_config, env_vars_snapshot, memory, vcpus, port, status, error_message, build_progress, trigger, trigger_ref, started_at, finished_at, created_at, updated_at, replicas, autoscaling, stack FROM deployments
+WHERE service_id = $1 AND status = 'superseded' AND image_ref IS NOT NULL
+ORDER BY finished_at DESC, created_at DESC
+LIMIT 1
+`
+
+func (q *Queries) GetPreviousDeploymentByServiceID(ctx context.Context, serviceID string) (Deployment, error) {
+	row := q.db.QueryRow(ctx, getPreviousDeploymentByServiceID, serviceID)
+	var i Deployment
+	err := row.Scan(
+		&i.ID,
+		&i.ServiceID,
+		&i.WorkflowID,
+		&i.WorkflowRunID,
+		&i.CommitHash,
+		&i.ImageRef,
+		&i.BuildPack,
+		&i.BuildConfig,
+		&i.EnvVarsSnapshot,
+		&i.Memory,
+		&i.Vcpus,
+		&i.Port,
+		&i.Status,
+		&i.ErrorMessage,
+		&i.BuildProgress,
+		&i.Trigger,
+		&i.TriggerRef,
+		&i.StartedAt,
+		&i.FinishedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Replicas,
+		&i.Autoscaling,
+		&i.Stack,
 	)
 	return i, err
 }
 
 const listDeploymentsByServiceID = `-- name: ListDeploymentsByServiceID :many
-SELECT id, service_id, workflow_id, workflow_run_id, commit_hash, image_ref, build_pack, build_config, env_vars_snapshot, memory, vcpus, port, status, error_message, build_progress, trigger, trigger_ref, started_at, finished_at, created_at, updated_at FROM deployments
+SELECT id, service_id, workflow_id, workflow_run_id, commit_hash, image_ref, build_pack, build_config, env_vars_snapshot, memory, vcpus, port, status, error_message, build_progress, trigger, trigger_ref, started_at, finished_at, created_at, updated_at, replicas, autoscaling, stack FROM deployments
 WHERE service_id = $1
-ORDER BY created_at DESC
+ORDER BY created_at DESC, id DESC
 LIMIT $2 OFFSET $3
 `
 
@@ -299,6 +357,68 @@ func (q *Queries) ListDeploymentsByServiceID(ctx context.Context, arg ListDeploy
 			&i.FinishedAt,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Replicas,
+			&i.Autoscaling,
+			&i.Stack,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listDeploymentsByServiceIDAfter = `-- name: ListDeploymentsByServiceIDAfter :many
+SELECT d.id, d.service_id, d.workflow_id, d.workflow_run_id, d.commit_hash, d.image_ref, d.build_pack, d.build_config, d.env_vars_snapshot, d.memory, d.vcpus, d.port, d.status, d.error_message, d.build_progress, d.trigger, d.trigger_ref, d.started_at, d.finished_at, d.created_at, d.updated_at, d.replicas, d.autoscaling, d.stack FROM deployments d
+WHERE d.service_id = $1
+  AND (d.created_at, d.id) < (SELECT c.created_at, c.id FROM deployments c WHERE c.id = $2 AND c.service_id = $1)
+ORDER BY d.created_at DESC, d.id DESC
+LIMIT $3
+`
+
+type ListDeploymentsByServiceIDAfterParams struct {
+	ServiceID string `json:"service_id"`
+	ID        string `json:"id"`
+	Limit     int32  `json:"limit"`
+}
+
+func (q *Queries) ListDeploymentsByServiceIDAfter(ctx context.Context, arg ListDeploymentsByServiceIDAfterParams) ([]Deployment, error) {
+	rows, err := q.db.Query(ctx, listDeploymentsByServiceIDAfter, arg.ServiceID, arg.ID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Deployment{}
+	for rows.Next() {
+		var i Deployment
+		if err := rows.Scan(
+			&i.ID,
+			&i.ServiceID,
+			&i.WorkflowID,
+			&i.WorkflowRunID,
+			&i.CommitHash,
+			&i.ImageRef,
+			&i.BuildPack,
+			&i.BuildConfig,
+			&i.EnvVarsSnapshot,
+			&i.Memory,
+			&i.Vcpus,
+			&i.Port,
+			&i.Status,
+			&i.ErrorMessage,
+			&i.BuildProgress,
+			&i.Trigger,
+			&i.TriggerRef,
+			&i.StartedAt,
+			&i.FinishedAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Replicas,
+			&i.Autoscaling,
+			&i.Stack,
 		); err != nil {
 			return nil, err
 		}
@@ -312,30 +432,44 @@ func (q *Queries) ListDeploymentsByServiceID(ctx context.Context, arg ListDeploy
 
 const markDeploymentActive = `-- name: MarkDeploymentActive :exec
 UPDATE deployments
-SET status = 'active', commit_hash = $2, image_ref = $3, finished_at = NOW(), updated_at = NOW()
+SET status = 'active', commit_hash = $2, image_ref = $3,
+    port = CASE WHEN port = '' THEN $4::TEXT ELSE port END,
+    stack = $5,
+    finished_at = NOW(), updated_at = NOW()
 WHERE id = $1
 `
 
 type MarkDeploymentActiveParams struct {
-	ID         string  `json:"id"`
-	CommitHash *string `json:"commit_hash"`
-	ImageRef   *string `json:"image_ref"`
+	ID           string  `json:"id"`
+	CommitHash   *string `json:"commit_hash"`
+	ImageRef     *string `json:"image_ref"`
+	ResolvedPort string  `json:"resolved_port"`
+	Stack        []byte  `json:"stack"`
 }
 
 func (q *Queries) MarkDeploymentActive(ctx context.Context, arg MarkDeploymentActiveParams) error {
-	_, err := q.db.Exec(ctx, markDeploymentActive, arg.ID, arg.CommitHash, arg.ImageRef)
+	_, err := q.db.Exec(ctx, markDeploymentActive,
+		arg.ID,
+		arg.CommitHash,
+		arg.ImageRef,
+		arg.ResolvedPort,
+		arg.Stack,
+	)
 	return err
 }
 
-const markDeploymentCancelled = `-- name: MarkDeploymentCancelled :exec
+const markDeploymentCancelled = `-- name: MarkDeploymentCancelled :execrows
 UPDATE deployments
 SET status = 'cancelled', finished_at = NOW(), updated_at = NOW()
-WHERE id = $1
+WHERE id = $1 AND status IN ('queued', 'building', 'deploying')
 `
 
-func (q *Queries) MarkDeploymentCancelled(ctx context.Context, id string) error {
-	_, err := q.db.Exec(ctx, markDeploymentCancelled, id)
-	return err
+func (q *Queries) MarkDeploymentCancelled(ctx context.Context, id string) (int64, error) {
+	result, err := q.db.Exec(ctx, markDeploymentCancelled, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
 const markDeploymentFailed = `-- name: MarkDeploymentFailed :exec
@@ -410,7 +544,7 @@ func (q *Queries) UpdateDeploymentCommitHash(ctx context.Context, arg UpdateDepl
 
 const updateDeploymentDeploying = `-- name: UpdateDeploymentDeploying :exec
 UPDATE deployments
-SET status = 'deploying', updated_at = NOW()
+SET status = 'deploying', started_at = COALESCE(started_at, NOW()), updated_at = NOW()
 WHERE id = $1
 `
 
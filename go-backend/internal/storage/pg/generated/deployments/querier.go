@@ -16,9 +16,11 @@ type Querier interface {
 	GetDeploymentByID(ctx context.Context, id string) (Deployment, error)
 	GetDeploymentByWorkflowID(ctx context.Context, workflowID string) (Deployment, error)
 	GetLatestDeploymentByServiceID(ctx context.Context, serviceID string) (Deployment, error)
+	GetPreviousDeploymentByServiceID(ctx context.Context, serviceID string) (Deployment, error)
 	ListDeploymentsByServiceID(ctx context.Context, arg ListDeploymentsByServiceIDParams) ([]Deployment, error)
+	ListDeploymentsByServiceIDAfter(ctx context.Context, arg ListDeploymentsByServiceIDAfterParams) ([]Deployment, error)
 	MarkDeploymentActive(ctx context.Context, arg MarkDeploymentActiveParams) error
-	MarkDeploymentCancelled(ctx context.Context, id string) error
+	MarkDeploymentCancelled(ctx context.Context, id string) (int64, error)
 	MarkDeploymentFailed(ctx context.Context, arg MarkDeploymentFailedParams) error
 	SupersedeActiveDeployment(ctx context.Context, serviceID string) error
 	UpdateDeploymentBuildProgress(ctx context.Context, arg UpdateDeploymentBuildProgressParams) error
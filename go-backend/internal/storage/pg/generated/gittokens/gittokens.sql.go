@@ -21,9 +21,9 @@ func (q *Queries) CleanupExpired(ctx context.Context) error {
 }
 
 const createToken = `-- name: CreateToken :one
-INSERT INTO git_tokens (token_hash, token_prefix, user_id, repo_id, scopes, expires_at)
-VALUES ($1, $2, $3, $4, $5, $6)
-RETURNING id, token_hash, token_prefix, user_id, repo_id, scopes, expires_at, last_used_at, revoked_at, created_at
+INSERT INTO git_tokens (token_hash, token_prefix, user_id, repo_id, scopes, expires_at, service_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING id, token_hash, token_prefix, user_id, repo_id, scopes, expires_at, last_used_at, revoked_at, created_at, service_id
 `
 
 type CreateTokenParams struct {
@@ -33,6 +33,7 @@ type CreateTokenParams struct {
 	RepoID      *string            `json:"repo_id"`
 	Scopes      []string           `json:"scopes"`
 	ExpiresAt   pgtype.Timestamptz `json:"expires_at"`
+	ServiceID   *string            `json:"service_id"`
 }
 
 func (q *Queries) CreateToken(ctx context.Context, arg CreateTokenParams) (GitToken, error) {
@@ -43,6 +44,7 @@ func (q *Queries) CreateToken(ctx context.Context, arg CreateTokenParams) (GitTo
 		arg.RepoID,
 		arg.Scopes,
 		arg.ExpiresAt,
+		arg.ServiceID,
 	)
 	var i GitToken
 	err := row.Scan(
@@ -56,12 +58,13 @@ func (q *Queries) CreateToken(ctx context.Context, arg CreateTokenParams) (GitTo
 		&i.LastUsedAt,
 		&i.RevokedAt,
 		&i.CreatedAt,
+		&i.ServiceID,
 	)
 	return i, err
 }
 
 const getTokenByHash = `-- name: GetTokenByHash :one
-SELECT gt.id, gt.token_hash, gt.token_prefix, gt.user_id, gt.repo_id, gt.scopes, gt.expires_at, gt.last_used_at, gt.revoked_at, gt.created_at, ir.full_name AS repo_full_name
+SELECT gt.id, gt.token_hash, gt.token_prefix, gt.user_id, gt.repo_id, gt.scopes, gt.expires_at, gt.last_used_at, gt.revoked_at, gt.created_at, gt.service_id, ir.full_name AS repo_full_name
 FROM git_tokens gt
 LEFT JOIN internal_repos ir ON gt.repo_id = ir.id
 WHERE gt.token_hash = $1
@@ -80,6 +83,7 @@ type GetTokenByHashRow struct {
 	LastUsedAt   pgtype.Timestamptz `json:"last_used_at"`
 	RevokedAt    pgtype.Timestamptz `json:"revoked_at"`
 	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+	ServiceID    *string            `json:"service_id"`
 	RepoFullName *string            `json:"repo_full_name"`
 }
 
@@ -97,13 +101,14 @@ func (q *Queries) GetTokenByHash(ctx context.Context, tokenHash string) (GetToke
 		&i.LastUsedAt,
 		&i.RevokedAt,
 		&i.CreatedAt,
+		&i.ServiceID,
 		&i.RepoFullName,
 	)
 	return i, err
 }
 
 const listByRepoID = `-- name: ListByRepoID :many
-SELECT id, token_hash, token_prefix, user_id, repo_id, scopes, expires_at, last_used_at, revoked_at, created_at FROM git_tokens
+SELECT id, token_hash, token_prefix, user_id, repo_id, scopes, expires_at, last_used_at, revoked_at, created_at, service_id FROM git_tokens
 WHERE repo_id = $1
   AND revoked_at IS NULL
 ORDER BY created_at DESC
@@ -129,6 +134,7 @@ func (q *Queries) ListByRepoID(ctx context.Context, repoID *string) ([]GitToken,
 			&i.LastUsedAt,
 			&i.RevokedAt,
 			&i.CreatedAt,
+			&i.ServiceID,
 		); err != nil {
 			return nil, err
 		}
@@ -141,7 +147,7 @@ func (q *Queries) ListByRepoID(ctx context.Context, repoID *string) ([]GitToken,
 }
 
 const listByUserID = `-- name: ListByUserID :many
-SELECT id, token_hash, token_prefix, user_id, repo_id, scopes, expires_at, last_used_at, revoked_at, created_at FROM git_tokens
+SELECT id, token_hash, token_prefix, user_id, repo_id, scopes, expires_at, last_used_at, revoked_at, created_at, service_id FROM git_tokens
 WHERE user_id = $1
   AND revoked_at IS NULL
 ORDER BY created_at DESC
@@ -167,6 +173,7 @@ func (q *Queries) ListByUserID(ctx context.Context, userID string) ([]GitToken,
 			&i.LastUsedAt,
 			&i.RevokedAt,
 			&i.CreatedAt,
+			&i.ServiceID,
 		); err != nil {
 			return nil, err
 		}
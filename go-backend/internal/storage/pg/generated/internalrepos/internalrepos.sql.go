@@ -12,7 +12,7 @@ import (
 const createInternalRepo = `-- name: CreateInternalRepo :one
 INSERT INTO internal_repos (user_id, project_id, name, clone_url, provider, repo_id, full_name, bare_path)
 VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
-RETURNING id, user_id, name, clone_url, provider, repo_id, full_name, created_at, updated_at, bare_path, project_id
+RETURNING id, user_id, name, clone_url, provider, repo_id, full_name, created_at, updated_at, bare_path, project_id, protected_branches, max_pack_bytes, max_file_bytes, size_bytes, size_updated_at
 `
 
 type CreateInternalRepoParams struct {
@@ -50,6 +50,11 @@ func (q *Queries) CreateInternalRepo(ctx context.Context, arg CreateInternalRepo
 		&i.UpdatedAt,
 		&i.BarePath,
 		&i.ProjectID,
+		&i.ProtectedBranches,
+		&i.MaxPackBytes,
+		&i.MaxFileBytes,
+		&i.SizeBytes,
+		&i.SizeUpdatedAt,
 	)
 	return i, err
 }
@@ -73,7 +78,7 @@ func (q *Queries) DeleteInternalRepoByFullName(ctx context.Context, fullName str
 }
 
 const getInternalRepoByFullName = `-- name: GetInternalRepoByFullName :one
-SELECT id, user_id, name, clone_url, provider, repo_id, full_name, created_at, updated_at, bare_path, project_id FROM internal_repos WHERE full_name = $1
+SELECT id, user_id, name, clone_url, provider, repo_id, full_name, created_at, updated_at, bare_path, project_id, protected_branches, max_pack_bytes, max_file_bytes, size_bytes, size_updated_at FROM internal_repos WHERE full_name = $1
 `
 
 func (q *Queries) GetInternalRepoByFullName(ctx context.Context, fullName string) (InternalRepo, error) {
@@ -91,12 +96,17 @@ func (q *Queries) GetInternalRepoByFullName(ctx context.Context, fullName string
 		&i.UpdatedAt,
 		&i.BarePath,
 		&i.ProjectID,
+		&i.ProtectedBranches,
+		&i.MaxPackBytes,
+		&i.MaxFileBytes,
+		&i.SizeBytes,
+		&i.SizeUpdatedAt,
 	)
 	return i, err
 }
 
 const getInternalRepoByID = `-- name: GetInternalRepoByID :one
-SELECT id, user_id, name, clone_url, provider, repo_id, full_name, created_at, updated_at, bare_path, project_id FROM internal_repos WHERE id = $1
+SELECT id, user_id, name, clone_url, provider, repo_id, full_name, created_at, updated_at, bare_path, project_id, protected_branches, max_pack_bytes, max_file_bytes, size_bytes, size_updated_at FROM internal_repos WHERE id = $1
 `
 
 func (q *Queries) GetInternalRepoByID(ctx context.Context, id string) (InternalRepo, error) {
@@ -114,12 +124,17 @@ func (q *Queries) GetInternalRepoByID(ctx context.Context, id string) (InternalR
 		&i.UpdatedAt,
 		&i.BarePath,
 		&i.ProjectID,
+		&i.ProtectedBranches,
+		&i.MaxPackBytes,
+		&i.MaxFileBytes,
+		&i.SizeBytes,
+		&i.SizeUpdatedAt,
 	)
 	return i, err
 }
 
 const getInternalRepoByProjectAndName = `-- name: GetInternalRepoByProjectAndName :one
-SELECT id, user_id, name, clone_url, provider, repo_id, full_name, created_at, updated_at, bare_path, project_id FROM internal_repos WHERE project_id = $1 AND name = $2
+SELECT id, user_id, name, clone_url, provider, repo_id, full_name, created_at, updated_at, bare_path, project_id, protected_branches, max_pack_bytes, max_file_bytes, size_bytes, size_updated_at FROM internal_repos WHERE project_id = $1 AND name = $2
 `
 
 type GetInternalRepoByProjectAndNameParams struct {
@@ -142,12 +157,74 @@ func (q *Queries) GetInternalRepoByProjectAndName(ctx context.Context, arg GetIn
 		&i.UpdatedAt,
 		&i.BarePath,
 		&i.ProjectID,
+		&i.ProtectedBranches,
+		&i.MaxPackBytes,
+		&i.MaxFileBytes,
+		&i.SizeBytes,
+		&i.SizeUpdatedAt,
 	)
 	return i, err
 }
 
+const getUserRepoUsage = `-- name: GetUserRepoUsage :one
+SELECT COALESCE(SUM(size_bytes), 0)::BIGINT AS total_bytes
+FROM internal_repos
+WHERE user_id = $1
+`
+
+// Total size of a user's internal repos.
+func (q *Queries) GetUserRepoUsage(ctx context.Context, userID string) (int64, error) {
+	row := q.db.QueryRow(ctx, getUserRepoUsage, userID)
+	var total_bytes int64
+	err := row.Scan(&total_bytes)
+	return total_bytes, err
+}
+
+const listInternalReposByProjectID = `-- name: ListInternalReposByProjectID :many
+SELECT id, user_id, name, clone_url, provider, repo_id, full_name, created_at, updated_at, bare_path, project_id, protected_branches, max_pack_bytes, max_file_bytes, size_bytes, size_updated_at FROM internal_repos
+WHERE project_id = $1
+ORDER BY created_at DESC
+`
+
+func (q *Queries) ListInternalReposByProjectID(ctx context.Context, projectID string) ([]InternalRepo, error) {
+	rows, err := q.db.Query(ctx, listInternalReposByProjectID, projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []InternalRepo{}
+	for rows.Next() {
+		var i InternalRepo
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Name,
+			&i.CloneUrl,
+			&i.Provider,
+			&i.RepoID,
+			&i.FullName,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.BarePath,
+			&i.ProjectID,
+			&i.ProtectedBranches,
+			&i.MaxPackBytes,
+			&i.MaxFileBytes,
+			&i.SizeBytes,
+			&i.SizeUpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listInternalReposByUserID = `-- name: ListInternalReposByUserID :many
-SELECT id, user_id, name, clone_url, provider, repo_id, full_name, created_at, updated_at, bare_path, project_id FROM internal_repos
+SELECT id, user_id, name, clone_url, provider, repo_id, full_name, created_at, updated_at, bare_path, project_id, protected_branches, max_pack_bytes, max_file_bytes, size_bytes, size_updated_at FROM internal_repos
 WHERE user_id = $1
 ORDER BY created_at DESC
 `
@@ -173,6 +250,11 @@ func (q *Queries) ListInternalReposByUserID(ctx context.Context, userID string)
 			&i.UpdatedAt,
 			&i.BarePath,
 			&i.ProjectID,
+			&i.ProtectedBranches,
+			&i.MaxPackBytes,
+			&i.MaxFileBytes,
+			&i.SizeBytes,
+			&i.SizeUpdatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -183,3 +265,67 @@ func (q *Queries) ListInternalReposByUserID(ctx context.Context, userID string)
 	}
 	return items, nil
 }
+
+const updateInternalRepoPolicy = `-- name: UpdateInternalRepoPolicy :one
+UPDATE internal_repos
+SET protected_branches = $2,
+    max_pack_bytes = $3,
+    max_file_bytes = $4,
+    updated_at = NOW()
+WHERE id = $1
+RETURNING id, user_id, name, clone_url, provider, repo_id, full_name, created_at, updated_at, bare_path, project_id, protected_branches, max_pack_bytes, max_file_bytes, size_bytes, size_updated_at
+`
+
+type UpdateInternalRepoPolicyParams struct {
+	ID                string   `json:"id"`
+	ProtectedBranches []string `json:"protected_branches"`
+	MaxPackBytes      *int64   `json:"max_pack_bytes"`
+	MaxFileBytes      *int64   `json:"max_file_bytes"`
+}
+
+func (q *Queries) UpdateInternalRepoPolicy(ctx context.Context, arg UpdateInternalRepoPolicyParams) (InternalRepo, error) {
+	row := q.db.QueryRow(ctx, updateInternalRepoPolicy,
+		arg.ID,
+		arg.ProtectedBranches,
+		arg.MaxPackBytes,
+		arg.MaxFileBytes,
+	)
+	var i InternalRepo
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Name,
+		&i.CloneUrl,
+		&i.Provider,
+		&i.RepoID,
+		&i.FullName,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.BarePath,
+		&i.ProjectID,
+		&i.ProtectedBranches,
+		&i.MaxPackBytes,
+		&i.MaxFileBytes,
+		&i.SizeBytes,
+		&i.SizeUpdatedAt,
+	)
+	return i, err
+}
+
+const updateInternalRepoSize = `-- name: UpdateInternalRepoSize :exec
+UPDATE internal_repos
+SET size_bytes = $2,
+    size_updated_at = NOW()
+WHERE full_name = $1
+`
+
+type UpdateInternalRepoSizeParams struct {
+	FullName  string `json:"full_name"`
+	SizeBytes int64  `json:"size_bytes"`
+}
+
+// Repos are measured on disk, where the git server knows them by full name.
+func (q *Queries) UpdateInternalRepoSize(ctx context.Context, arg UpdateInternalRepoSizeParams) error {
+	_, err := q.db.Exec(ctx, updateInternalRepoSize, arg.FullName, arg.SizeBytes)
+	return err
+}
@@ -15,7 +15,13 @@ type Querier interface {
 	GetInternalRepoByFullName(ctx context.Context, fullName string) (InternalRepo, error)
 	GetInternalRepoByID(ctx context.Context, id string) (InternalRepo, error)
 	GetInternalRepoByProjectAndName(ctx context.Context, arg GetInternalRepoByProjectAndNameParams) (InternalRepo, error)
+	// Total size of a user's internal repos.
+	GetUserRepoUsage(ctx context.Context, userID string) (int64, error)
+	ListInternalReposByProjectID(ctx context.Context, projectID string) ([]InternalRepo, error)
 	ListInternalReposByUserID(ctx context.Context, userID string) ([]InternalRepo, error)
+	UpdateInternalRepoPolicy(ctx context.Context, arg UpdateInternalRepoPolicyParams) (InternalRepo, error)
+	// Repos are measured on disk, where the git server knows them by full name.
+	UpdateInternalRepoSize(ctx context.Context, arg UpdateInternalRepoSizeParams) error
 }
 
 var _ Querier = (*Queries)(nil)
@@ -9,6 +9,22 @@ import (
 	"context"
 )
 
+const countProjectWorkloads = `-- name: CountProjectWorkloads :one
+SELECT (
+    (SELECT COUNT(*) FROM services s WHERE s.project_id = $1 AND s.is_deleted = false)
+    + (SELECT COUNT(*) FROM resources r WHERE r.project_id = $1 AND r.provider = 'cluster')
+)::BIGINT AS workloads
+`
+
+// CountProjectWorkloads counts what runs in the project's namespace: its
+// live services and in-cluster resources.
+func (q *Queries) CountProjectWorkloads(ctx context.Context, projectID string) (int64, error) {
+	row := q.db.QueryRow(ctx, countProjectWorkloads, projectID)
+	var workloads int64
+	err := row.Scan(&workloads)
+	return workloads, err
+}
+
 const countProjectsByUserID = `-- name: CountProjectsByUserID :one
 SELECT COUNT(*) FROM projects WHERE user_id = $1
 `
@@ -9,6 +9,9 @@ import (
 )
 
 type Querier interface {
+	// CountProjectWorkloads counts what runs in the project's namespace: its
+	// live services and in-cluster resources.
+	CountProjectWorkloads(ctx context.Context, projectID string) (int64, error)
 	CountProjectsByUserID(ctx context.Context, userID string) (int64, error)
 	CreateDefaultProject(ctx context.Context, userID string) (Project, error)
 	CreateProject(ctx context.Context, arg CreateProjectParams) (Project, error)
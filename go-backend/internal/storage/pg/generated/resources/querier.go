@@ -14,13 +14,20 @@ type Querier interface {
 	DeleteResource(ctx context.Context, id string) error
 	DeleteResourceByUserAndID(ctx context.Context, arg DeleteResourceByUserAndIDParams) error
 	GetResourceByID(ctx context.Context, id string) (Resource, error)
-	GetResourceByUserAndName(ctx context.Context, arg GetResourceByUserAndNameParams) (Resource, error)
+	GetResourceByProjectAndName(ctx context.Context, arg GetResourceByProjectAndNameParams) (Resource, error)
+	GetResourceUsage(ctx context.Context, resourceID string) (ResourceUsage, error)
+	ListActiveResourcesByProvider(ctx context.Context, provider string) ([]Resource, error)
+	ListResourceUsageByUser(ctx context.Context, userID string) ([]ResourceUsage, error)
 	ListResourcesByProject(ctx context.Context, arg ListResourcesByProjectParams) ([]Resource, error)
 	ListResourcesByUser(ctx context.Context, arg ListResourcesByUserParams) ([]Resource, error)
 	ListResourcesByUserAndType(ctx context.Context, arg ListResourcesByUserAndTypeParams) ([]Resource, error)
+	// Services bound to the resource or referencing it by name in their env vars,
+	// which only resolve within the service's project.
+	ListServiceIDsByResource(ctx context.Context, id string) ([]string, error)
 	UpdateResourceAfterProvisioning(ctx context.Context, arg UpdateResourceAfterProvisioningParams) (Resource, error)
 	UpdateResourceCredentials(ctx context.Context, arg UpdateResourceCredentialsParams) error
 	UpdateResourceStatus(ctx context.Context, arg UpdateResourceStatusParams) error
+	UpsertResourceUsage(ctx context.Context, arg UpsertResourceUsageParams) error
 }
 
 var _ Querier = (*Queries)(nil)
@@ -126,17 +126,17 @@ func (q *Queries) GetResourceByID(ctx context.Context, id string) (Resource, err
 	return i, err
 }
 
-const getResourceByUserAndName = `-- name: GetResourceByUserAndName :one
-SELECT id, user_id, project_id, name, type, provider, region, external_id, connection_url, auth_token, credentials, metadata, status, created_at, updated_at FROM resources WHERE user_id = $1 AND name = $2
+const getResourceByProjectAndName = `-- name: GetResourceByProjectAndName :one
+SELECT id, user_id, project_id, name, type, provider, region, external_id, connection_url, auth_token, credentials, metadata, status, created_at, updated_at FROM resources WHERE project_id = $1 AND name = $2
 `
 
-type GetResourceByUserAndNameParams struct {
-	UserID string `json:"user_id"`
-	Name   string `json:"name"`
+type GetResourceByProjectAndNameParams struct {
+	ProjectID string `json:"project_id"`
+	Name      string `json:"name"`
 }
 
-func (q *Queries) GetResourceByUserAndName(ctx context.Context, arg GetResourceByUserAndNameParams) (Resource, error) {
-	row := q.db.QueryRow(ctx, getResourceByUserAndName, arg.UserID, arg.Name)
+func (q *Queries) GetResourceByProjectAndName(ctx context.Context, arg GetResourceByProjectAndNameParams) (Resource, error) {
+	row := q.db.QueryRow(ctx, getResourceByProjectAndName, arg.ProjectID, arg.Name)
 	var i Resource
 	err := row.Scan(
 		&i.ID,
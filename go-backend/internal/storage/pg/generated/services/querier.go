@@ -9,20 +9,34 @@ import (
 )
 
 type Querier interface {
+	ClearServiceSleeping(ctx context.Context, id string) error
 	CountServicesByProjectID(ctx context.Context, projectID string) (int64, error)
 	CountServicesByUserID(ctx context.Context, userID string) (int64, error)
 	CreateService(ctx context.Context, arg CreateServiceParams) (Service, error)
+	CreateServiceResource(ctx context.Context, arg CreateServiceResourceParams) error
 	DeleteService(ctx context.Context, id string) error
+	DeleteSourceUpload(ctx context.Context, id string) error
+	GetLatestSourceUpload(ctx context.Context, serviceID string) (SourceUpload, error)
+	GetReferencedResource(ctx context.Context, arg GetReferencedResourceParams) (GetReferencedResourceRow, error)
+	GetServiceByHost(ctx context.Context, host string) (Service, error)
 	GetServiceByID(ctx context.Context, id string) (Service, error)
 	GetServiceByNameAndProject(ctx context.Context, arg GetServiceByNameAndProjectParams) (Service, error)
 	GetServiceByNameAndUserProject(ctx context.Context, arg GetServiceByNameAndUserProjectParams) (Service, error)
 	GetServicesByRepoBranch(ctx context.Context, arg GetServicesByRepoBranchParams) ([]Service, error)
 	GetServicesByRepoBranchProvider(ctx context.Context, arg GetServicesByRepoBranchProviderParams) ([]Service, error)
+	ListServiceResources(ctx context.Context, serviceID string) ([]ListServiceResourcesRow, error)
 	ListServicesByProjectID(ctx context.Context, arg ListServicesByProjectIDParams) ([]Service, error)
 	ListServicesByUserID(ctx context.Context, arg ListServicesByUserIDParams) ([]Service, error)
+	ListSleepCandidates(ctx context.Context, region string) ([]Service, error)
+	ListStaleSourceUploads(ctx context.Context, arg ListStaleSourceUploadsParams) ([]SourceUpload, error)
+	MarkServiceSleeping(ctx context.Context, id string) (int64, error)
 	SetCurrentDeploymentID(ctx context.Context, arg SetCurrentDeploymentIDParams) error
+	SetResourceCredentials(ctx context.Context, arg SetResourceCredentialsParams) error
+	SetResourceStatus(ctx context.Context, arg SetResourceStatusParams) error
 	SetServiceFQDN(ctx context.Context, arg SetServiceFQDNParams) error
 	SoftDeleteService(ctx context.Context, id string) (Service, error)
+	UpdateServiceConfig(ctx context.Context, arg UpdateServiceConfigParams) (Service, error)
+	UpsertSourceUpload(ctx context.Context, arg UpsertSourceUploadParams) (SourceUpload, error)
 }
 
 var _ Querier = (*Queries)(nil)
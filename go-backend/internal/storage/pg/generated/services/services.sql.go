@@ -9,6 +9,17 @@ import (
 	"context"
 )
 
+const clearServiceSleeping = `-- name: ClearServiceSleeping :exec
+UPDATE services
+SET slept_at = NULL
+WHERE id = $1
+`
+
+func (q *Queries) ClearServiceSleeping(ctx context.Context, id string) error {
+	_, err := q.db.Exec(ctx, clearServiceSleeping, id)
+	return err
+}
+
 const countServicesByProjectID = `-- name: CountServicesByProjectID :one
 SELECT COUNT(*) FROM services WHERE project_id = $1 AND is_deleted = false
 `
@@ -33,29 +44,35 @@ func (q *Queries) CountServicesByUserID(ctx context.Context, userID string) (int
 
 const createService = `-- name: CreateService :one
 INSERT INTO services (
-    id, user_id, project_id, repo, branch, server_uuid, name, build_pack, port, env_vars, git_provider, build_config, memory, vcpus, region
+    id, user_id, project_id, repo, branch, server_uuid, name, build_pack, port, env_vars, git_provider, build_config, memory, vcpus, region, replicas, autoscaling, sleep_after, source_type, image, registry_credentials
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21
 )
-RETURNING id, user_id, project_id, repo, branch, git_provider, name, port, build_pack, env_vars, build_config, memory, vcpus, publish_directory, fqdn, custom_domain, server_uuid, current_deployment_id, is_deleted, created_at, updated_at, region
+RETURNING id, user_id, project_id, repo, branch, git_provider, name, port, build_pack, env_vars, build_config, memory, vcpus, publish_directory, fqdn, custom_domain, server_uuid, current_deployment_id, is_deleted, created_at, updated_at, region, replicas, autoscaling, sleep_after, slept_at, source_type, image, registry_credentials
 `
 
 type CreateServiceParams struct {
-	ID          string  `json:"id"`
-	UserID      string  `json:"user_id"`
-	ProjectID   string  `json:"project_id"`
-	Repo        string  `json:"repo"`
-	Branch      string  `json:"branch"`
-	ServerUuid  string  `json:"server_uuid"`
-	Name        *string `json:"name"`
-	BuildPack   string  `json:"build_pack"`
-	Port        string  `json:"port"`
-	EnvVars     []byte  `json:"env_vars"`
-	GitProvider string  `json:"git_provider"`
-	BuildConfig []byte  `json:"build_config"`
-	Memory      string  `json:"memory"`
-	Vcpus       string  `json:"vcpus"`
-	Region      string  `json:"region"`
+	ID                  string  `json:"id"`
+	UserID              string  `json:"user_id"`
+	ProjectID           string  `json:"project_id"`
+	Repo                string  `json:"repo"`
+	Branch              string  `json:"branch"`
+	ServerUuid          string  `json:"server_uuid"`
+	Name                *string `json:"name"`
+	BuildPack           string  `json:"build_pack"`
+	Port                string  `json:"port"`
+	EnvVars             []byte  `json:"env_vars"`
+	GitProvider         string  `json:"git_provider"`
+	BuildConfig         []byte  `json:"build_config"`
+	Memory              string  `json:"memory"`
+	Vcpus               string  `json:"vcpus"`
+	Region              string  `json:"region"`
+	Replicas            int32   `json:"replicas"`
+	Autoscaling         []byte  `json:"autoscaling"`
+	SleepAfter          *int32  `json:"sleep_after"`
+	SourceType          string  `json:"source_type"`
+	Image               *string `json:"image"`
+	RegistryCredentials []byte  `json:"registry_credentials"`
 }
 
 func (q *Queries) CreateService(ctx context.Context, arg CreateServiceParams) (Service, error) {
@@ -75,6 +92,12 @@ func (q *Queries) CreateService(ctx context.Context, arg CreateServiceParams) (S
 		arg.Memory,
 		arg.Vcpus,
 		arg.Region,
+		arg.Replicas,
+		arg.Autoscaling,
+		arg.SleepAfter,
+		arg.SourceType,
+		arg.Image,
+		arg.RegistryCredentials,
 	)
 	var i Service
 	err := row.Scan(
@@ -100,6 +123,13 @@ func (q *Queries) CreateService(ctx context.Context, arg CreateServiceParams) (S
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.Region,
+		&i.Replicas,
+		&i.Autoscaling,
+		&i.SleepAfter,
+		&i.SleptAt,
+		&i.SourceType,
+		&i.Image,
+		&i.RegistryCredentials,
 	)
 	return i, err
 }
@@ -113,8 +143,60 @@ func (q *Queries) DeleteService(ctx context.Context, id string) error {
 	return err
 }
 
+const getServiceByHost = `-- name: GetServiceByHost :one
+SELECT s.id, s.user_id, s.project_id, s.repo, s.branch, s.git_provider, s.name, s.port, s.build_pack, s.env_vars, s.build_config, s.memory, s.vcpus, s.publish_directory, s.fqdn, s.custom_domain, s.server_uuid, s.current_deployment_id, s.is_deleted, s.created_at, s.updated_at, s.region, s.replicas, s.autoscaling, s.sleep_after, s.slept_at, s.source_type, s.image, s.registry_credentials FROM services s
+WHERE s.is_deleted = false
+  AND (
+    lower(s.fqdn) = 'https://' || lower($1::TEXT)
+    OR EXISTS (
+      SELECT 1 FROM zone_records zr
+      JOIN delegated_zones dz ON dz.id = zr.zone_id
+      WHERE zr.service_id = s.id
+        AND lower(CASE WHEN zr.name = '@' THEN dz.zone ELSE zr.name || '.' || dz.zone END) = lower($1::TEXT)
+    )
+  )
+LIMIT 1
+`
+
+func (q *Queries) GetServiceByHost(ctx context.Context, host string) (Service, error) {
+	row := q.db.QueryRow(ctx, getServiceByHost, host)
+	var i Service
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.ProjectID,
+		&i.Repo,
+		&i.Branch,
+		&i.GitProvider,
+		&i.Name,
+		&i.Port,
+		&i.BuildPack,
+		&i.EnvVars,
+		&i.BuildConfig,
+		&i.Memory,
+		&i.Vcpus,
+		&i.PublishDirectory,
+		&i.Fqdn,
+		&i.CustomDomain,
+		&i.ServerUuid,
+		&i.CurrentDeploymentID,
+		&i.IsDeleted,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Region,
+		&i.Replicas,
+		&i.Autoscaling,
+		&i.SleepAfter,
+		&i.SleptAt,
+		&i.SourceType,
+		&i.Image,
+		&i.RegistryCredentials,
+	)
+	return i, err
+}
+
 const getServiceByID = `-- name: GetServiceByID :one
-SELECT id, user_id, project_id, repo, branch, git_provider, name, port, build_pack, env_vars, build_config, memory, vcpus, publish_directory, fqdn, custom_domain, server_uuid, current_deployment_id, is_deleted, created_at, updated_at, region FROM services WHERE id = $1 AND is_deleted = false
+SELECT id, user_id, project_id, repo, branch, git_provider, name, port, build_pack, env_vars, build_config, memory, vcpus, publish_directory, fqdn, custom_domain, server_uuid, current_deployment_id, is_deleted, created_at, updated_at, region, replicas, autoscaling, sleep_after, slept_at, source_type, image, registry_credentials FROM services WHERE id = $1 AND is_deleted = false
 `
 
 func (q *Queries) GetServiceByID(ctx context.Context, id string) (Service, error) {
@@ -143,12 +225,19 @@ func (q *Queries) GetServiceByID(ctx context.Context, id string) (Service, error
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.Region,
+		&i.Replicas,
+		&i.Autoscaling,
+		&i.SleepAfter,
+		&i.SleptAt,
+		&i.SourceType,
+		&i.Image,
+		&i.RegistryCredentials,
 	)
 	return i, err
 }
 
 const getServiceByNameAndProject = `-- name: GetServiceByNameAndProject :one
-SELECT id, user_id, project_id, repo, branch, git_provider, name, port, build_pack, env_vars, build_config, memory, vcpus, publish_directory, fqdn, custom_domain, server_uuid, current_deployment_id, is_deleted, created_at, updated_at, region FROM services
+SELECT id, user_id, project_id, repo, branch, git_provider, name, port, build_pack, env_vars, build_config, memory, vcpus, publish_directory, fqdn, custom_domain, server_uuid, current_deployment_id, is_deleted, created_at, updated_at, region, replicas, autoscaling, sleep_after, slept_at, source_type, image, registry_credentials FROM services
 WHERE name = $1 AND project_id = $2 AND is_deleted = false
 `
 
@@ -183,12 +272,19 @@ func (q *Queries) GetServiceByNameAndProject(ctx context.Context, arg GetService
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.Region,
+		&i.Replicas,
+		&i.Autoscaling,
+		&i.SleepAfter,
+		&i.SleptAt,
+		&i.SourceType,
+		&i.Image,
+		&i.RegistryCredentials,
 	)
 	return i, err
 }
 
 const getServiceByNameAndUserProject = `-- name: GetServiceByNameAndUserProject :one
-SELECT a.id, a.user_id, a.project_id, a.repo, a.branch, a.git_provider, a.name, a.port, a.build_pack, a.env_vars, a.build_config, a.memory, a.vcpus, a.publish_directory, a.fqdn, a.custom_domain, a.server_uuid, a.current_deployment_id, a.is_deleted, a.created_at, a.updated_at, a.region FROM services a
+SELECT a.id, a.user_id, a.project_id, a.repo, a.branch, a.git_provider, a.name, a.port, a.build_pack, a.env_vars, a.build_config, a.memory, a.vcpus, a.publish_directory, a.fqdn, a.custom_domain, a.server_uuid, a.current_deployment_id, a.is_deleted, a.created_at, a.updated_at, a.region, a.replicas, a.autoscaling, a.sleep_after, a.slept_at, a.source_type, a.image, a.registry_credentials FROM services a
 JOIN projects p ON a.project_id = p.id
 WHERE a.name = $1
   AND p.user_id = $2
@@ -230,12 +326,19 @@ func (q *Queries) GetServiceByNameAndUserProject(ctx context.Context, arg GetSer
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.Region,
+		&i.Replicas,
+		&i.Autoscaling,
+		&i.SleepAfter,
+		&i.SleptAt,
+		&i.SourceType,
+		&i.Image,
+		&i.RegistryCredentials,
 	)
 	return i, err
 }
 
 const getServicesByRepoBranch = `-- name: GetServicesByRepoBranch :many
-SELECT id, user_id, project_id, repo, branch, git_provider, name, port, build_pack, env_vars, build_config, memory, vcpus, publish_directory, fqdn, custom_domain, server_uuid, current_deployment_id, is_deleted, created_at, updated_at, region FROM services
+SELECT id, user_id, project_id, repo, branch, git_provider, name, port, build_pack, env_vars, build_config, memory, vcpus, publish_directory, fqdn, custom_domain, server_uuid, current_deployment_id, is_deleted, created_at, updated_at, region, replicas, autoscaling, sleep_after, slept_at, source_type, image, registry_credentials FROM services
 WHERE repo = $1 AND branch = $2 AND is_deleted = false
 `
 
@@ -276,6 +379,16 @@ func (q *Queries) GetServicesByRepoBranch(ctx context.Context, arg GetServicesBy
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.Region,
+			&i.Replicas,
+			&i.Autoscaling,
+			&i.SleepAfter,
+			&i.SleptAt,
+			&i.SourceType,
+			&i.Image,
+			&i.RegistryCredentials,
+			&i.SourceType,
+			&i.Image,
+			&i.RegistryCredentials,
 		); err != nil {
 			return nil, err
 		}
@@ -288,7 +401,7 @@ func (q *Queries) GetServicesByRepoBranch(ctx context.Context, arg GetServicesBy
 }
 
 const getServicesByRepoBranchProvider = `-- name: GetServicesByRepoBranchProvider :many
-SELECT id, user_id, project_id, repo, branch, git_provider, name, port, build_pack, env_vars, build_config, memory, vcpus, publish_directory, fqdn, custom_domain, server_uuid, current_deployment_id, is_deleted, created_at, updated_at, region FROM services
+SELECT id, user_id, project_id, repo, branch, git_provider, name, port, build_pack, env_vars, build_config, memory, vcpus, publish_directory, fqdn, custom_domain, server_uuid, current_deployment_id, is_deleted, created_at, updated_at, region, replicas, autoscaling, sleep_after, slept_at, source_type, image, registry_credentials FROM services
 WHERE repo = $1 AND branch = $2 AND git_provider = $3 AND is_deleted = false
 `
 
@@ -330,6 +443,84 @@ func (q *Queries) GetServicesByRepoBranchProvider(ctx context.Context, arg GetSe
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.Region,
+			&i.Replicas,
+			&i.Autoscaling,
+			&i.SleepAfter,
+			&i.SleptAt,
+			&i.SourceType,
+			&i.Image,
+			&i.RegistryCredentials,
+			&i.SourceType,
+			&i.Image,
+			&i.RegistryCredentials,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSleepCandidates = `-- name: ListSleepCandidates :many
+SELECT s.id, s.user_id, s.project_id, s.repo, s.branch, s.git_provider, s.name, s.port, s.build_pack, s.env_vars, s.build_config, s.memory, s.vcpus, s.publish_directory, s.fqdn, s.custom_domain, s.server_uuid, s.current_deployment_id, s.is_deleted, s.created_at, s.updated_at, s.region, s.replicas, s.autoscaling, s.sleep_after, s.slept_at, s.source_type, s.image, s.registry_credentials FROM services s
+WHERE s.region = $1
+  AND s.sleep_after IS NOT NULL
+  AND s.slept_at IS NULL
+  AND s.autoscaling IS NULL
+  AND s.current_deployment_id IS NOT NULL
+  AND s.is_deleted = false
+  AND NOT EXISTS (
+    SELECT 1 FROM deployments d
+    WHERE d.service_id = s.id AND d.status IN ('queued','building','deploying')
+  )
+ORDER BY s.id
+`
+
+func (q *Queries) ListSleepCandidates(ctx context.Context, region string) ([]Service, error) {
+	rows, err := q.db.Query(ctx, listSleepCandidates, region)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Service{}
+	for rows.Next() {
+		var i Service
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.ProjectID,
+			&i.Repo,
+			&i.Branch,
+			&i.GitProvider,
+			&i.Name,
+			&i.Port,
+			&i.BuildPack,
+			&i.EnvVars,
+			&i.BuildConfig,
+			&i.Memory,
+			&i.Vcpus,
+			&i.PublishDirectory,
+			&i.Fqdn,
+			&i.CustomDomain,
+			&i.ServerUuid,
+			&i.CurrentDeploymentID,
+			&i.IsDeleted,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Region,
+			&i.Replicas,
+			&i.Autoscaling,
+			&i.SleepAfter,
+			&i.SleptAt,
+			&i.SourceType,
+			&i.Image,
+			&i.RegistryCredentials,
+			&i.SourceType,
+			&i.Image,
+			&i.RegistryCredentials,
 		); err != nil {
 			return nil, err
 		}
@@ -342,7 +533,7 @@ func (q *Queries) GetServicesByRepoBranchProvider(ctx context.Context, arg GetSe
 }
 
 const listServicesByProjectID = `-- name: ListServicesByProjectID :many
-SELECT id, user_id, project_id, repo, branch, git_provider, name, port, build_pack, env_vars, build_config, memory, vcpus, publish_directory, fqdn, custom_domain, server_uuid, current_deployment_id, is_deleted, created_at, updated_at, region FROM services
+SELECT id, user_id, project_id, repo, branch, git_provider, name, port, build_pack, env_vars, build_config, memory, vcpus, publish_directory, fqdn, custom_domain, server_uuid, current_deployment_id, is_deleted, created_at, updated_at, region, replicas, autoscaling, sleep_after, slept_at, source_type, image, registry_credentials FROM services
 WHERE project_id = $1 AND is_deleted = false
 ORDER BY created_at DESC
 LIMIT $2 OFFSET $3
@@ -386,6 +577,16 @@ func (q *Queries) ListServicesByProjectID(ctx context.Context, arg ListServicesB
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.Region,
+			&i.Replicas,
+			&i.Autoscaling,
+			&i.SleepAfter,
+			&i.SleptAt,
+			&i.SourceType,
+			&i.Image,
+			&i.RegistryCredentials,
+			&i.SourceType,
+			&i.Image,
+			&i.RegistryCredentials,
 		); err != nil {
 			return nil, err
 		}
@@ -398,7 +599,7 @@ func (q *Queries) ListServicesByProjectID(ctx context.Context, arg ListServicesB
 }
 
 const listServicesByUserID = `-- name: ListServicesByUserID :many
-SELECT id, user_id, project_id, repo, branch, git_provider, name, port, build_pack, env_vars, build_config, memory, vcpus, publish_directory, fqdn, custom_domain, server_uuid, current_deployment_id, is_deleted, created_at, updated_at, region FROM services
+SELECT id, user_id, project_id, repo, branch, git_provider, name, port, build_pack, env_vars, build_config, memory, vcpus, publish_directory, fqdn, custom_domain, server_uuid, current_deployment_id, is_deleted, created_at, updated_at, region, replicas, autoscaling, sleep_after, slept_at, source_type, image, registry_credentials FROM services
 WHERE user_id = $1 AND is_deleted = false
 ORDER BY created_at DESC
 LIMIT $2 OFFSET $3
@@ -442,6 +643,16 @@ func (q *Queries) ListServicesByUserID(ctx context.Context, arg ListServicesByUs
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.Region,
+			&i.Replicas,
+			&i.Autoscaling,
+			&i.SleepAfter,
+			&i.SleptAt,
+			&i.SourceType,
+			&i.Image,
+			&i.RegistryCredentials,
+			&i.SourceType,
+			&i.Image,
+			&i.RegistryCredentials,
 		); err != nil {
 			return nil, err
 		}
@@ -453,6 +664,20 @@ func (q *Queries) ListServicesByUserID(ctx context.Context, arg ListServicesByUs
 	return items, nil
 }
 
+const markServiceSleeping = `-- name: MarkServiceSleeping :execrows
+UPDATE services
+SET slept_at = NOW()
+WHERE id = $1 AND slept_at IS NULL AND is_deleted = false
+`
+
+func (q *Queries) MarkServiceSleeping(ctx context.Context, id string) (int64, error) {
+	result, err := q.db.Exec(ctx, markServiceSleeping, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const setCurrentDeploymentID = `-- name: SetCurrentDeploymentID :exec
 UPDATE services
 SET current_deployment_id = $2, updated_at = NOW()
@@ -489,7 +714,7 @@ const softDeleteService = `-- name: SoftDeleteService :one
 UPDATE services
 SET is_deleted = true, updated_at = NOW()
 WHERE id = $1 AND is_deleted = false
-RETURNING id, user_id, project_id, repo, branch, git_provider, name, port, build_pack, env_vars, build_config, memory, vcpus, publish_directory, fqdn, custom_domain, server_uuid, current_deployment_id, is_deleted, created_at, updated_at, region
+RETURNING id, user_id, project_id, repo, branch, git_provider, name, port, build_pack, env_vars, build_config, memory, vcpus, publish_directory, fqdn, custom_domain, server_uuid, current_deployment_id, is_deleted, created_at, updated_at, region, replicas, autoscaling, sleep_after, slept_at, source_type, image, registry_credentials
 `
 
 func (q *Queries) SoftDeleteService(ctx context.Context, id string) (Service, error) {
@@ -518,6 +743,93 @@ func (q *Queries) SoftDeleteService(ctx context.Context, id string) (Service, er
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.Region,
+		&i.Replicas,
+		&i.Autoscaling,
+		&i.SleepAfter,
+		&i.SleptAt,
+		&i.SourceType,
+		&i.Image,
+		&i.RegistryCredentials,
+	)
+	return i, err
+}
+
+const updateServiceConfig = `-- name: UpdateServiceConfig :one
+UPDATE services
+SET branch = $2,
+    build_pack = $3,
+    port = $4,
+    env_vars = $5,
+    build_config = $6,
+    memory = $7,
+    vcpus = $8,
+    replicas = $9,
+    sleep_after = $10,
+    autoscaling = $11,
+    updated_at = NOW()
+WHERE id = $1 AND is_deleted = false
+RETURNING id, user_id, project_id, repo, branch, git_provider, name, port, build_pack, env_vars, build_config, memory, vcpus, publish_directory, fqdn, custom_domain, server_uuid, current_deployment_id, is_deleted, created_at, updated_at, region, replicas, autoscaling, sleep_after, slept_at, source_type, image, registry_credentials
+`
+
+type UpdateServiceConfigParams struct {
+	ID          string `json:"id"`
+	Branch      string `json:"branch"`
+	BuildPack   string `json:"build_pack"`
+	Port        string `json:"port"`
+	EnvVars     []byte `json:"env_vars"`
+	BuildConfig []byte `json:"build_config"`
+	Memory      string `json:"memory"`
+	Vcpus       string `json:"vcpus"`
+	Replicas    int32  `json:"replicas"`
+	SleepAfter  *int32 `json:"sleep_after"`
+	Autoscaling []byte `json:"autoscaling"`
+}
+
+func (q *Queries) UpdateServiceConfig(ctx context.Context, arg UpdateServiceConfigParams) (Service, error) {
+	row := q.db.QueryRow(ctx, updateServiceConfig,
+		arg.ID,
+		arg.Branch,
+		arg.BuildPack,
+		arg.Port,
+		arg.EnvVars,
+		arg.BuildConfig,
+		arg.Memory,
+		arg.Vcpus,
+		arg.Replicas,
+		arg.SleepAfter,
+		arg.Autoscaling,
+	)
+	var i Service
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.ProjectID,
+		&i.Repo,
+		&i.Branch,
+		&i.GitProvider,
+		&i.Name,
+		&i.Port,
+		&i.BuildPack,
+		&i.EnvVars,
+		&i.BuildConfig,
+		&i.Memory,
+		&i.Vcpus,
+		&i.PublishDirectory,
+		&i.Fqdn,
+		&i.CustomDomain,
+		&i.ServerUuid,
+		&i.CurrentDeploymentID,
+		&i.IsDeleted,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Region,
+		&i.Replicas,
+		&i.Autoscaling,
+		&i.SleepAfter,
+		&i.SleptAt,
+		&i.SourceType,
+		&i.Image,
+		&i.RegistryCredentials,
 	)
 	return i, err
 }
@@ -10,7 +10,7 @@ import (
 )
 
 const createFirebaseUser = `-- name: CreateFirebaseUser :one
-INSERT INTO users (id, email, display_name, avatar_url) VALUES ($1, $2, $3, $4) RETURNING id, github_id, email, firebase_uid, github_username, gitea_username, avatar_url, display_name, github_scopes, created_at, updated_at
+INSERT INTO users (id, email, display_name, avatar_url) VALUES ($1, $2, $3, $4) RETURNING id, github_id, email, firebase_uid, github_username, gitea_username, avatar_url, display_name, github_scopes, created_at, updated_at, max_replicas
 `
 
 type CreateFirebaseUserParams struct {
@@ -40,6 +40,7 @@ func (q *Queries) CreateFirebaseUser(ctx context.Context, arg CreateFirebaseUser
 		&i.GithubScopes,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.MaxReplicas,
 	)
 	return i, err
 }
@@ -47,7 +48,7 @@ func (q *Queries) CreateFirebaseUser(ctx context.Context, arg CreateFirebaseUser
 const createUser = `-- name: CreateUser :one
 INSERT INTO users (id, github_id, github_username, avatar_url)
 VALUES ($1, $2, $3, $4)
-RETURNING id, github_id, email, firebase_uid, github_username, gitea_username, avatar_url, display_name, github_scopes, created_at, updated_at
+RETURNING id, github_id, email, firebase_uid, github_username, gitea_username, avatar_url, display_name, github_scopes, created_at, updated_at, max_replicas
 `
 
 type CreateUserParams struct {
@@ -77,6 +78,7 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.GithubScopes,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.MaxReplicas,
 	)
 	return i, err
 }
@@ -91,7 +93,7 @@ func (q *Queries) DeleteUser(ctx context.Context, id string) error {
 }
 
 const getUserByGitHubID = `-- name: GetUserByGitHubID :one
-SELECT id, github_id, email, firebase_uid, github_username, gitea_username, avatar_url, display_name, github_scopes, created_at, updated_at FROM users WHERE github_id = $1
+SELECT id, github_id, email, firebase_uid, github_username, gitea_username, avatar_url, display_name, github_scopes, created_at, updated_at, max_replicas FROM users WHERE github_id = $1
 `
 
 func (q *Queries) GetUserByGitHubID(ctx context.Context, githubID *int64) (User, error) {
@@ -109,12 +111,13 @@ func (q *Queries) GetUserByGitHubID(ctx context.Context, githubID *int64) (User,
 		&i.GithubScopes,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.MaxReplicas,
 	)
 	return i, err
 }
 
 const getUserByGiteaUsername = `-- name: GetUserByGiteaUsername :one
-SELECT id, github_id, email, firebase_uid, github_username, gitea_username, avatar_url, display_name, github_scopes, created_at, updated_at FROM users WHERE gitea_username = $1
+SELECT id, github_id, email, firebase_uid, github_username, gitea_username, avatar_url, display_name, github_scopes, created_at, updated_at, max_replicas FROM users WHERE gitea_username = $1
 `
 
 func (q *Queries) GetUserByGiteaUsername(ctx context.Context, giteaUsername *string) (User, error) {
@@ -132,12 +135,13 @@ func (q *Queries) GetUserByGiteaUsername(ctx context.Context, giteaUsername *str
 		&i.GithubScopes,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.MaxReplicas,
 	)
 	return i, err
 }
 
 const getUserByID = `-- name: GetUserByID :one
-SELECT id, github_id, email, firebase_uid, github_username, gitea_username, avatar_url, display_name, github_scopes, created_at, updated_at FROM users WHERE id = $1
+SELECT id, github_id, email, firebase_uid, github_username, gitea_username, avatar_url, display_name, github_scopes, created_at, updated_at, max_replicas FROM users WHERE id = $1
 `
 
 func (q *Queries) GetUserByID(ctx context.Context, id string) (User, error) {
@@ -155,6 +159,7 @@ func (q *Queries) GetUserByID(ctx context.Context, id string) (User, error) {
 		&i.GithubScopes,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.MaxReplicas,
 	)
 	return i, err
 }
@@ -163,7 +168,7 @@ const linkGitHub = `-- name: LinkGitHub :one
 UPDATE users
 SET github_id = $2, github_username = $3, avatar_url = $4, updated_at = NOW()
 WHERE id = $1
-RETURNING id, github_id, email, firebase_uid, github_username, gitea_username, avatar_url, display_name, github_scopes, created_at, updated_at
+RETURNING id, github_id, email, firebase_uid, github_username, gitea_username, avatar_url, display_name, github_scopes, created_at, updated_at, max_replicas
 `
 
 type LinkGitHubParams struct {
@@ -193,6 +198,7 @@ func (q *Queries) LinkGitHub(ctx context.Context, arg LinkGitHubParams) (User, e
 		&i.GithubScopes,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.MaxReplicas,
 	)
 	return i, err
 }
@@ -201,7 +207,7 @@ const setGiteaUsername = `-- name: SetGiteaUsername :one
 UPDATE users
 SET gitea_username = $2, updated_at = NOW()
 WHERE id = $1
-RETURNING id, github_id, email, firebase_uid, github_username, gitea_username, avatar_url, display_name, github_scopes, created_at, updated_at
+RETURNING id, github_id, email, firebase_uid, github_username, gitea_username, avatar_url, display_name, github_scopes, created_at, updated_at, max_replicas
 `
 
 type SetGiteaUsernameParams struct {
@@ -224,6 +230,7 @@ func (q *Queries) SetGiteaUsername(ctx context.Context, arg SetGiteaUsernamePara
 		&i.GithubScopes,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.MaxReplicas,
 	)
 	return i, err
 }
@@ -232,7 +239,7 @@ const updateUserProfile = `-- name: UpdateUserProfile :one
 UPDATE users
 SET github_username = $2, avatar_url = $3, updated_at = NOW()
 WHERE id = $1
-RETURNING id, github_id, email, firebase_uid, github_username, gitea_username, avatar_url, display_name, github_scopes, created_at, updated_at
+RETURNING id, github_id, email, firebase_uid, github_username, gitea_username, avatar_url, display_name, github_scopes, created_at, updated_at, max_replicas
 `
 
 type UpdateUserProfileParams struct {
@@ -256,6 +263,7 @@ func (q *Queries) UpdateUserProfile(ctx context.Context, arg UpdateUserProfilePa
 		&i.GithubScopes,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.MaxReplicas,
 	)
 	return i, err
 }
@@ -13,6 +13,7 @@ type Querier interface {
 	Delete(ctx context.Context, id string) error
 	DeleteByServiceID(ctx context.Context, serviceID string) error
 	GetByZoneAndName(ctx context.Context, arg GetByZoneAndNameParams) (ZoneRecord, error)
+	ListByProjectID(ctx context.Context, projectID string) ([]ListByProjectIDRow, error)
 	ListByServiceID(ctx context.Context, serviceID string) ([]ZoneRecord, error)
 	ListByZoneID(ctx context.Context, zoneID string) ([]ZoneRecord, error)
 }
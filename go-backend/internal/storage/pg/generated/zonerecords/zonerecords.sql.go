@@ -7,6 +7,8 @@ package zonerecords
 
 import (
 	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 const create = `-- name: Create :one
@@ -74,6 +76,53 @@ func (q *Queries) GetByZoneAndName(ctx context.Context, arg GetByZoneAndNamePara
 	return i, err
 }
 
+const listByProjectID = `-- name: ListByProjectID :many
+SELECT zr.id, zr.zone_id, zr.service_id, zr.name, zr.created_at, dz.zone, s.name AS service_name
+FROM zone_records zr
+JOIN delegated_zones dz ON dz.id = zr.zone_id
+JOIN services s ON s.id = zr.service_id
+WHERE s.project_id = $1
+ORDER BY zr.created_at DESC
+`
+
+type ListByProjectIDRow struct {
+	ID          string             `json:"id"`
+	ZoneID      string             `json:"zone_id"`
+	ServiceID   string             `json:"service_id"`
+	Name        string             `json:"name"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	Zone        string             `json:"zone"`
+	ServiceName *string            `json:"service_name"`
+}
+
+func (q *Queries) ListByProjectID(ctx context.Context, projectID string) ([]ListByProjectIDRow, error) {
+	rows, err := q.db.Query(ctx, listByProjectID, projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListByProjectIDRow{}
+	for rows.Next() {
+		var i ListByProjectIDRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ZoneID,
+			&i.ServiceID,
+			&i.Name,
+			&i.CreatedAt,
+			&i.Zone,
+			&i.ServiceName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listByServiceID = `-- name: ListByServiceID :many
 SELECT id, zone_id, service_id, name, created_at FROM zone_records
 WHERE service_id = $1
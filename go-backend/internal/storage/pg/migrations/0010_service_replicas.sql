@@ -0,0 +1,13 @@
+-- +goose Up
+
+ALTER TABLE services ADD COLUMN replicas INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE deployments ADD COLUMN replicas INTEGER NOT NULL DEFAULT 1;
+
+-- Per-user cap on replicas for a single service
+ALTER TABLE users ADD COLUMN max_replicas INTEGER NOT NULL DEFAULT 3;
+
+-- +goose Down
+
+ALTER TABLE users DROP COLUMN IF EXISTS max_replicas;
+ALTER TABLE deployments DROP COLUMN IF EXISTS replicas;
+ALTER TABLE services DROP COLUMN IF EXISTS replicas;
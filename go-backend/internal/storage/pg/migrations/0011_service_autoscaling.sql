@@ -0,0 +1,11 @@
+-- +goose Up
+
+-- NULL means fixed replicas; otherwise {min_replicas, max_replicas,
+-- target_cpu_utilization, target_memory_utilization}.
+ALTER TABLE services ADD COLUMN autoscaling JSONB;
+ALTER TABLE deployments ADD COLUMN autoscaling JSONB;
+
+-- +goose Down
+
+ALTER TABLE deployments DROP COLUMN IF EXISTS autoscaling;
+ALTER TABLE services DROP COLUMN IF EXISTS autoscaling;
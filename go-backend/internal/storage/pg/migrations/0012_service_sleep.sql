@@ -0,0 +1,14 @@
+-- +goose Up
+
+-- Seconds without inbound traffic before a service is scaled to zero; NULL
+-- keeps it running. slept_at is set while the service is scaled down.
+ALTER TABLE services ADD COLUMN sleep_after INTEGER;
+ALTER TABLE services ADD COLUMN slept_at TIMESTAMPTZ;
+
+CREATE INDEX idx_services_sleep_after ON services(region) WHERE sleep_after IS NOT NULL AND is_deleted = false;
+
+-- +goose Down
+
+DROP INDEX IF EXISTS idx_services_sleep_after;
+ALTER TABLE services DROP COLUMN IF EXISTS slept_at;
+ALTER TABLE services DROP COLUMN IF EXISTS sleep_after;
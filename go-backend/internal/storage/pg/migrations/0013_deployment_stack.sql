@@ -0,0 +1,9 @@
+-- +goose Up
+
+-- Resolved components of a dockercompose deployment: each compose service's
+-- image, ports, environment and command. NULL for single-image build packs.
+ALTER TABLE deployments ADD COLUMN stack JSONB;
+
+-- +goose Down
+
+ALTER TABLE deployments DROP COLUMN IF EXISTS stack;
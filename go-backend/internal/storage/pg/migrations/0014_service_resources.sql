@@ -0,0 +1,19 @@
+-- +goose Up
+
+-- Resources a service is bound to. Each binding's credentials are injected
+-- into the service's env at deploy time, as DATABASE_URL and friends behind
+-- env_prefix, so a rotated or deleted resource reaches every bound service on
+-- its next deploy.
+CREATE TABLE service_resources (
+    service_id TEXT NOT NULL REFERENCES services(id) ON DELETE CASCADE,
+    resource_id TEXT NOT NULL REFERENCES resources(id) ON DELETE CASCADE,
+    env_prefix TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (service_id, resource_id)
+);
+
+CREATE INDEX idx_service_resources_resource_id ON service_resources(resource_id);
+
+-- +goose Down
+
+DROP TABLE IF EXISTS service_resources;
@@ -0,0 +1,19 @@
+-- +goose Up
+
+-- Latest usage of each sqlite resource as Turso reports it, refreshed by the
+-- usage workflow. size_limit_bytes is the size declared at creation;
+-- writes_blocked records that writes were blocked for exceeding it, so they
+-- can be unblocked once the database is back under.
+CREATE TABLE resource_usage (
+    resource_id TEXT PRIMARY KEY REFERENCES resources(id) ON DELETE CASCADE,
+    storage_bytes BIGINT NOT NULL DEFAULT 0,
+    rows_read BIGINT NOT NULL DEFAULT 0,
+    rows_written BIGINT NOT NULL DEFAULT 0,
+    size_limit_bytes BIGINT NOT NULL DEFAULT 0,
+    writes_blocked BOOLEAN NOT NULL DEFAULT false,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- +goose Down
+
+DROP TABLE IF EXISTS resource_usage;
@@ -0,0 +1,10 @@
+-- +goose Up
+
+-- Resource names are unique per project, like service names, so each
+-- project can have its own "db". Names were unique per user until now, so
+-- existing rows already satisfy this.
+CREATE UNIQUE INDEX idx_resources_project_name ON resources(project_id, name);
+
+-- +goose Down
+
+DROP INDEX IF EXISTS idx_resources_project_name;
@@ -0,0 +1,15 @@
+-- +goose Up
+
+-- A service is built from a git repo or deployed from a prebuilt image.
+-- Image services keep the reference as given (usually a tag) and pin each
+-- deployment to the digest it resolved to. registry_credentials holds the
+-- encrypted login for a private registry.
+ALTER TABLE services ADD COLUMN source_type TEXT NOT NULL DEFAULT 'git';
+ALTER TABLE services ADD COLUMN image TEXT;
+ALTER TABLE services ADD COLUMN registry_credentials BYTEA;
+
+-- +goose Down
+
+ALTER TABLE services DROP COLUMN IF EXISTS registry_credentials;
+ALTER TABLE services DROP COLUMN IF EXISTS image;
+ALTER TABLE services DROP COLUMN IF EXISTS source_type;
@@ -0,0 +1,24 @@
+-- +goose Up
+
+-- Services deployed from an uploaded source tarball instead of a git repo
+-- (source_type 'upload'). The git server keeps each archive on its volume
+-- under the service's ID, named by its sha256 digest, which stands in for
+-- the commit SHA when it's built.
+CREATE TABLE source_uploads (
+    id TEXT PRIMARY KEY DEFAULT gen_random_uuid()::TEXT,
+    service_id TEXT NOT NULL REFERENCES services(id) ON DELETE CASCADE,
+    digest TEXT NOT NULL,
+    size_bytes BIGINT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (service_id, digest)
+);
+CREATE INDEX idx_source_uploads_service_id ON source_uploads(service_id, created_at DESC);
+
+-- Upload tokens are short-lived git tokens with the upload scope, bound to
+-- the service they deploy.
+ALTER TABLE git_tokens ADD COLUMN service_id TEXT REFERENCES services(id) ON DELETE CASCADE;
+
+-- +goose Down
+
+ALTER TABLE git_tokens DROP COLUMN IF EXISTS service_id;
+DROP TABLE IF EXISTS source_uploads;
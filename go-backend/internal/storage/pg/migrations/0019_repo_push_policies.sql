@@ -0,0 +1,16 @@
+-- +goose Up
+
+-- Push policies of internal repos, enforced by the git server's pre-receive
+-- hook. Protected branches refuse deletes and non-fast-forward pushes; the
+-- byte limits override the server's defaults when set. Existing repos keep
+-- accepting force pushes, new ones protect main.
+ALTER TABLE internal_repos ADD COLUMN protected_branches TEXT[] NOT NULL DEFAULT '{}';
+ALTER TABLE internal_repos ALTER COLUMN protected_branches SET DEFAULT '{main}';
+ALTER TABLE internal_repos ADD COLUMN max_pack_bytes BIGINT;
+ALTER TABLE internal_repos ADD COLUMN max_file_bytes BIGINT;
+
+-- +goose Down
+
+ALTER TABLE internal_repos DROP COLUMN IF EXISTS max_file_bytes;
+ALTER TABLE internal_repos DROP COLUMN IF EXISTS max_pack_bytes;
+ALTER TABLE internal_repos DROP COLUMN IF EXISTS protected_branches;
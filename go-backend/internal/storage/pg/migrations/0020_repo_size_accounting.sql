@@ -0,0 +1,12 @@
+-- +goose Up
+
+-- On-disk size of each internal repo's bare directory, measured by the git
+-- server after every push and garbage collection. Quotas are checked
+-- against it and against the sum over a user's repos.
+ALTER TABLE internal_repos ADD COLUMN size_bytes BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE internal_repos ADD COLUMN size_updated_at TIMESTAMPTZ;
+
+-- +goose Down
+
+ALTER TABLE internal_repos DROP COLUMN IF EXISTS size_updated_at;
+ALTER TABLE internal_repos DROP COLUMN IF EXISTS size_bytes;
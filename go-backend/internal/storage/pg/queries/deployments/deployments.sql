@@ -1,9 +1,9 @@
 -- name: CreateDeployment :one
 INSERT INTO deployments (
     id, service_id, workflow_id, build_pack, build_config, env_vars_snapshot,
-    memory, vcpus, port, trigger, trigger_ref, commit_hash
+    memory, vcpus, port, trigger, trigger_ref, commit_hash, replicas, autoscaling
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14
 )
 RETURNING *;
 
@@ -16,9 +16,16 @@ SELECT * FROM deployments WHERE workflow_id = $1;
 -- name: ListDeploymentsByServiceID :many
 SELECT * FROM deployments
 WHERE service_id = $1
-ORDER BY created_at DESC
+ORDER BY created_at DESC, id DESC
 LIMIT $2 OFFSET $3;
 
+-- name: ListDeploymentsByServiceIDAfter :many
+SELECT d.* FROM deployments d
+WHERE d.service_id = $1
+  AND (d.created_at, d.id) < (SELECT c.created_at, c.id FROM deployments c WHERE c.id = $2 AND c.service_id = $1)
+ORDER BY d.created_at DESC, d.id DESC
+LIMIT $3;
+
 -- name: GetActiveDeploymentByServiceID :one
 SELECT * FROM deployments
 WHERE service_id = $1 AND status = 'active';
@@ -29,6 +36,12 @@ WHERE service_id = $1
 ORDER BY created_at DESC
 LIMIT 1;
 
+-- name: GetPreviousDeploymentByServiceID :one
+SELECT * FROM deployments
+WHERE service_id = $1 AND status = 'superseded' AND image_ref IS NOT NULL
+ORDER BY finished_at DESC, created_at DESC
+LIMIT 1;
+
 -- name: UpdateDeploymentBuilding :exec
 UPDATE deployments
 SET status = 'building', started_at = NOW(), updated_at = NOW()
@@ -36,12 +49,15 @@ WHERE id = $1;
 
 -- name: UpdateDeploymentDeploying :exec
 UPDATE deployments
-SET status = 'deploying', updated_at = NOW()
+SET status = 'deploying', started_at = COALESCE(started_at, NOW()), updated_at = NOW()
 WHERE id = $1;
 
 -- name: MarkDeploymentActive :exec
 UPDATE deployments
-SET status = 'active', commit_hash = $2, image_ref = $3, finished_at = NOW(), updated_at = NOW()
+SET status = 'active', commit_hash = $2, image_ref = $3,
+    port = CASE WHEN port = '' THEN sqlc.arg(resolved_port)::TEXT ELSE port END,
+    stack = $5,
+    finished_at = NOW(), updated_at = NOW()
 WHERE id = $1;
 
 -- name: MarkDeploymentFailed :exec
@@ -49,10 +65,10 @@ UPDATE deployments
 SET status = 'failed', error_message = $2, finished_at = NOW(), updated_at = NOW()
 WHERE id = $1;
 
--- name: MarkDeploymentCancelled :exec
+-- name: MarkDeploymentCancelled :execrows
 UPDATE deployments
 SET status = 'cancelled', finished_at = NOW(), updated_at = NOW()
-WHERE id = $1;
+WHERE id = $1 AND status IN ('queued', 'building', 'deploying');
 
 -- name: SupersedeActiveDeployment :exec
 UPDATE deployments
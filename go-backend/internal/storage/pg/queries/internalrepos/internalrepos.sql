@@ -12,6 +12,11 @@ SELECT * FROM internal_repos WHERE full_name = $1;
 -- name: GetInternalRepoByProjectAndName :one
 SELECT * FROM internal_repos WHERE project_id = $1 AND name = $2;
 
+-- name: ListInternalReposByProjectID :many
+SELECT * FROM internal_repos
+WHERE project_id = $1
+ORDER BY created_at DESC;
+
 -- name: ListInternalReposByUserID :many
 SELECT * FROM internal_repos
 WHERE user_id = $1
@@ -22,3 +27,25 @@ DELETE FROM internal_repos WHERE id = $1;
 
 -- name: DeleteInternalRepoByFullName :exec
 DELETE FROM internal_repos WHERE full_name = $1;
+
+-- name: UpdateInternalRepoPolicy :one
+UPDATE internal_repos
+SET protected_branches = $2,
+    max_pack_bytes = $3,
+    max_file_bytes = $4,
+    updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: GetUserRepoUsage :one
+-- Total size of a user's internal repos.
+SELECT COALESCE(SUM(size_bytes), 0)::BIGINT AS total_bytes
+FROM internal_repos
+WHERE user_id = $1;
+
+-- name: UpdateInternalRepoSize :exec
+-- Repos are measured on disk, where the git server knows them by full name.
+UPDATE internal_repos
+SET size_bytes = $2,
+    size_updated_at = NOW()
+WHERE full_name = $1;
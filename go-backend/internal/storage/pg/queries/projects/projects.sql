@@ -23,6 +23,14 @@ WHERE user_id = $1
 ORDER BY created_at DESC
 LIMIT $2 OFFSET $3;
 
+-- name: CountProjectWorkloads :one
+-- CountProjectWorkloads counts what runs in the project's namespace: its
+-- live services and in-cluster resources.
+SELECT (
+    (SELECT COUNT(*) FROM services s WHERE s.project_id = $1 AND s.is_deleted = false)
+    + (SELECT COUNT(*) FROM resources r WHERE r.project_id = $1 AND r.provider = 'cluster')
+)::BIGINT AS workloads;
+
 -- name: CountProjectsByUserID :one
 SELECT COUNT(*) FROM projects WHERE user_id = $1;
 
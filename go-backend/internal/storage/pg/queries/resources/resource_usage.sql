@@ -0,0 +1,27 @@
+-- name: UpsertResourceUsage :exec
+INSERT INTO resource_usage (
+    resource_id, storage_bytes, rows_read, rows_written, size_limit_bytes, writes_blocked, updated_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, NOW()
+)
+ON CONFLICT (resource_id) DO UPDATE
+SET storage_bytes = EXCLUDED.storage_bytes,
+    rows_read = EXCLUDED.rows_read,
+    rows_written = EXCLUDED.rows_written,
+    size_limit_bytes = EXCLUDED.size_limit_bytes,
+    writes_blocked = EXCLUDED.writes_blocked,
+    updated_at = NOW();
+
+-- name: GetResourceUsage :one
+SELECT * FROM resource_usage WHERE resource_id = $1;
+
+-- name: ListResourceUsageByUser :many
+SELECT u.*
+FROM resource_usage u
+JOIN resources r ON r.id = u.resource_id
+WHERE r.user_id = $1;
+
+-- name: ListActiveResourcesByProvider :many
+SELECT * FROM resources
+WHERE provider = $1 AND status = 'active'
+ORDER BY created_at;
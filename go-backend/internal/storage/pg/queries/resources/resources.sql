@@ -9,8 +9,8 @@ RETURNING *;
 -- name: GetResourceByID :one
 SELECT * FROM resources WHERE id = $1;
 
--- name: GetResourceByUserAndName :one
-SELECT * FROM resources WHERE user_id = $1 AND name = $2;
+-- name: GetResourceByProjectAndName :one
+SELECT * FROM resources WHERE project_id = $1 AND name = $2;
 
 -- name: ListResourcesByUser :many
 SELECT * FROM resources
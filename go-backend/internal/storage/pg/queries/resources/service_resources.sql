@@ -0,0 +1,12 @@
+-- name: ListServiceIDsByResource :many
+-- Services bound to the resource or referencing it by name in their env vars,
+-- which only resolve within the service's project.
+SELECT s.id
+FROM services s
+JOIN resources r ON r.id = $1
+WHERE s.is_deleted = false
+  AND (
+    EXISTS (SELECT 1 FROM service_resources sr WHERE sr.service_id = s.id AND sr.resource_id = r.id)
+    OR (s.project_id = r.project_id AND s.env_vars::text ~ ('\$\{\{\s*resources\.' || r.name || '\.'))
+  )
+ORDER BY s.created_at;
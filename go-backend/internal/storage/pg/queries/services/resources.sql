@@ -0,0 +1,9 @@
+-- name: SetResourceCredentials :exec
+UPDATE resources
+SET credentials = $2, metadata = COALESCE(metadata, '{}'::jsonb) || sqlc.arg(metadata)::jsonb, updated_at = NOW()
+WHERE id = $1;
+
+-- name: SetResourceStatus :exec
+UPDATE resources
+SET status = $2, updated_at = NOW()
+WHERE id = $1;
@@ -0,0 +1,16 @@
+-- name: CreateServiceResource :exec
+INSERT INTO service_resources (service_id, resource_id, env_prefix)
+VALUES ($1, $2, $3)
+ON CONFLICT (service_id, resource_id) DO UPDATE SET env_prefix = EXCLUDED.env_prefix;
+
+-- name: ListServiceResources :many
+SELECT r.id, r.name, r.type, r.status, r.credentials, sr.env_prefix
+FROM service_resources sr
+JOIN resources r ON r.id = sr.resource_id
+WHERE sr.service_id = $1
+ORDER BY sr.created_at, r.name;
+
+-- name: GetReferencedResource :one
+SELECT id, name, status, credentials
+FROM resources
+WHERE project_id = $1 AND name = $2;
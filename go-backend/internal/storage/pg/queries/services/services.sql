@@ -1,8 +1,8 @@
 -- name: CreateService :one
 INSERT INTO services (
-    id, user_id, project_id, repo, branch, server_uuid, name, build_pack, port, env_vars, git_provider, build_config, memory, vcpus, region
+    id, user_id, project_id, repo, branch, server_uuid, name, build_pack, port, env_vars, git_provider, build_config, memory, vcpus, region, replicas, autoscaling, sleep_after, source_type, image, registry_credentials
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21
 )
 RETURNING *;
 
@@ -67,3 +67,57 @@ WHERE id = $1 AND is_deleted = false;
 UPDATE services
 SET fqdn = $2, updated_at = NOW()
 WHERE id = $1 AND is_deleted = false;
+
+-- name: UpdateServiceConfig :one
+UPDATE services
+SET branch = $2,
+    build_pack = $3,
+    port = $4,
+    env_vars = $5,
+    build_config = $6,
+    memory = $7,
+    vcpus = $8,
+    replicas = $9,
+    sleep_after = $10,
+    autoscaling = $11,
+    updated_at = NOW()
+WHERE id = $1 AND is_deleted = false
+RETURNING *;
+
+-- name: ListSleepCandidates :many
+SELECT s.* FROM services s
+WHERE s.region = $1
+  AND s.sleep_after IS NOT NULL
+  AND s.slept_at IS NULL
+  AND s.autoscaling IS NULL
+  AND s.current_deployment_id IS NOT NULL
+  AND s.is_deleted = false
+  AND NOT EXISTS (
+    SELECT 1 FROM deployments d
+    WHERE d.service_id = s.id AND d.status IN ('queued','building','deploying')
+  )
+ORDER BY s.id;
+
+-- name: MarkServiceSleeping :execrows
+UPDATE services
+SET slept_at = NOW()
+WHERE id = $1 AND slept_at IS NULL AND is_deleted = false;
+
+-- name: ClearServiceSleeping :exec
+UPDATE services
+SET slept_at = NULL
+WHERE id = $1;
+
+-- name: GetServiceByHost :one
+SELECT s.* FROM services s
+WHERE s.is_deleted = false
+  AND (
+    lower(s.fqdn) = 'https://' || lower(sqlc.arg(host)::TEXT)
+    OR EXISTS (
+      SELECT 1 FROM zone_records zr
+      JOIN delegated_zones dz ON dz.id = zr.zone_id
+      WHERE zr.service_id = s.id
+        AND lower(CASE WHEN zr.name = '@' THEN dz.zone ELSE zr.name || '.' || dz.zone END) = lower(sqlc.arg(host)::TEXT)
+    )
+  )
+LIMIT 1;
@@ -0,0 +1,21 @@
+-- name: UpsertSourceUpload :one
+INSERT INTO source_uploads (service_id, digest, size_bytes)
+VALUES ($1, $2, $3)
+ON CONFLICT (service_id, digest) DO UPDATE SET created_at = NOW()
+RETURNING *;
+
+-- name: GetLatestSourceUpload :one
+SELECT * FROM source_uploads
+WHERE service_id = $1
+ORDER BY created_at DESC
+LIMIT 1;
+
+-- name: ListStaleSourceUploads :many
+-- Every upload of the service but the newest $2.
+SELECT * FROM source_uploads
+WHERE service_id = $1
+ORDER BY created_at DESC
+OFFSET $2;
+
+-- name: DeleteSourceUpload :exec
+DELETE FROM source_uploads WHERE id = $1;
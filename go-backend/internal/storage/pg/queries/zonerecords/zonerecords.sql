@@ -14,6 +14,14 @@ SELECT * FROM zone_records
 WHERE zone_id = $1
 ORDER BY created_at DESC;
 
+-- name: ListByProjectID :many
+SELECT zr.*, dz.zone, s.name AS service_name
+FROM zone_records zr
+JOIN delegated_zones dz ON dz.id = zr.zone_id
+JOIN services s ON s.id = zr.service_id
+WHERE s.project_id = $1
+ORDER BY zr.created_at DESC;
+
 -- name: ListByServiceID :many
 SELECT * FROM zone_records
 WHERE service_id = $1
@@ -63,6 +63,9 @@ func (c *Client) CreateDatabase(ctx context.Context, req *CreateDatabaseRequest)
 	path := fmt.Sprintf("/organizations/%s/databases", c.config.OrgSlug)
 
 	c.logger.Info("creating turso database", "name", req.Name, "group", req.Group, "size_limit", req.SizeLimit)
+	if req.Seed != nil {
+		c.logger.Info("seeding turso database", "name", req.Name, "seed_type", req.Seed.Type, "seed_name", req.Seed.Name, "timestamp", req.Seed.Timestamp)
+	}
 
 	respBody, err := c.doRequest(ctx, "POST", path, req)
 	if err != nil {
@@ -111,3 +114,58 @@ func (c *Client) DeleteDatabase(ctx context.Context, dbName string) error {
 
 	return nil
 }
+
+func (c *Client) GetDatabaseUsage(ctx context.Context, dbName string) (*DatabaseUsage, error) {
+	path := fmt.Sprintf("/organizations/%s/databases/%s/usage", c.config.OrgSlug, dbName)
+
+	respBody, err := c.doRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database usage: %w", err)
+	}
+
+	var resp struct {
+		Database struct {
+			Usage DatabaseUsage `json:"usage"`
+		} `json:"database"`
+	}
+	if err := json.Unmarshal(respBody, &resp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return &resp.Database.Usage, nil
+}
+
+// GetDatabaseConfiguration returns a database's current settings, whether
+// its writes are blocked among them.
+func (c *Client) GetDatabaseConfiguration(ctx context.Context, dbName string) (*DatabaseConfiguration, error) {
+	path := fmt.Sprintf("/organizations/%s/databases/%s/configuration", c.config.OrgSlug, dbName)
+
+	respBody, err := c.doRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database configuration: %w", err)
+	}
+
+	var config DatabaseConfiguration
+	if err := json.Unmarshal(respBody, &config); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return &config, nil
+}
+
+func (c *Client) UpdateDatabaseConfiguration(ctx context.Context, dbName string, config *DatabaseConfiguration) error {
+	path := fmt.Sprintf("/organizations/%s/databases/%s/configuration", c.config.OrgSlug, dbName)
+
+	c.logger.Info("updating turso database configuration", "name", dbName)
+
+	if _, err := c.doRequest(ctx, "PATCH", path, config); err != nil {
+		return fmt.Errorf("failed to update database configuration: %w", err)
+	}
+
+	return nil
+}
+
+// SetBlockWrites makes a database read-only, or writable again.
+func (c *Client) SetBlockWrites(ctx context.Context, dbName string, block bool) error {
+	return c.UpdateDatabaseConfiguration(ctx, dbName, &DatabaseConfiguration{BlockWrites: &block})
+}
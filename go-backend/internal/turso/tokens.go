@@ -39,3 +39,19 @@ func (c *Client) CreateReadOnlyToken(ctx context.Context, dbName string, expirat
 		Expiration:    expiration,
 	})
 }
+
+// RotateAuthTokens invalidates every token issued for the database so far,
+// read-only ones included.
+func (c *Client) RotateAuthTokens(ctx context.Context, dbName string) error {
+	path := fmt.Sprintf("/organizations/%s/databases/%s/auth/rotate", c.config.OrgSlug, dbName)
+
+	c.logger.Info("rotating turso auth tokens", "database", dbName)
+
+	if _, err := c.doRequest(ctx, "POST", path, nil); err != nil {
+		return fmt.Errorf("failed to rotate auth tokens: %w", err)
+	}
+
+	c.logger.Info("turso auth tokens rotated", "database", dbName)
+
+	return nil
+}
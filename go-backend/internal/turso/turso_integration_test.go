@@ -129,6 +129,46 @@ func TestCreateDatabaseWithReadOnlyToken(t *testing.T) {
 	t.Logf("Read-only token created (length: %d)", len(token))
 }
 
+func TestRotateAuthTokens(t *testing.T) {
+	config := getTestConfig()
+	if config == nil {
+		t.Skip("TURSO_APIKEY and TURSO_ORGSLUG not set")
+	}
+
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	client := turso.NewClient(*config, logger)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	dbName := fmt.Sprintf("test-rotate-%d", time.Now().UnixNano())
+
+	if _, err := client.CreateDatabase(ctx, &turso.CreateDatabaseRequest{
+		Name:  dbName,
+		Group: "eu-west",
+	}); err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+
+	defer func() {
+		if delErr := client.DeleteDatabase(ctx, dbName); delErr != nil {
+			t.Logf("Cleanup failed: %v", delErr)
+		}
+	}()
+
+	if err := client.RotateAuthTokens(ctx, dbName); err != nil {
+		t.Fatalf("Failed to rotate auth tokens: %v", err)
+	}
+
+	token, err := client.CreateAuthToken(ctx, dbName, nil)
+	if err != nil {
+		t.Fatalf("Failed to create auth token after rotation: %v", err)
+	}
+	if token == "" {
+		t.Error("Expected token to be non-empty")
+	}
+}
+
 func TestListGroups(t *testing.T) {
 	config := getTestConfig()
 	if config == nil {
@@ -4,8 +4,25 @@ type CreateDatabaseRequest struct {
 	Name      string `json:"name"`
 	Group     string `json:"group"`
 	SizeLimit string `json:"size_limit,omitempty"`
+	Seed      *Seed  `json:"seed,omitempty"`
 }
 
+// Seed creates a database from existing data rather than empty. With
+// Type "database" it copies the database Name, as of Timestamp (RFC 3339)
+// when set and as it is now otherwise; with Type "dump" it loads the SQL
+// dump at URL.
+type Seed struct {
+	Type      string `json:"type"`
+	Name      string `json:"name,omitempty"`
+	URL       string `json:"url,omitempty"`
+	Timestamp string `json:"timestamp,omitempty"`
+}
+
+const (
+	SeedDatabase = "database"
+	SeedDump     = "dump"
+)
+
 type CreateDatabaseResponse struct {
 	Database Database `json:"database"`
 }
@@ -54,3 +71,19 @@ func ValidRegions() []string {
 	}
 	return regions
 }
+
+// DatabaseUsage is what a database has used over the current usage period,
+// apart from StorageBytes, which is its size now.
+type DatabaseUsage struct {
+	RowsRead     int64 `json:"rows_read"`
+	RowsWritten  int64 `json:"rows_written"`
+	StorageBytes int64 `json:"storage_bytes"`
+}
+
+// DatabaseConfiguration changes a database's settings; unset fields are
+// left as they are.
+type DatabaseConfiguration struct {
+	SizeLimit   string `json:"size_limit,omitempty"`
+	BlockReads  *bool  `json:"block_reads,omitempty"`
+	BlockWrites *bool  `json:"block_writes,omitempty"`
+}